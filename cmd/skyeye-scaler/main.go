@@ -96,7 +96,7 @@ func run() error {
 		log.Fatal().Msg("stop-delay must be shorter than scale-interval")
 	}
 
-	parsedFrequencies := cli.LoadFrequencies(srsFrequencies)
+	parsedFrequencies := cli.LoadFrequencies(srsFrequencies, simpleradio.RoleTactical)
 	radios := make([]srstypes.Radio, 0, len(parsedFrequencies))
 	for _, radioFrequency := range parsedFrequencies {
 		radios = append(radios, srstypes.Radio{
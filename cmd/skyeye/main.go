@@ -11,6 +11,7 @@ import (
 	"reflect"
 	"runtime"
 	"runtime/pprof"
+	"slices"
 	"strings"
 	"sync"
 	"syscall"
@@ -29,8 +30,10 @@ import (
 	"github.com/dharmab/skyeye/internal/cli"
 	"github.com/dharmab/skyeye/internal/conf"
 	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/elevation"
 	"github.com/dharmab/skyeye/pkg/encyclopedia"
 	"github.com/dharmab/skyeye/pkg/locations"
+	"github.com/dharmab/skyeye/pkg/simpleradio"
 	"github.com/dharmab/skyeye/pkg/synthesizer/voices"
 	"github.com/ggerganov/whisper.cpp/bindings/go/pkg/whisper"
 )
@@ -42,13 +45,20 @@ var (
 	logFormat                    string
 	enableTranscriptionLogging   bool
 	acmiFile                     string
+	acmiFileStart                time.Duration
+	acmiFileEnd                  time.Duration
+	acmiFileTimeScale            float64
 	telemetryAddress             string
 	telemetryConnectionTimeout   time.Duration
 	telemetryPassword            string
+	telemetryRecordingDirectory  string
+	grpcTelemetry                bool
 	srsAddress                   string
 	srsConnectionTimeout         time.Duration
 	srsExternalAWACSModePassword string
 	srsFrequencies               []string
+	srsBroadcastFrequencies      []string
+	srsGuardFrequencies          []string
 	enableGRPC                   bool
 	grpcAddress                  string
 	grpcAPIKey                   string
@@ -75,13 +85,19 @@ var (
 	mandatoryThreatRadiusNM      float64
 	threatBRAABearingSpreadDeg   float64
 	threatBRAARangeSpreadNM      float64
+	popUpRadiusNM                float64
 	enableTracing                bool
 	discordWebhookID             string
 	discordWebhookToken          string
 	exitAfter                    time.Duration
 	enableTerrainDetection       bool
 	locationsFile                string
+	leakerLinesFile              string
 	aircraftFile                 string
+	enableSensorModel            bool
+	radarSitesFile               string
+	terrainElevationFile         string
+	awacsUnit                    string
 )
 
 const (
@@ -103,22 +119,33 @@ func init() {
 
 	// Telemetry
 	skyeye.Flags().StringVar(&acmiFile, "acmi-file", "", "path to ACMI file")
+	skyeye.Flags().DurationVar(&acmiFileStart, "acmi-file-start", 0, "Offset into the ACMI file at which to begin replaying. Earlier data is read as fast as possible")
+	skyeye.Flags().DurationVar(&acmiFileEnd, "acmi-file-end", 0, "Offset into the ACMI file at which to pause the replay. The entire file is replayed if not set")
+	skyeye.Flags().Float64Var(&acmiFileTimeScale, "acmi-file-time-scale", 1.0, "ACMI file replay speed relative to real time. Set to 0 to replay as fast as possible")
 	skyeye.Flags().StringVar(&telemetryAddress, "telemetry-address", "localhost:42674", "Address of the real-time telemetry service")
 	skyeye.MarkFlagsMutuallyExclusive("acmi-file", "telemetry-address")
 	skyeye.Flags().DurationVar(&telemetryConnectionTimeout, "telemetry-connection-timeout", 10*time.Second, "Connection timeout for real-time telemetry client")
 	skyeye.Flags().StringVar(&telemetryPassword, "telemetry-password", "", "Password for the real-time telemetry service")
 	skyeye.Flags().DurationVar(&telemetryUpdateInterval, "telemetry-update-interval", 2*time.Second, "Interval at which trackfiles are updated from telemetry data")
+	skyeye.Flags().StringVar(&telemetryRecordingDirectory, "telemetry-recording-directory", "", "Directory in which to record real-time telemetry to ACMI files. Telemetry is not recorded if not set")
+	skyeye.MarkFlagsMutuallyExclusive("acmi-file", "telemetry-recording-directory")
 
 	// SRS
 	skyeye.Flags().StringVar(&srsAddress, "srs-server-address", "localhost:5002", "Address of the SRS server")
 	skyeye.Flags().DurationVar(&srsConnectionTimeout, "srs-connection-timeout", 10*time.Second, "Connection timeout for SRS client")
 	skyeye.Flags().StringVar(&srsExternalAWACSModePassword, "srs-eam-password", "", "SRS external AWACS mode password")
 	skyeye.Flags().StringSliceVar(&srsFrequencies, "srs-frequencies", []string{"251.0AM", "133.0AM", "30.0FM"}, "List of SRS frequencies to use")
+	skyeye.Flags().StringSliceVar(&srsBroadcastFrequencies, "srs-broadcast-frequencies", []string{}, "List of SRS frequencies used only for broadcasts such as automatic PICTURE and THREAT calls")
+	skyeye.Flags().StringSliceVar(&srsGuardFrequencies, "srs-guard-frequencies", []string{}, "List of SRS frequencies used only for emergency calls such as MISSILE and POP-UP calls")
 
 	// DCS-gRPC
 	skyeye.Flags().BoolVar(&enableGRPC, "enable-grpc", false, "Enable DCS-gRPC features")
 	skyeye.Flags().StringVar(&grpcAddress, "grpc-address", "localhost:50051", "Address of the DCS-gRPC server")
 	skyeye.Flags().StringVar(&grpcAPIKey, "grpc-api-key", "", "API key for DCS-gRPC authentication")
+	skyeye.Flags().BoolVar(&grpcTelemetry, "grpc-telemetry", false, "Use DCS-gRPC as the telemetry source instead of TacView. Requires --enable-grpc")
+	skyeye.MarkFlagsMutuallyExclusive("acmi-file", "grpc-telemetry")
+	skyeye.MarkFlagsMutuallyExclusive("telemetry-address", "grpc-telemetry")
+	skyeye.MarkFlagsMutuallyExclusive("telemetry-recording-directory", "grpc-telemetry")
 
 	// Identity
 	skyeye.Flags().StringVar(&controllerCallsign, "callsign", "", "GCI callsign used in radio transmissions. Automatically chosen if not provided")
@@ -160,15 +187,32 @@ func init() {
 	skyeye.Flags().Float64Var(&threatBRAABearingSpreadDeg, "threat-braa-bearing-spread", 5, "Bearing spread threshold for THREAT call BRAA-vs-bullseye decision, in degrees")
 	skyeye.Flags().Float64Var(&threatBRAARangeSpreadNM, "threat-braa-range-spread", 1, "Range spread threshold for THREAT call BRAA-vs-bullseye decision, in nautical miles")
 	skyeye.Flags().BoolVar(&threatMonitoringRequiresSRS, "threat-monitoring-requires-srs", true, "Require aircraft to be on SRS to receive THREAT calls. Only useful to disable when debugging")
+	skyeye.Flags().Float64Var(&popUpRadiusNM, "pop-up-radius", 30, "Radius around friendly aircraft for POP-UP calls, in nautical miles. Set to 0 to disable POP-UP calls")
 	skyeye.Flags().StringVar(&locationsFile, "locations-file", "", "Path to file containing additional locations that may be referenced in VECTOR calls.")
 	if err := skyeye.MarkFlagFilename("locations-file", "json", "yaml", "yml"); err != nil {
 		log.Fatal().Err(err).Msg("failed to mark flag as filename")
 	}
+	skyeye.Flags().StringVar(&leakerLinesFile, "leaker-lines-file", "", "Path to file containing lines which trigger LEAKER calls when crossed by hostile aircraft.")
+	if err := skyeye.MarkFlagFilename("leaker-lines-file", "json", "yaml", "yml"); err != nil {
+		log.Fatal().Err(err).Msg("failed to mark flag as filename")
+	}
 	skyeye.Flags().StringVar(&aircraftFile, "aircraft-file", "", "Path to file containing additional aircraft that extend or override the built-in encyclopedia.")
 	if err := skyeye.MarkFlagFilename("aircraft-file", "json", "yaml", "yml"); err != nil {
 		log.Fatal().Err(err).Msg("failed to mark flag as filename")
 	}
 
+	// Sensor model
+	skyeye.Flags().BoolVar(&enableSensorModel, "sensor-model", false, "Hide hostile aircraft which are not detected by a friendly AWACS or early warning radar")
+	skyeye.Flags().StringVar(&radarSitesFile, "radar-sites-file", "", "Path to file containing fixed friendly radar sites used by the sensor model.")
+	if err := skyeye.MarkFlagFilename("radar-sites-file", "json", "yaml", "yml"); err != nil {
+		log.Fatal().Err(err).Msg("failed to mark flag as filename")
+	}
+	skyeye.Flags().StringVar(&terrainElevationFile, "terrain-elevation-file", "", "Path to an ESRI ASCII grid of terrain elevations used by the sensor model for terrain masking.")
+	if err := skyeye.MarkFlagFilename("terrain-elevation-file", "asc"); err != nil {
+		log.Fatal().Err(err).Msg("failed to mark flag as filename")
+	}
+	skyeye.Flags().StringVar(&awacsUnit, "awacs-unit", "", "Unit or group name of a friendly aircraft in the mission which carries the GCI's radar. If set, the GCI goes off station while the aircraft is not airborne. The aircraft is a radar emitter only if the sensor model is enabled")
+
 	// Tracing
 	skyeye.Flags().BoolVar(&enableTracing, "enable-tracing", false, "Enable tracing")
 	skyeye.Flags().BoolVar(&enableTracing, "tracing", false, "Enable tracing")
@@ -335,6 +379,18 @@ func loadCallsign(rando *rand.Rand) (callsign string) {
 	return
 }
 
+func loadSRSFrequencies() []simpleradio.RadioFrequency {
+	frequencies := cli.LoadFrequencies(srsFrequencies, simpleradio.RoleTactical)
+	frequencies = append(frequencies, cli.LoadFrequencies(srsBroadcastFrequencies, simpleradio.RoleBroadcast)...)
+	frequencies = append(frequencies, cli.LoadFrequencies(srsGuardFrequencies, simpleradio.RoleGuard)...)
+	for i, frequency := range frequencies {
+		if slices.ContainsFunc(frequencies[:i], frequency.IsSameFrequency) {
+			log.Fatal().Stringer("frequency", frequency).Msg("SRS frequency is configured more than once")
+		}
+	}
+	return frequencies
+}
+
 func loadLock(path string) *flock.Flock {
 	if path == "" {
 		return nil
@@ -367,6 +423,22 @@ func loadLocations() []locations.Location {
 	return locs
 }
 
+func loadLeakerLines() []locations.Line {
+	if leakerLinesFile == "" {
+		return nil
+	}
+	data, err := os.ReadFile(leakerLinesFile)
+	if err != nil {
+		log.Fatal().Err(err).Str("path", leakerLinesFile).Msg("failed to read leaker lines file")
+	}
+	lines, err := locations.LoadLines(data)
+	if err != nil {
+		log.Fatal().Err(err).Str("path", leakerLinesFile).Msg("failed to load leaker lines file")
+	}
+	log.Info().Int("count", len(lines)).Msg("loaded leaker lines")
+	return lines
+}
+
 func loadAircraft() []encyclopedia.Aircraft {
 	if aircraftFile == "" {
 		return nil
@@ -383,6 +455,39 @@ func loadAircraft() []encyclopedia.Aircraft {
 	return aircraft
 }
 
+func loadRadarSites() []locations.RadarSite {
+	if radarSitesFile == "" {
+		return nil
+	}
+	data, err := os.ReadFile(radarSitesFile)
+	if err != nil {
+		log.Fatal().Err(err).Str("path", radarSitesFile).Msg("failed to read radar sites file")
+	}
+	sites, err := locations.LoadRadarSites(data)
+	if err != nil {
+		log.Fatal().Err(err).Str("path", radarSitesFile).Msg("failed to load radar sites file")
+	}
+	log.Info().Int("count", len(sites)).Msg("loaded radar sites")
+	return sites
+}
+
+func loadTerrainElevation() *elevation.Grid {
+	if terrainElevationFile == "" {
+		return nil
+	}
+	f, err := os.Open(terrainElevationFile)
+	if err != nil {
+		log.Fatal().Err(err).Str("path", terrainElevationFile).Msg("failed to open terrain elevation file")
+	}
+	defer f.Close()
+	grid, err := elevation.LoadASCIIGrid(f)
+	if err != nil {
+		log.Fatal().Err(err).Str("path", terrainElevationFile).Msg("failed to load terrain elevation file")
+	}
+	log.Info().Str("path", terrainElevationFile).Msg("loaded terrain elevation")
+	return grid
+}
+
 func preRun(cmd *cobra.Command, _ []string) error {
 	if err := initializeConfig(cmd); err != nil {
 		return fmt.Errorf("failed to initialize config: %w", err)
@@ -426,19 +531,27 @@ func run(_ *cobra.Command, _ []string) {
 	rando := randomizer()
 	voice := loadVoice(rando)
 	callsign := loadCallsign(rando)
-	parsedSRSFrequencies := cli.LoadFrequencies(srsFrequencies)
+	parsedSRSFrequencies := loadSRSFrequencies()
 	voiceLock := loadLock(voiceLockPath)
 	recognizerLock := loadLock(recognizerLockPath)
 	volume := loadVoiceVolume()
 	locs := loadLocations()
+	leakerLines := loadLeakerLines()
 	customAircraft := loadAircraft()
+	radarSites := loadRadarSites()
+	terrainElevation := loadTerrainElevation()
 
 	config := conf.Configuration{
 		ACMIFile:                     acmiFile,
+		ACMIFileStart:                acmiFileStart,
+		ACMIFileEnd:                  acmiFileEnd,
+		ACMIFileTimeScale:            acmiFileTimeScale,
 		TelemetryAddress:             telemetryAddress,
 		TelemetryConnectionTimeout:   telemetryConnectionTimeout,
 		TelemetryClientName:          callsign,
 		TelemetryPassword:            telemetryPassword,
+		TelemetryRecordingDirectory:  telemetryRecordingDirectory,
+		GRPCTelemetry:                grpcTelemetry,
 		SRSAddress:                   srsAddress,
 		SRSConnectionTimeout:         srsConnectionTimeout,
 		SRSClientName:                fmt.Sprintf("GCI %s [BOT]", callsign),
@@ -467,6 +580,7 @@ func run(_ *cobra.Command, _ []string) {
 		MandatoryThreatRadius:        unit.Length(mandatoryThreatRadiusNM) * unit.NauticalMile,
 		ThreatBRAABearingSpread:      unit.Angle(threatBRAABearingSpreadDeg) * unit.Degree,
 		ThreatBRAARangeSpread:        unit.Length(threatBRAARangeSpreadNM) * unit.NauticalMile,
+		PopUpRadius:                  unit.Length(popUpRadiusNM) * unit.NauticalMile,
 		EnableTracing:                enableTracing,
 		DiscordWebhookID:             discordWebhookID,
 		DiscorbWebhookToken:          discordWebhookToken,
@@ -476,7 +590,12 @@ func run(_ *cobra.Command, _ []string) {
 		GRPCAPIKey:                   grpcAPIKey,
 		EnableTerrainDetection:       enableTerrainDetection,
 		Locations:                    locs,
+		LeakerLines:                  leakerLines,
 		CustomAircraft:               customAircraft,
+		EnableSensorModel:            enableSensorModel,
+		RadarSites:                   radarSites,
+		TerrainElevation:             terrainElevation,
+		AWACSUnit:                    awacsUnit,
 	}
 
 	log.Info().Msg("starting application")
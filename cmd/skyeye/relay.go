@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/dharmab/skyeye/internal/cli"
+	"github.com/dharmab/skyeye/pkg/telemetry"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+// relayHostname is the hostname used by the relay in handshakes with both the upstream telemetry service and
+// downstream clients.
+const relayHostname = "SkyEye Relay"
+
+// Used for relay CLI configuration values.
+var (
+	relayAddress   string
+	relayPasswords []string
+)
+
+func init() {
+	relay.Flags().StringVar(&configFile, "config-file", "/etc/skyeye/config.yaml", "Path to config file")
+
+	// Logging
+	logLevelFlag := cli.NewEnum(&logLevel, "Level", "info", "error", "warn", "info", "debug", "trace")
+	relay.Flags().Var(logLevelFlag, "log-level", "Log level (error, warn, info, debug, trace)")
+	logFormats := cli.NewEnum(&logFormat, "Format", "pretty", "json")
+	relay.Flags().Var(logFormats, "log-format", "Log format (pretty, json)")
+
+	// Upstream telemetry
+	relay.Flags().StringVar(&telemetryAddress, "telemetry-address", "localhost:42674", "Address of the real-time telemetry service")
+	relay.Flags().DurationVar(&telemetryConnectionTimeout, "telemetry-connection-timeout", 10*time.Second, "Connection timeout for real-time telemetry client")
+	relay.Flags().StringVar(&telemetryPassword, "telemetry-password", "", "Password for the real-time telemetry service")
+
+	// Downstream clients
+	relay.Flags().StringVar(&relayAddress, "relay-address", "0.0.0.0:42675", "Address on which to listen for SkyEye instances")
+	relay.Flags().StringSliceVar(&relayPasswords, "relay-passwords", []string{}, "Passwords which SkyEye instances may use to connect to the relay. Each instance may use a different password. If not set, instances must connect without a password")
+
+	skyeye.AddCommand(relay)
+}
+
+var relay = &cobra.Command{
+	Use:   "relay",
+	Short: "Share one real-time telemetry connection between multiple SkyEye instances",
+	Long:  "The relay connects once to a real-time telemetry service and serves the telemetry stream to multiple SkyEye instances over the same protocol.",
+	Example: "  skyeye relay --telemetry-address=your-tacview-server:42674 --telemetry-password=your-tacview-password --relay-passwords=blue-password,red-password\n" +
+		"  skyeye --telemetry-address=your-relay-server:42675 --telemetry-password=blue-password ...",
+	PreRunE: preRun,
+	Run:     runRelay,
+}
+
+func runRelay(_ *cobra.Command, _ []string) {
+	cli.SetupZerolog(logLevel, logFormat)
+	log.Info().Str("version", Version).Msg("SkyEye telemetry relay")
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	r, err := telemetry.NewRelay(telemetryAddress, telemetryPassword, telemetryConnectionTimeout, relayAddress, relayHostname, relayPasswords)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to start relay")
+	}
+	log.Info().Int("passwords", len(relayPasswords)).Msg("starting relay")
+	if err := r.Run(ctx); err != nil {
+		log.Fatal().Err(err).Msg("relay exited with error")
+	}
+	log.Info().Msg("relay stopped")
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/dharmab/skyeye/pkg/telemetry"
 	"github.com/dharmab/skyeye/pkg/traces"
 	"github.com/gofrs/flock"
+	"github.com/martinlindhe/unit"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -67,9 +68,11 @@ type Application struct {
 	// tracers are destinations where traces are sent when tracing is enabled
 	tracers []traces.Tracer
 
-	starts  chan sim.Started
-	updates chan sim.Updated
-	fades   chan sim.Faded
+	starts   chan sim.Started
+	updates  chan sim.Updated
+	fades    chan sim.Faded
+	destroys chan sim.Destroyed
+	launches chan sim.Launched
 
 	// exitAfter is the duration after which the application should exit
 	exitAfter time.Duration
@@ -80,8 +83,12 @@ func NewApplication(config conf.Configuration) (*Application, error) {
 	starts := make(chan sim.Started)
 	updates := make(chan sim.Updated)
 	fades := make(chan sim.Faded)
+	destroys := make(chan sim.Destroyed)
+	launches := make(chan sim.Launched)
 
 	var chatListener *commands.ChatListener
+	var missionClient mission.MissionServiceClient
+	var coalitionClient grpccoalition.CoalitionServiceClient
 	if config.EnableGRPC {
 		log.Info().Str("address", config.GRPCAddress).Msg("constructing gRPC clients")
 		opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
@@ -92,13 +99,18 @@ func NewApplication(config conf.Configuration) (*Application, error) {
 				ctx = metadata.NewOutgoingContext(ctx, m)
 				return invoker(ctx, method, req, reply, cc, opts...)
 			}))
+			opts = append(opts, grpc.WithStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+				m := metadata.Pairs("X-API-Key", config.GRPCAPIKey)
+				ctx = metadata.NewOutgoingContext(ctx, m)
+				return streamer(ctx, desc, cc, method, opts...)
+			}))
 		}
 		grpcClient, err := grpc.NewClient(config.GRPCAddress, opts...)
 		if err != nil {
 			return nil, err
 		}
-		missionClient := mission.NewMissionServiceClient(grpcClient)
-		coalitionClient := grpccoalition.NewCoalitionServiceClient(grpcClient)
+		missionClient = mission.NewMissionServiceClient(grpcClient)
+		coalitionClient = grpccoalition.NewCoalitionServiceClient(grpcClient)
 		netClient := net.NewNetServiceClient(grpcClient)
 
 		log.Info().Msg("constructing chat listener")
@@ -141,9 +153,21 @@ func NewApplication(config conf.Configuration) (*Application, error) {
 	}
 
 	var telemetryClient telemetry.Client
-	if config.ACMIFile != "" {
+	if config.GRPCTelemetry {
+		if !config.EnableGRPC {
+			return nil, errors.New("DCS-gRPC telemetry requires DCS-gRPC to be enabled")
+		}
+		log.Info().Str("address", config.GRPCAddress).Msg("constructing DCS-gRPC telemetry client")
+		telemetryClient = telemetry.NewGRPCClient(missionClient, coalitionClient, config.RadarSweepInterval)
+	} else if config.ACMIFile != "" {
 		log.Info().Str("file", config.ACMIFile).Msg("constructing ACMI file reader")
-		telemetryClient = telemetry.NewFileClient(config.ACMIFile, config.RadarSweepInterval)
+		telemetryClient = telemetry.NewFileClient(
+			config.ACMIFile,
+			config.RadarSweepInterval,
+			telemetry.WithReplayStart(config.ACMIFileStart),
+			telemetry.WithReplayEnd(config.ACMIFileEnd),
+			telemetry.WithReplayTimeScale(config.ACMIFileTimeScale),
+		)
 	} else {
 		log.Info().Str("address", config.TelemetryAddress).Msg("constructing telemetry client")
 		telemetryClient = telemetry.NewRealTimeClient(
@@ -152,6 +176,7 @@ func NewApplication(config conf.Configuration) (*Application, error) {
 			config.TelemetryPassword,
 			config.TelemetryConnectionTimeout,
 			config.RadarSweepInterval,
+			config.TelemetryRecordingDirectory,
 		)
 	}
 
@@ -193,7 +218,27 @@ func NewApplication(config conf.Configuration) (*Application, error) {
 	if config.ThreatMonitoringRequiresSRS {
 		radarSRSClient = srsClient
 	}
-	rdr := radar.New(config.Coalition, starts, updates, fades, config.MandatoryThreatRadius, config.ThreatBRAABearingSpread, config.ThreatBRAARangeSpread, config.EnableTerrainDetection, radarSRSClient)
+	rdr := radar.New(config.Coalition, starts, updates, fades, destroys, launches, config.MandatoryThreatRadius, config.ThreatBRAABearingSpread, config.ThreatBRAARangeSpread, config.EnableTerrainDetection, radarSRSClient)
+	if config.AWACSUnit != "" {
+		log.Info().Str("unit", config.AWACSUnit).Msg("binding GCI to AWACS aircraft")
+		rdr.SetAWACS(config.AWACSUnit)
+	}
+	if config.EnableSensorModel {
+		log.Info().Int("sites", len(config.RadarSites)).Bool("terrainMasking", config.TerrainElevation != nil).Msg("enabling radar sensor model")
+		sites := make([]radar.Emitter, 0, len(config.RadarSites))
+		for _, site := range config.RadarSites {
+			sites = append(sites, radar.Emitter{
+				Point:    site.Coordinates.Point(),
+				Altitude: unit.Length(site.AltitudeFeet) * unit.Foot,
+				Range:    unit.Length(site.RangeNM) * unit.NauticalMile,
+			})
+		}
+		var terrain radar.Terrain
+		if config.TerrainElevation != nil {
+			terrain = config.TerrainElevation
+		}
+		rdr.SetSensorModel(radar.NewSensorModel(sites, terrain))
+	}
 	log.Info().Msg("constructing GCI controller")
 	gciController := controller.New(
 		rdr,
@@ -204,8 +249,11 @@ func NewApplication(config conf.Configuration) (*Application, error) {
 		config.EnableThreatMonitoring,
 		config.ThreatMonitoringInterval,
 		config.ThreatMonitoringRequiresSRS,
+		config.PopUpRadius,
 		config.Locations,
+		config.LeakerLines,
 	)
+	gciController.SetFrequencies(config.SRSFrequencies)
 
 	log.Info().Msg("constructing response composer")
 	responseComposer := composer.Composer{Callsign: config.Callsign}
@@ -255,6 +303,8 @@ func NewApplication(config conf.Configuration) (*Application, error) {
 		starts:                     starts,
 		updates:                    updates,
 		fades:                      fades,
+		destroys:                   destroys,
+		launches:                   launches,
 		exitAfter:                  config.ExitAfter,
 	}
 	return app, nil
@@ -274,7 +324,7 @@ func (a *Application) Run(ctx context.Context, cancel context.CancelFunc, wg *sy
 
 	wg.Go(func() {
 		log.Info().Msg("streaming telemetry data to radar")
-		a.telemetryClient.Stream(ctx, wg, a.starts, a.updates, a.fades)
+		a.telemetryClient.Stream(ctx, wg, a.starts, a.updates, a.fades, a.destroys, a.launches)
 	})
 
 	wg.Go(func() {
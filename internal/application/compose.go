@@ -28,7 +28,12 @@ func (a *Application) compose(ctx context.Context, in <-chan controller.Call, ou
 // composeCall handles a single call, publishing the composition to the output channel.
 func (a *Application) composeCall(ctx context.Context, call any, out chan<- Message[composer.NaturalLanguageResponse]) {
 	ctx = traces.WithHandledAt(ctx, time.Now())
+	ctx = traces.WithCall(ctx, call)
 	logger := log.With().Type("type", call).Any("params", call).Logger()
+	if len(a.controller.Route(ctx)) == 0 {
+		logger.Debug().Msg("skipping brevity call because no frequency accepts it")
+		return
+	}
 	logger.Info().Msg("composing brevity call")
 	var response composer.NaturalLanguageResponse
 	switch c := call.(type) {
@@ -56,16 +61,42 @@ func (a *Application) composeCall(ctx context.Context, call any, out chan<- Mess
 		response = a.composer.ComposeSpikedResponse(c)
 	case brevity.StrobeResponse:
 		response = a.composer.ComposeStrobeResponse(c)
+	case brevity.InterceptResponse:
+		response = a.composer.ComposeInterceptResponse(c)
+	case brevity.TargetedResponse:
+		response = a.composer.ComposeTargetedResponse(c)
 	case brevity.TripwireResponse:
 		response = a.composer.ComposeTripwireResponse(c)
 	case brevity.VectorResponse:
 		response = a.composer.ComposeVectorResponse(c)
 	case brevity.SunriseCall:
 		response = a.composer.ComposeSunriseCall(c)
+	case brevity.OffStationCall:
+		response = a.composer.ComposeOffStationCall(c)
+	case brevity.OffStationResponse:
+		response = a.composer.ComposeOffStationResponse(c)
 	case brevity.ThreatCall:
 		response = a.composer.ComposeThreatCall(c)
+	case brevity.SplitCall:
+		response = a.composer.ComposeSplitCall(c)
+	case brevity.GroupsMergedCall:
+		response = a.composer.ComposeGroupsMergedCall(c)
+	case brevity.LeakerCall:
+		response = a.composer.ComposeLeakerCall(c)
+	case brevity.PopUpCall:
+		response = a.composer.ComposePopUpCall(c)
+	case brevity.MissileCall:
+		response = a.composer.ComposeMissileCall(c)
+	case brevity.SplashCall:
+		response = a.composer.ComposeSplashCall(c)
 	case brevity.MergedCall:
 		response = a.composer.ComposeMergedCall(c)
+	case brevity.InterceptCall:
+		response = a.composer.ComposeInterceptCall(c)
+	case brevity.TargetedCall:
+		response = a.composer.ComposeTargetedCall(c)
+	case brevity.TripwireCall:
+		response = a.composer.ComposeTripwireCall(c)
 	case brevity.SayAgainResponse:
 		response = a.composer.ComposeSayAgainResponse(c)
 	default:
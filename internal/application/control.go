@@ -41,6 +41,8 @@ func (a *Application) handleRequest(ctx context.Context, r any) {
 		a.controller.HandleCheckIn(ctx, request)
 	case *brevity.DeclareRequest:
 		a.controller.HandleDeclare(ctx, request)
+	case *brevity.InterceptRequest:
+		a.controller.HandleIntercept(ctx, request)
 	case *brevity.PictureRequest:
 		a.controller.HandlePicture(ctx, request)
 	case *brevity.RadioCheckRequest:
@@ -53,6 +55,8 @@ func (a *Application) handleRequest(ctx context.Context, r any) {
 		a.controller.HandleSpiked(ctx, request)
 	case *brevity.StrobeRequest:
 		a.controller.HandleStrobe(ctx, request)
+	case *brevity.TargetedRequest:
+		a.controller.HandleTargeted(ctx, request)
 	case *brevity.TripwireRequest:
 		a.controller.HandleTripwire(ctx, request)
 	case *brevity.VectorRequest:
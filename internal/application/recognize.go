@@ -19,9 +19,17 @@ func (a *Application) recognize(ctx context.Context, out chan<- Message[string])
 			log.Info().Msg("stopping speech recognition due to context cancellation")
 			return
 		case transmission := <-a.srsClient.Receive():
+			if len(transmission.Frequencies) > 0 && !a.controller.IsListening(transmission.Frequencies[0]) {
+				log.Debug().Stringer("frequency", transmission.Frequencies[0]).Msg("ignoring transmission on frequency which does not accept requests")
+				continue
+			}
 			rCtx := context.Background()
 			rCtx = traces.WithTraceID(rCtx, transmission.TraceID)
 			rCtx = traces.WithClientName(rCtx, transmission.ClientName)
+			rCtx = traces.WithSteppedOn(rCtx, transmission.Overlapped)
+			if len(transmission.Frequencies) > 0 {
+				rCtx = traces.WithRadioFrequency(rCtx, transmission.Frequencies[0])
+			}
 			rCtx = traces.WithReceivedAt(rCtx, time.Now())
 			a.recognizeSample(ctx, rCtx, transmission.Audio, out)
 		}
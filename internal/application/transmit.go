@@ -25,9 +25,10 @@ func (a *Application) transmit(ctx context.Context, in <-chan Message[simpleradi
 // transmitMessage submits a single audio sample to SRS.
 func (a *Application) transmitMessage(rCtx context.Context, audio simpleradio.Audio) {
 	transmission := simpleradio.Transmission{
-		TraceID:    traces.GetTraceID(rCtx),
-		ClientName: traces.GetClientName(rCtx),
-		Audio:      audio,
+		TraceID:     traces.GetTraceID(rCtx),
+		ClientName:  traces.GetClientName(rCtx),
+		Audio:       audio,
+		Frequencies: a.controller.Route(rCtx),
 	}
 
 	log.Info().Str("traceID", transmission.TraceID).Msg("transmitting audio")
@@ -5,15 +5,16 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-func LoadFrequencies(frequencyStrs []string) []simpleradio.RadioFrequency {
+func LoadFrequencies(frequencyStrs []string, role simpleradio.Role) []simpleradio.RadioFrequency {
 	frequencies := make([]simpleradio.RadioFrequency, 0, len(frequencyStrs))
 	for _, s := range frequencyStrs {
 		freq, err := simpleradio.ParseRadioFrequency(s)
 		if err != nil {
 			log.Fatal().Err(err).Str("frequency", s).Msg("failed to parse SRS frequency")
 		}
+		freq.Role = role
 		frequencies = append(frequencies, *freq)
-		log.Info().Stringer("frequency", freq).Msg("parsed SRS frequency")
+		log.Info().Stringer("frequency", freq).Stringer("role", role).Msg("parsed SRS frequency")
 	}
 	return frequencies
 }
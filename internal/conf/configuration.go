@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/elevation"
 	"github.com/dharmab/skyeye/pkg/encyclopedia"
 	"github.com/dharmab/skyeye/pkg/locations"
 	"github.com/dharmab/skyeye/pkg/simpleradio"
@@ -26,6 +27,12 @@ const (
 type Configuration struct {
 	// ACMIFile is the path to the ACMI file
 	ACMIFile string
+	// ACMIFileStart is the offset into the ACMI file at which to begin replaying in real time
+	ACMIFileStart time.Duration
+	// ACMIFileEnd is the offset into the ACMI file at which to pause the replay. Zero means the entire file is replayed
+	ACMIFileEnd time.Duration
+	// ACMIFileTimeScale is the ACMI file replay speed relative to real time. Zero means as fast as possible
+	ACMIFileTimeScale float64
 	// TelemetryAddress is the network address of the real-time telemetry server (including port)
 	TelemetryAddress string
 	// TelemetryConnectionTimeout is the connection timeout for connecting to the real-time telemetry server
@@ -34,6 +41,12 @@ type Configuration struct {
 	TelemetryClientName string
 	// TelemetryPassword is the password for connecting to the real-time telemetry server
 	TelemetryPassword string
+	// TelemetryRecordingDirectory is the directory in which real-time telemetry is recorded to ACMI files. If empty,
+	// telemetry is not recorded.
+	TelemetryRecordingDirectory string
+	// GRPCTelemetry controls whether DCS-gRPC is used as the telemetry source instead of ACMIFile or TelemetryAddress.
+	// Requires EnableGRPC.
+	GRPCTelemetry bool
 	// SRSAddress is the network address of the SimpleRadio Standalone server (including port)
 	SRSAddress string
 	// SRSConnectionTimeout is the connection timeout for connecting to the SimpleRadio Standalone server
@@ -42,7 +55,8 @@ type Configuration struct {
 	SRSClientName string
 	// SRSExternalAWACSModePassword is the password for connecting to the SimpleRadio Standalone server using External AWACS Mode
 	SRSExternalAWACSModePassword string
-	// SRSFrequencies that the bot simultaneously receives and transmits on
+	// SRSFrequencies that the bot simultaneously receives on, and their roles. Replies are transmitted on the frequency
+	// the request was received on, and other calls on all frequencies whose role accepts the call.
 	SRSFrequencies []simpleradio.RadioFrequency
 	// EnableGRPC controls whether DCS-gRPC features are enabled
 	EnableGRPC bool
@@ -101,11 +115,27 @@ type Configuration struct {
 	// ThreatMonitoringRequiresSRS controls whether threat calls are issued to aircraft that are not on an SRS frequency. This is mostly
 	// for debugging.
 	ThreatMonitoringRequiresSRS bool
+	// PopUpRadius is the distance from friendly aircraft within which POP-UP calls are broadcast. If zero, POP-UP calls
+	// are disabled.
+	PopUpRadius unit.Length
 	// Locations is a slice of named locations that can be referenced in VECTOR calls.
 	Locations []locations.Location
+	// LeakerLines is a slice of named lines which trigger LEAKER calls when crossed by hostile aircraft.
+	LeakerLines []locations.Line
 	// CustomAircraft is a slice of user-provided aircraft entries that extend or override the
 	// built-in encyclopedia. Registered into the encyclopedia at application startup.
 	CustomAircraft []encyclopedia.Aircraft
+	// EnableSensorModel controls whether hostile aircraft must be detected by a friendly surveillance radar to appear
+	// in the radar picture.
+	EnableSensorModel bool
+	// RadarSites is a slice of fixed friendly radars used by the sensor model, in addition to friendly early warning
+	// aircraft and radars in the telemetry.
+	RadarSites []locations.RadarSite
+	// TerrainElevation is used by the sensor model for terrain masking. If nil, terrain masking is disabled.
+	TerrainElevation *elevation.Grid
+	// AWACSUnit is the unit or group name of the friendly aircraft which carries the GCI's radar. If set, the GCI is off
+	// station while the aircraft is not airborne, and the aircraft is an emitter if the sensor model is enabled.
+	AWACSUnit string
 	// EnableTracing controls whether to publish traces
 	EnableTracing bool
 	// DiscordWebhookID is the ID of the Discord webhook
@@ -50,6 +50,13 @@ type Group interface {
 	MergedWith() int
 	// SetMergedWith sets the number of friendlies this group is merged with.
 	SetMergedWith(int)
+	// Name is the group's name within a PICTURE formation, such as "north" or "lead". This is empty except for
+	// PICTURE calls.
+	Name() string
+	// SetName sets the group's name within a PICTURE formation.
+	SetName(string)
+	// Label is a persistent label for the group, such as "Alpha", which stays the same across calls. This may be empty.
+	Label() string
 	// String returns a human-readable description of the group.
 	String() string
 	// ObjectIDs returns the object IDs of all contacts in the group.
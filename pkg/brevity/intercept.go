@@ -0,0 +1,72 @@
+package brevity
+
+import (
+	"fmt"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+)
+
+// InterceptRequest is a request for tactical control of an intercept against a hostile group. The controller steers
+// the requesting aircraft onto the group until the merge, or until the pilot terminates the intercept.
+type InterceptRequest struct {
+	// Callsign of the friendly aircraft requesting the intercept.
+	Callsign string
+	// Bullseye is the position of the group to intercept. If nil, the controller intercepts the group targeted by the
+	// friendly aircraft, or the nearest hostile group if no group is targeted.
+	Bullseye *Bullseye
+	// Terminate is true if the pilot is ending a previously requested intercept.
+	Terminate bool
+}
+
+func (r InterceptRequest) String() string {
+	if r.Terminate {
+		return "INTERCEPT TERMINATE for " + r.Callsign
+	}
+	if r.Bullseye == nil {
+		return "INTERCEPT for " + r.Callsign
+	}
+	return fmt.Sprintf("INTERCEPT for %s: bullseye %s", r.Callsign, r.Bullseye)
+}
+
+// Turn is the direction a friendly aircraft is told to turn to reach the intercept heading.
+type Turn string
+
+const (
+	// LeftTurn instructs the friendly aircraft to turn left.
+	LeftTurn Turn = "left"
+	// RightTurn instructs the friendly aircraft to turn right.
+	RightTurn Turn = "right"
+	// NoTurn instructs the friendly aircraft to hold its current heading.
+	NoTurn Turn = "steady"
+)
+
+// Steering is an intercept heading, and the turn the friendly aircraft must make to fly it.
+type Steering struct {
+	// Turn to make.
+	Turn Turn
+	// Heading to fly. This is a magnetic bearing.
+	Heading bearings.Bearing
+}
+
+// InterceptResponse starts or ends an intercept.
+type InterceptResponse struct {
+	// Callsign of the friendly aircraft requesting the intercept.
+	Callsign string
+	// Terminate is true if the intercept has ended.
+	Terminate bool
+	// Group to intercept. The group's location is given in BRAA format relative to the friendly aircraft. If nil and
+	// Terminate is false, no group was found to intercept.
+	Group Group
+	// Steering is the first steering instruction. Only set if Group is set.
+	Steering Steering
+}
+
+// InterceptCall steers a friendly aircraft during an intercept.
+type InterceptCall struct {
+	// Callsign of the friendly aircraft being steered.
+	Callsign string
+	// Steering instruction.
+	Steering Steering
+	// Group being intercepted. The group's location is given in BRAA format relative to the friendly aircraft.
+	Group Group
+}
@@ -0,0 +1,10 @@
+package brevity
+
+// LeakerCall warns all friendly aircraft that a hostile group has passed through a defensive line.
+// Reference: ATP 3-52.4 Chapter II section 3.
+type LeakerCall struct {
+	// Line is the name of the line the group crossed.
+	Line string
+	// Group which crossed the line. The group's location is given in bullseye format.
+	Group Group
+}
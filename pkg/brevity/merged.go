@@ -8,6 +8,9 @@ import (
 type MergedCall struct {
 	// Callsigns of the friendly aircraft in the merge.
 	Callsigns []string
+	// Target is the group the friendly aircraft were assigned, if they merged with their targeted group. Otherwise,
+	// Target is nil.
+	Target Group
 }
 
 const (
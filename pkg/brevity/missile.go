@@ -0,0 +1,16 @@
+package brevity
+
+import (
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/martinlindhe/unit"
+)
+
+// MissileCall warns a friendly aircraft that a hostile aircraft has launched an air-to-air missile at it.
+type MissileCall struct {
+	// Callsign of the targeted friendly aircraft.
+	Callsign string
+	// Bearing from the targeted friendly aircraft to the launching aircraft.
+	Bearing bearings.Bearing
+	// Range from the targeted friendly aircraft to the launching aircraft.
+	Range unit.Length
+}
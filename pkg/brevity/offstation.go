@@ -0,0 +1,11 @@
+package brevity
+
+// OffStationCall reports that the GCI's AWACS aircraft has left the area and the GCI is no longer providing radar
+// services.
+type OffStationCall struct{}
+
+// OffStationResponse provides a response when the GCI cannot answer a request because it is off station.
+type OffStationResponse struct {
+	// Callsign of the friendly aircraft that made the request.
+	Callsign string
+}
@@ -19,6 +19,31 @@ func (r PictureRequest) String() string {
 type PictureResponse struct {
 	// Count is the total number of groups in the PICTURE.
 	Count int
-	// Groups included in the PICTURE. This is a maximum of 3 groups.
+	// Formation describes the geometric relationship between the groups. This is NoFormation unless the PICTURE
+	// contains between 2 and 3 groups which form a standard presentation.
+	Formation Formation
+	// Groups included in the PICTURE. This is a maximum of 3 groups. If Formation is set, the groups are ordered and
+	// named according to the formation.
 	Groups []Group
 }
+
+// Formation is a label describing the geometric relationship between the groups in a PICTURE.
+// Reference: ATP 3-52.4 Chapter IV section 9.
+type Formation string
+
+const (
+	// NoFormation indicates the groups do not form a standard presentation.
+	NoFormation Formation = ""
+	// AzimuthFormation is two groups side by side, separated laterally as seen from the friendly aircraft.
+	AzimuthFormation Formation = "azimuth"
+	// RangeFormation is two groups in trail, separated in range as seen from the friendly aircraft.
+	RangeFormation Formation = "range"
+	// WallFormation is three or more groups side by side.
+	WallFormation Formation = "wall"
+	// LadderFormation is three or more groups in trail.
+	LadderFormation Formation = "ladder"
+	// ChampagneFormation is three groups with two lead groups side by side and a single trail group.
+	ChampagneFormation Formation = "champagne"
+	// VicFormation is three groups with a single lead group and two trail groups side by side.
+	VicFormation Formation = "vic"
+)
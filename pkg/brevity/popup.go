@@ -0,0 +1,10 @@
+package brevity
+
+// PopUpCall warns friendly aircraft of a group that has suddenly appeared nearby.
+type PopUpCall struct {
+	// Callsigns of the friendly aircraft near the pop-up group.
+	Callsigns []string
+	// Group which popped up. The group's location is given in BRAA format if there is a single friendly aircraft
+	// nearby, and in bullseye format otherwise.
+	Group Group
+}
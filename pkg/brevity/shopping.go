@@ -1,6 +1,8 @@
 package brevity
 
-// ShoppingRequest is an air-to-ground brevity commonly mistaken as an air-to-air brevity.
+// ShoppingRequest is a request for hostile surface targets near the requestor. SHOPPING is an air-to-ground brevity
+// usually directed at a JTAC or FAC, but a GCI with a surface picture can answer it too. A request for a SURFACE
+// PICTURE is treated the same way.
 type ShoppingRequest struct {
 	Callsign string
 }
@@ -9,7 +11,45 @@ func (r ShoppingRequest) String() string {
 	return "SHOPPING for " + r.Callsign
 }
 
-// ShoppingResponse is reeducation.
+// SurfaceCategory is the kind of contacts in a surface group.
+type SurfaceCategory int
+
+const (
+	// Ships are watercraft.
+	Ships SurfaceCategory = iota
+	// AirDefenses are surface-to-air missile systems and anti-aircraft artillery.
+	AirDefenses
+	// Vehicles are ground vehicles other than air defenses.
+	Vehicles
+)
+
+func (c SurfaceCategory) String() string {
+	switch c {
+	case Ships:
+		return "ships"
+	case AirDefenses:
+		return "air defenses"
+	case Vehicles:
+		return "vehicles"
+	default:
+		return "unknown"
+	}
+}
+
+// SurfaceGroup is a cluster of nearby surface contacts of the same category.
+type SurfaceGroup struct {
+	// Category of the contacts in the group.
+	Category SurfaceCategory
+	// Contacts is the number of contacts in the group.
+	Contacts int
+	// Vector is the bearing and range from the requestor to the group.
+	Vector *Vector
+}
+
+// ShoppingResponse reports hostile surface targets near the requestor.
 type ShoppingResponse struct {
 	Callsign string
+	// Groups contains the nearest hostile surface groups, ordered by increasing range. If empty, there are no hostile
+	// surface contacts nearby.
+	Groups []SurfaceGroup
 }
@@ -0,0 +1,9 @@
+package brevity
+
+// SplashCall reports that one or more hostile aircraft have been destroyed.
+type SplashCall struct {
+	// Callsigns of the friendly aircraft near the kill.
+	Callsigns []string
+	// Group which was destroyed. Only the destroyed contacts are included in the group.
+	Group Group
+}
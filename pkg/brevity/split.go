@@ -0,0 +1,22 @@
+package brevity
+
+// SplitCall warns friendly aircraft that a hostile group has split into several groups.
+type SplitCall struct {
+	// Callsigns of the friendly aircraft which were threatened by the original group.
+	Callsigns []string
+	// Label of the group which split.
+	Label string
+	// Groups the original group split into, including the remainder of the original group. The groups' locations
+	// are given in bullseye format.
+	Groups []Group
+}
+
+// GroupsMergedCall warns friendly aircraft that several hostile groups have joined into a single group.
+type GroupsMergedCall struct {
+	// Callsigns of the friendly aircraft which were threatened by the original groups.
+	Callsigns []string
+	// Labels of the groups which joined the merged group.
+	Labels []string
+	// Group is the merged group. The group's location is given in bullseye format.
+	Group Group
+}
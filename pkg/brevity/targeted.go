@@ -0,0 +1,34 @@
+package brevity
+
+import "fmt"
+
+// TargetedRequest is a request to assign the group at the given bullseye position to the requesting flight.
+// Reference: ATP 3-52.4 Chapter V section 8.
+type TargetedRequest struct {
+	// Callsign of the friendly aircraft requesting the assignment.
+	Callsign string
+	// Bullseye is the position of the group to target.
+	Bullseye *Bullseye
+}
+
+func (r TargetedRequest) String() string {
+	return fmt.Sprintf("TARGETED for %s: bullseye %s", r.Callsign, r.Bullseye)
+}
+
+// TargetedResponse commits the requesting flight on the targeted group.
+type TargetedResponse struct {
+	// Callsign of the friendly aircraft requesting the assignment.
+	Callsign string
+	// Group assigned to the friendly aircraft. The group's location is given in BRAA format relative to the friendly
+	// aircraft. If nil, no group was found near the requested position and nothing was assigned.
+	Group Group
+}
+
+// TargetedCall updates a friendly aircraft on the position of the group it has been assigned.
+type TargetedCall struct {
+	// Callsign of the friendly aircraft which is targeting the group.
+	Callsign string
+	// Group targeted by the friendly aircraft. The group's location is given in BRAA format relative to the friendly
+	// aircraft.
+	Group Group
+}
@@ -8,4 +8,6 @@ type ThreatCall struct {
 	Callsigns []string
 	// Group that is threatening the friendly aircraft.
 	Group Group
+	// Targeted indicates that every friendly aircraft in the call has been assigned the threatening group.
+	Targeted bool
 }
@@ -1,15 +1,40 @@
 package brevity
 
-// TripwireRequest does not exist.
+import (
+	"fmt"
+
+	"github.com/martinlindhe/unit"
+)
+
+// TripwireRequest is a request to monitor the airspace around the requesting aircraft and issue a warning when any
+// hostile group closes inside the given range. TRIPWIRE is not real brevity, but it is a popular feature of other
+// GCI bots, so it is provided as a convenience.
 type TripwireRequest struct {
+	// Callsign of the friendly aircraft requesting the TRIPWIRE.
 	Callsign string
+	// Range at which to warn the friendly aircraft. If zero, the controller chooses a default range.
+	Range unit.Length
 }
 
 func (r TripwireRequest) String() string {
-	return "TRIPWIRE for " + r.Callsign
+	if r.Range == 0 {
+		return "TRIPWIRE for " + r.Callsign
+	}
+	return fmt.Sprintf("TRIPWIRE for %s: range %.0f", r.Callsign, r.Range.NauticalMiles())
 }
 
-// TripwireResponse is reeducation.
+// TripwireResponse confirms that a tripwire has been set.
 type TripwireResponse struct {
+	// Callsign of the friendly aircraft requesting the TRIPWIRE.
+	Callsign string
+	// Range at which the friendly aircraft will be warned.
+	Range unit.Length
+}
+
+// TripwireCall warns a friendly aircraft that a hostile group has closed inside the range of its tripwire.
+type TripwireCall struct {
+	// Callsign of the friendly aircraft whose tripwire was crossed.
 	Callsign string
+	// Group that crossed the tripwire. The group's location is given in BRAA format relative to the friendly aircraft.
+	Group Group
 }
@@ -19,4 +19,6 @@ type SayAgainResponse struct {
 	// Callsign of the friendly aircraft that made the request.
 	// This may be empty if the GCI is unsure of the caller's identity.
 	Callsign string
+	// SteppedOn is true if the caller's transmission was stepped on by another transmission on the same frequency.
+	SteppedOn bool
 }
@@ -6,8 +6,13 @@ import (
 
 // ComposeFadedCall constructs natural language brevity for announcing a contact has faded.
 func (c *Composer) ComposeFadedCall(call brevity.FadedCall) (response NaturalLanguageResponse) {
+	if len(call.Callsigns) > 0 {
+		response.WriteBoth(c.composeCallsigns(call.Callsigns...) + ", ")
+	}
 	response.WriteBoth(c.composeCallsigns(c.Callsign) + ", ")
-	if call.Group.Contacts() == 1 {
+	if len(call.Callsigns) > 0 {
+		response.WriteBoth(lowerFirst(composeTargetedLabel(call.Group)) + " faded,")
+	} else if call.Group.Contacts() == 1 {
 		response.WriteBoth("single contact faded,")
 	} else {
 		response.WriteBothf("%d contacts faded,", call.Group.Contacts())
@@ -35,17 +35,29 @@ func (c *Composer) composeCoreInformationFormat(groups ...brevity.Group) Natural
 	return response
 }
 
-func (c *Composer) composeGroup(group brevity.Group) (response NaturalLanguageResponse) {
+func (c *Composer) composeGroup(group brevity.Group) NaturalLanguageResponse {
+	label := "Group"
+	switch {
+	case group.Threat() && group.Label() != "":
+		label = "Threat group " + group.Label()
+	case group.Threat():
+		label = "Threat"
+	case group.Name() != "":
+		label = upperFirst(group.Name()) + " group"
+	case group.Label() != "":
+		label = "Group " + group.Label()
+	}
+	return c.composeLabeledGroup(label, group)
+}
+
+// composeLabeledGroup communicates information about a group, referring to it by the given label.
+func (c *Composer) composeLabeledGroup(label string, group brevity.Group) (response NaturalLanguageResponse) {
 	if group.BRAA() != nil && !group.BRAA().Bearing().IsMagnetic() {
 		log.Error().Stringer("bearing", group.BRAA().Bearing()).Msg("bearing provided to ComposeGroup should be magnetic")
 	}
 	if group.Bullseye() != nil && !group.Bullseye().Bearing().IsMagnetic() {
 		log.Error().Stringer("bearing", group.Bullseye().Bearing()).Msg("bearing provided to ComposeGroup should be magnetic")
 	}
-	label := "Group"
-	if group.Threat() {
-		label = "Threat"
-	}
 
 	// Group location, altitude, and track direction or specific aspect
 	stacks := group.Stacks()
@@ -0,0 +1,67 @@
+package composer
+
+import (
+	"fmt"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/rs/zerolog/log"
+)
+
+// ComposeInterceptResponse constructs natural language brevity for starting or ending an intercept.
+func (c *Composer) ComposeInterceptResponse(response brevity.InterceptResponse) NaturalLanguageResponse {
+	callsigns := fmt.Sprintf("%s, %s", c.composeCallsigns(response.Callsign), c.composeCallsigns(c.Callsign))
+	if response.Terminate {
+		reply := callsigns + ", terminate."
+		return NaturalLanguageResponse{
+			Subtitle: reply,
+			Speech:   reply,
+		}
+	}
+	if response.Group == nil {
+		reply := callsigns + ", unable, no group to intercept."
+		return NaturalLanguageResponse{
+			Subtitle: reply,
+			Speech:   reply,
+		}
+	}
+	steering := c.composeSteering(response.Steering)
+	group := c.composeLabeledGroup(composeInterceptLabel(response.Group), response.Group)
+	return NaturalLanguageResponse{
+		Subtitle: fmt.Sprintf("%s, intercept, %s, %s", callsigns, steering.Subtitle, group.Subtitle),
+		Speech:   fmt.Sprintf("%s, intercept, %s, %s", callsigns, steering.Speech, group.Speech),
+	}
+}
+
+// ComposeInterceptCall constructs natural language brevity for steering a friendly aircraft during an intercept.
+func (c *Composer) ComposeInterceptCall(call brevity.InterceptCall) NaturalLanguageResponse {
+	callsign := c.composeCallsigns(call.Callsign)
+	steering := c.composeSteering(call.Steering)
+	group := c.composeLabeledGroup(composeInterceptLabel(call.Group), call.Group)
+	return NaturalLanguageResponse{
+		Subtitle: fmt.Sprintf("%s, %s, %s", callsign, steering.Subtitle, group.Subtitle),
+		Speech:   fmt.Sprintf("%s, %s, %s", callsign, steering.Speech, group.Speech),
+	}
+}
+
+// composeSteering constructs natural language brevity for a steering instruction.
+func (*Composer) composeSteering(steering brevity.Steering) NaturalLanguageResponse {
+	if !steering.Heading.IsMagnetic() {
+		log.Error().Stringer("bearing", steering.Heading).Msg("heading provided to composeSteering should be magnetic")
+	}
+	instruction := "steady"
+	if steering.Turn != brevity.NoTurn {
+		instruction = "come " + string(steering.Turn)
+	}
+	return NaturalLanguageResponse{
+		Subtitle: fmt.Sprintf("%s heading %s", instruction, steering.Heading.String()),
+		Speech:   fmt.Sprintf("%s heading %s", instruction, pronounceBearing(steering.Heading)),
+	}
+}
+
+// composeInterceptLabel returns the label used to refer to a group being intercepted.
+func composeInterceptLabel(group brevity.Group) string {
+	if group.Label() == "" {
+		return "target"
+	}
+	return "target group " + group.Label()
+}
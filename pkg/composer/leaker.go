@@ -0,0 +1,17 @@
+package composer
+
+import (
+	"fmt"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+)
+
+// ComposeLeakerCall constructs natural language brevity for announcing a hostile group has crossed a defensive line.
+func (c *Composer) ComposeLeakerCall(call brevity.LeakerCall) NaturalLanguageResponse {
+	group := c.composeGroup(call.Group)
+	callsign := c.composeCallsigns(c.Callsign)
+	return NaturalLanguageResponse{
+		Subtitle: fmt.Sprintf("%s, leaker across %s, %s", callsign, call.Line, lowerFirst(group.Subtitle)),
+		Speech:   fmt.Sprintf("%s, leaker across %s, %s", callsign, call.Line, group.Speech),
+	}
+}
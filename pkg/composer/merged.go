@@ -1,12 +1,17 @@
 package composer
 
 import (
+	"fmt"
+
 	"github.com/dharmab/skyeye/pkg/brevity"
 )
 
 // ComposeMergedCall constructs natural language brevity for announcing a merge.
 func (c *Composer) ComposeMergedCall(call brevity.MergedCall) NaturalLanguageResponse {
 	reply := c.composeCallsigns(call.Callsigns...) + ", merged."
+	if call.Target != nil {
+		reply = fmt.Sprintf("%s, merged with %s.", c.composeCallsigns(call.Callsigns...), lowerFirst(composeTargetedLabel(call.Target)))
+	}
 	return NaturalLanguageResponse{
 		Subtitle: reply,
 		Speech:   reply,
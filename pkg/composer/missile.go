@@ -0,0 +1,21 @@
+package composer
+
+import (
+	"fmt"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/rs/zerolog/log"
+)
+
+// ComposeMissileCall constructs natural language brevity for warning a friendly aircraft of a missile launch.
+func (c *Composer) ComposeMissileCall(call brevity.MissileCall) NaturalLanguageResponse {
+	if !call.Bearing.IsMagnetic() {
+		log.Error().Stringer("bearing", call.Bearing).Msg("bearing provided to ComposeMissileCall should be magnetic")
+	}
+	callsign := c.composeCallsigns(call.Callsign)
+	_range := int(call.Range.NauticalMiles())
+	return NaturalLanguageResponse{
+		Subtitle: fmt.Sprintf("%s, missile launch, %s/%d.", callsign, call.Bearing.String(), _range),
+		Speech:   fmt.Sprintf("%s, missile launch, %s, %d", callsign, pronounceBearing(call.Bearing), _range),
+	}
+}
@@ -0,0 +1,25 @@
+package composer
+
+import (
+	"fmt"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+)
+
+// ComposeOffStationCall constructs natural language brevity for announcing GCI services are offline.
+func (c *Composer) ComposeOffStationCall(_ brevity.OffStationCall) NaturalLanguageResponse {
+	controllerCallsign := c.composeCallsigns(c.Callsign)
+	return NaturalLanguageResponse{
+		Subtitle: fmt.Sprintf("All players: GCI %s (bot) going off station.", controllerCallsign),
+		Speech:   fmt.Sprintf("All players, GCI %s going off station.", controllerCallsign),
+	}
+}
+
+// ComposeOffStationResponse constructs natural language brevity for declining a request while the GCI is off station.
+func (c *Composer) ComposeOffStationResponse(response brevity.OffStationResponse) NaturalLanguageResponse {
+	reply := fmt.Sprintf("%s, %s, unable, off station.", c.composeCallsigns(response.Callsign), c.composeCallsigns(c.Callsign))
+	return NaturalLanguageResponse{
+		Subtitle: reply,
+		Speech:   reply,
+	}
+}
@@ -21,6 +21,9 @@ func (c *Composer) ComposePictureResponse(response brevity.PictureResponse) Natu
 	groupCountFillIn := "single group."
 	if response.Count > 1 {
 		groupCountFillIn = fmt.Sprintf("%d groups.", response.Count)
+		if response.Formation != brevity.NoFormation {
+			groupCountFillIn = fmt.Sprintf("%d groups, %s.", response.Count, response.Formation)
+		}
 	}
 
 	info.Speech = strings.TrimSpace(info.Speech)
@@ -0,0 +1,17 @@
+package composer
+
+import (
+	"fmt"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+)
+
+// ComposePopUpCall constructs natural language brevity for announcing a pop-up group.
+func (c *Composer) ComposePopUpCall(call brevity.PopUpCall) NaturalLanguageResponse {
+	group := c.composeGroup(call.Group)
+	callsignList := c.composeCallsigns(call.Callsigns...)
+	return NaturalLanguageResponse{
+		Subtitle: fmt.Sprintf("%s, pop-up %s", callsignList, lowerFirst(group.Subtitle)),
+		Speech:   fmt.Sprintf("%s, pop-up %s", callsignList, group.Speech),
+	}
+}
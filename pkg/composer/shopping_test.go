@@ -0,0 +1,40 @@
+package composer
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComposeShoppingResponse_NoTargets(t *testing.T) {
+	t.Parallel()
+	c := &Composer{Callsign: "Anyface"}
+	resp := c.ComposeShoppingResponse(brevity.ShoppingResponse{Callsign: "eagle 1"})
+	assert.Equal(t, "EAGLE 1, ANYFACE, shopping, negative surface targets.", resp.Subtitle)
+	assert.Equal(t, resp.Subtitle, resp.Speech)
+}
+
+func TestComposeShoppingResponse_Groups(t *testing.T) {
+	t.Parallel()
+	c := &Composer{Callsign: "Anyface"}
+	resp := c.ComposeShoppingResponse(brevity.ShoppingResponse{
+		Callsign: "eagle 1",
+		Groups: []brevity.SurfaceGroup{
+			{
+				Category: brevity.AirDefenses,
+				Contacts: 3,
+				Vector:   brevity.NewVector(bearings.NewMagneticBearing(45*unit.Degree), 30*unit.NauticalMile),
+			},
+			{
+				Category: brevity.Ships,
+				Contacts: 1,
+				Vector:   brevity.NewVector(bearings.NewMagneticBearing(120*unit.Degree), 45*unit.NauticalMile),
+			},
+		},
+	})
+	assert.Equal(t, "EAGLE 1, ANYFACE, shopping, 2 groups. Air defenses, 045/30, 3 contacts. Ships, 120/45.", resp.Subtitle)
+	assert.Contains(t, resp.Speech, "Air defenses, 0 4 5, 30, 3 contacts.")
+}
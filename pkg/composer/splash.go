@@ -0,0 +1,31 @@
+package composer
+
+import (
+	"github.com/dharmab/skyeye/pkg/brevity"
+)
+
+// ComposeSplashCall constructs natural language brevity for announcing hostile aircraft have been destroyed.
+func (c *Composer) ComposeSplashCall(call brevity.SplashCall) (response NaturalLanguageResponse) {
+	response.WriteBoth(c.composeCallsigns(call.Callsigns...) + ", ")
+	switch call.Group.Contacts() {
+	case 1:
+		response.WriteBoth("splash one")
+	case 2:
+		response.WriteBoth("splash two")
+	default:
+		response.WriteBothf("splash %d", call.Group.Contacts())
+	}
+
+	if bullseye := call.Group.Bullseye(); bullseye != nil {
+		bullseye := c.composeBullseye(bullseye)
+		response.WriteBoth(",")
+		response.WriteResponse(bullseye)
+	}
+
+	for _, platform := range call.Group.Platforms() {
+		response.WriteBoth(", " + platform)
+	}
+
+	response.WriteBoth(".")
+	return
+}
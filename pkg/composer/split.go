@@ -0,0 +1,30 @@
+package composer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+)
+
+// ComposeSplitCall constructs natural language brevity for announcing a group has split.
+func (c *Composer) ComposeSplitCall(call brevity.SplitCall) NaturalLanguageResponse {
+	info := c.composeCoreInformationFormat(call.Groups...)
+	callsignList := c.composeCallsigns(call.Callsigns...)
+	return NaturalLanguageResponse{
+		Subtitle: fmt.Sprintf("%s, group %s split. %s", callsignList, call.Label, strings.TrimSpace(info.Subtitle)),
+		Speech:   fmt.Sprintf("%s, group %s split. %s", callsignList, call.Label, strings.TrimSpace(info.Speech)),
+	}
+}
+
+// ComposeGroupsMergedCall constructs natural language brevity for announcing several groups have merged.
+func (c *Composer) ComposeGroupsMergedCall(call brevity.GroupsMergedCall) NaturalLanguageResponse {
+	group := c.composeGroup(call.Group)
+	callsignList := c.composeCallsigns(call.Callsigns...)
+	labels := append([]string{call.Group.Label()}, call.Labels...)
+	names := strings.Join(labels[:len(labels)-1], ", ") + " and " + labels[len(labels)-1]
+	return NaturalLanguageResponse{
+		Subtitle: fmt.Sprintf("%s, groups %s merged. %s", callsignList, names, group.Subtitle),
+		Speech:   fmt.Sprintf("%s, groups %s merged. %s", callsignList, names, group.Speech),
+	}
+}
@@ -0,0 +1,42 @@
+package composer
+
+import (
+	"fmt"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+)
+
+// ComposeTargetedResponse constructs natural language brevity for committing a friendly aircraft on a group.
+func (c *Composer) ComposeTargetedResponse(response brevity.TargetedResponse) NaturalLanguageResponse {
+	callsigns := fmt.Sprintf("%s, %s", c.composeCallsigns(response.Callsign), c.composeCallsigns(c.Callsign))
+	if response.Group == nil {
+		reply := callsigns + ", unable, no group at that position."
+		return NaturalLanguageResponse{
+			Subtitle: reply,
+			Speech:   reply,
+		}
+	}
+	group := c.composeLabeledGroup(composeTargetedLabel(response.Group), response.Group)
+	return NaturalLanguageResponse{
+		Subtitle: fmt.Sprintf("%s, commit, %s", callsigns, lowerFirst(group.Subtitle)),
+		Speech:   fmt.Sprintf("%s, commit, %s", callsigns, group.Speech),
+	}
+}
+
+// ComposeTargetedCall constructs natural language brevity for updating a friendly aircraft on its targeted group.
+func (c *Composer) ComposeTargetedCall(call brevity.TargetedCall) NaturalLanguageResponse {
+	group := c.composeLabeledGroup(composeTargetedLabel(call.Group)+", now", call.Group)
+	callsign := c.composeCallsigns(call.Callsign)
+	return NaturalLanguageResponse{
+		Subtitle: fmt.Sprintf("%s, %s", callsign, lowerFirst(group.Subtitle)),
+		Speech:   fmt.Sprintf("%s, %s", callsign, group.Speech),
+	}
+}
+
+// composeTargetedLabel returns the label used to refer to a group a friendly aircraft has been assigned.
+func composeTargetedLabel(group brevity.Group) string {
+	if group.Label() == "" {
+		return "Targeted group"
+	}
+	return "Targeted group " + group.Label()
+}
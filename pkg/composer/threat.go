@@ -9,6 +9,9 @@ import (
 // ComposeThreatCall constructs natural language brevity for announcing a threat.
 func (c *Composer) ComposeThreatCall(call brevity.ThreatCall) NaturalLanguageResponse {
 	group := c.composeGroup(call.Group)
+	if call.Targeted {
+		group = c.composeLabeledGroup(composeTargetedLabel(call.Group)+", threat", call.Group)
+	}
 	callsignList := c.composeCallsigns(call.Callsigns...)
 	return NaturalLanguageResponse{
 		Subtitle: fmt.Sprintf("%s, %s", callsignList, lowerFirst(group.Subtitle)),
@@ -1,14 +1,31 @@
 package composer
 
 import (
+	"fmt"
+
 	"github.com/dharmab/skyeye/pkg/brevity"
 )
 
-// ComposeTripwireResponse constructs natural language brevity for educating a caller about threat monitoring.
+// ComposeTripwireResponse constructs natural language brevity for acknowledging a TRIPWIRE request.
 func (c *Composer) ComposeTripwireResponse(response brevity.TripwireResponse) NaturalLanguageResponse {
-	reply := c.composeCallsigns(response.Callsign) + ", I'm not OverlordBot. Tripwires are not real brevity per the MULTI-SERVICE TACTICS TECHNIQUES AND PROCEDURES for Air Control Communication. If your name is set correctly in-game and in SRS, I'll automatically monitor you on the radar and provide threat warnings. You can also send requests such as picture, bogey dope, snaplock, spiked, declare and alpha check."
+	reply := fmt.Sprintf(
+		"%s, %s, tripwire set, %.0f miles.",
+		c.composeCallsigns(response.Callsign),
+		c.composeCallsigns(c.Callsign),
+		response.Range.NauticalMiles(),
+	)
 	return NaturalLanguageResponse{
 		Subtitle: reply,
 		Speech:   reply,
 	}
 }
+
+// ComposeTripwireCall constructs natural language brevity for announcing a hostile group has crossed a tripwire.
+func (c *Composer) ComposeTripwireCall(call brevity.TripwireCall) NaturalLanguageResponse {
+	group := c.composeGroup(call.Group)
+	callsign := c.composeCallsigns(call.Callsign)
+	return NaturalLanguageResponse{
+		Subtitle: fmt.Sprintf("%s, tripwire, %s", callsign, lowerFirst(group.Subtitle)),
+		Speech:   fmt.Sprintf("%s, tripwire, %s", callsign, group.Speech),
+	}
+}
@@ -25,6 +25,19 @@ func (c *Composer) ComposeSayAgainResponse(response brevity.SayAgainResponse) Na
 			"Sorry, I only caught part of that. Say again.",
 		},
 	}
+	if response.SteppedOn {
+		replies = map[bool][]string{
+			true: {
+				"%s, you were stepped on. Say again.",
+				"%s, say again, stepped on.",
+				"%s, you got stepped on. Say again.",
+			},
+			false: {
+				"Stepped on. Say again.",
+				"Two stations at once, you were stepped on. Say again.",
+			},
+		}
+	}
 	haveCallsign := response.Callsign != ""
 	variation := replies[haveCallsign][rand.IntN(len(replies[haveCallsign]))]
 	reply := ""
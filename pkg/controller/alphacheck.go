@@ -11,6 +11,9 @@ import (
 func (c *Controller) HandleAlphaCheck(ctx context.Context, request *brevity.AlphaCheckRequest) {
 	logger := log.With().Str("callsign", request.Callsign).Type("type", request).Logger()
 	logger.Debug().Msg("handling request")
+	if c.isOffStation(ctx, request.Callsign) {
+		return
+	}
 
 	foundCallsign, trackfile, ok := c.findCallsign(request.Callsign)
 	if !ok {
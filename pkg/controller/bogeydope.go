@@ -12,6 +12,9 @@ import (
 func (c *Controller) HandleBogeyDope(ctx context.Context, request *brevity.BogeyDopeRequest) {
 	logger := log.With().Str("callsign", request.Callsign).Type("type", request).Any("filter", request.Filter).Logger()
 	logger.Debug().Msg("handling request")
+	if c.isOffStation(ctx, request.Callsign) {
+		return
+	}
 
 	foundCallsign, trackfile, ok := c.findCallsign(request.Callsign)
 	if !ok {
@@ -20,7 +23,7 @@ func (c *Controller) HandleBogeyDope(ctx context.Context, request *brevity.Bogey
 	}
 	logger = logger.With().Str("callsign", foundCallsign).Logger()
 
-	origin := trackfile.LastKnown().Point
+	origin := c.scope.Position(trackfile)
 	radius := 300 * unit.NauticalMile
 	nearestGroup := c.scope.FindNearestGroupWithBRAA(
 		origin,
@@ -1,6 +1,8 @@
 package controller
 
 import (
+	"slices"
+
 	"github.com/dharmab/skyeye/pkg/brevity"
 	"github.com/dharmab/skyeye/pkg/coalitions"
 	"github.com/dharmab/skyeye/pkg/traces"
@@ -16,13 +18,34 @@ func (c *Controller) handleStarted() {
 	c.merges.reset()
 	c.threatCooldowns.reset()
 	c.mergeCooldowns.reset()
+	c.tripwires.reset()
+	c.tripwireCooldowns.reset()
+	c.popUpCooldowns.reset()
+	c.leakers.reset()
+	c.leakerCooldowns.reset()
+	c.audiences.reset()
+	c.groupChangeCooldowns.reset()
+	c.targets.reset()
+	c.targetCooldowns.reset()
+	c.intercepts.reset()
+	c.interceptCooldowns.reset()
+	c.missileCooldowns.reset()
 	c.wasLastPictureClean = false
+	c.onStation.Store(c.scope.IsOnStation())
 }
 
 func (c *Controller) handleFaded(location orb.Point, group brevity.Group, coalition coalitions.Coalition) {
+	targetingCallsigns := make([]string, 0)
+	for _, friendID := range c.targets.targetedBy(group.ObjectIDs()...) {
+		if callsign, _, ok := c.targets.get(friendID); ok && !slices.Contains(targetingCallsigns, callsign) {
+			targetingCallsigns = append(targetingCallsigns, callsign)
+		}
+	}
 	for _, id := range group.ObjectIDs() {
 		c.remove(id)
 	}
+	c.updateStation(traces.NewRequestContext())
+	isOnStation := c.onStation.Load()
 	isHostile := coalition == c.coalition.Opposite()
 	areHumansOnFrequency := c.srsClient.HumansOnFrequency() > 0
 	nearbyFriendlies := c.scope.FindNearbyGroupsWithBullseye(
@@ -34,14 +57,15 @@ func (c *Controller) handleFaded(location orb.Point, group brevity.Group, coalit
 		brevity.Aircraft,
 		[]uint64{},
 	)
-	isNearFriendly := len(nearbyFriendlies) > 0
+	isNearFriendly := len(nearbyFriendlies) > 0 || len(targetingCallsigns) > 0
 
-	if isHostile && isNearFriendly && areHumansOnFrequency {
+	if isOnStation && isHostile && isNearFriendly && areHumansOnFrequency {
 		log.Info().Stringer("group", group).Msg("broadcasting FADED call")
 		group.SetDeclaration(brevity.Hostile)
-		c.calls <- NewCall(traces.NewRequestContext(), brevity.FadedCall{Group: group})
+		c.calls <- NewCall(traces.NewRequestContext(), brevity.FadedCall{Callsigns: targetingCallsigns, Group: group})
 	} else {
 		log.Debug().
+			Bool("isOnStation", isOnStation).
 			Bool("isHostile", isHostile).
 			Bool("isNearFriendly", isNearFriendly).
 			Bool("areHumansOnFrequency", areHumansOnFrequency).
@@ -51,6 +75,7 @@ func (c *Controller) handleFaded(location orb.Point, group brevity.Group, coalit
 
 func (c *Controller) handleRemoved(trackfile *trackfiles.Trackfile) {
 	c.remove(trackfile.Contact.ID)
+	c.updateStation(traces.NewRequestContext())
 }
 
 func (c *Controller) remove(id uint64) {
@@ -58,4 +83,16 @@ func (c *Controller) remove(id uint64) {
 	c.threatCooldowns.remove(id)
 	c.mergeCooldowns.remove(id)
 	c.merges.remove(id)
+	c.tripwires.remove(id)
+	c.tripwireCooldowns.remove(id)
+	c.popUpCooldowns.remove(id)
+	c.leakers.remove(id)
+	c.leakerCooldowns.remove(id)
+	c.audiences.remove(id)
+	c.groupChangeCooldowns.remove(id)
+	c.targets.remove(id)
+	c.targetCooldowns.remove(id)
+	c.intercepts.remove(id)
+	c.interceptCooldowns.remove(id)
+	c.missileCooldowns.remove(id)
 }
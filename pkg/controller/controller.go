@@ -4,6 +4,7 @@ package controller
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/dharmab/skyeye/pkg/coalitions"
@@ -50,6 +51,8 @@ type Controller struct {
 
 	// srsClient is used to check if relevant friendly aircraft are on frequency before broadcasting calls.
 	srsClient *simpleradio.Client
+	// frequencies are the SRS frequencies and their roles, used to route calls to frequencies.
+	frequencies []simpleradio.RadioFrequency
 
 	// enableAutomaticPicture enables automatic picture broadcasts.
 	enableAutomaticPicture bool
@@ -77,6 +80,46 @@ type Controller struct {
 	// mergeCooldowns tracks the next time a merge call may be published for each friendly.
 	mergeCooldowns *cooldownTracker
 
+	// tripwires tracks the tripwires set by friendly aircraft.
+	tripwires *tripwireTracker
+	// tripwireCooldowns tracks the next time a tripwire call may be published for each friendly.
+	tripwireCooldowns *cooldownTracker
+
+	// popUpRadius is the distance from friendly aircraft within which pop-up groups are announced. If zero, POP-UP
+	// calls are disabled.
+	popUpRadius unit.Length
+	// popUpCooldowns tracks the next time a pop-up call may be published for each hostile.
+	popUpCooldowns *cooldownTracker
+
+	// leakerLines are the lines which trigger LEAKER calls when crossed by hostile aircraft.
+	leakerLines []locations.Line
+	// leakers tracks hostile positions between checks of the leaker lines.
+	leakers *leakerTracker
+	// leakerCooldowns tracks the next time a leaker call may be published for each hostile.
+	leakerCooldowns *cooldownTracker
+
+	// audiences tracks which friendlies have been warned about each hostile.
+	audiences *audienceTracker
+	// groupChangeCooldowns tracks the next time a split or merged call may be published for each hostile.
+	groupChangeCooldowns *cooldownTracker
+
+	// targets tracks the hostile groups assigned to friendly aircraft.
+	targets *targetTracker
+	// targetCooldowns tracks the next time a targeted update may be published for each friendly.
+	targetCooldowns *cooldownTracker
+
+	// intercepts tracks the hostile groups friendly aircraft are being steered onto.
+	intercepts *targetTracker
+	// interceptCooldowns tracks the next time a steering call may be published for each friendly.
+	interceptCooldowns *cooldownTracker
+
+	// missileCooldowns tracks the next time a missile call may be published for each friendly.
+	missileCooldowns *cooldownTracker
+
+	// onStation indicates whether the bound AWACS aircraft is airborne. If no AWACS is bound, the controller is always
+	// on station. While off station, the controller does not broadcast calls or answer radar requests.
+	onStation atomic.Bool
+
 	// calls is the channel to publish responses and calls to.
 	calls chan<- Call
 }
@@ -91,9 +134,11 @@ func New(
 	enableThreatMonitoring bool,
 	threatMonitoringCooldown time.Duration,
 	threatMonitoringRequiresSRS bool,
+	popUpRadius unit.Length,
 	locs []locations.Location,
+	leakerLines []locations.Line,
 ) *Controller {
-	return &Controller{
+	c := &Controller{
 		coalition:                   coalition,
 		scope:                       rdr,
 		locations:                   locs,
@@ -107,7 +152,23 @@ func New(
 		threatMonitoringRequiresSRS: threatMonitoringRequiresSRS,
 		merges:                      newMergeTracker(),
 		mergeCooldowns:              newCooldownTracker(30 * time.Second),
+		tripwires:                   newTripwireTracker(),
+		tripwireCooldowns:           newCooldownTracker(tripwireCooldown),
+		popUpRadius:                 popUpRadius,
+		popUpCooldowns:              newCooldownTracker(popUpCooldown),
+		leakerLines:                 leakerLines,
+		leakers:                     newLeakerTracker(),
+		leakerCooldowns:             newCooldownTracker(leakerCooldown),
+		audiences:                   newAudienceTracker(),
+		groupChangeCooldowns:        newCooldownTracker(groupChangeCooldown),
+		targets:                     newTargetTracker(),
+		targetCooldowns:             newCooldownTracker(targetedCooldown),
+		intercepts:                  newTargetTracker(),
+		interceptCooldowns:          newCooldownTracker(interceptCooldown),
+		missileCooldowns:            newCooldownTracker(missileCooldown),
 	}
+	c.onStation.Store(rdr.IsOnStation())
+	return c
 }
 
 // Run starts the controller's control loops. It should be called exactly once. It blocks until the context is canceled.
@@ -117,10 +178,16 @@ func (c *Controller) Run(ctx context.Context, calls chan<- Call) {
 
 	log.Info().Msg("attaching callbacks")
 	c.scope.SetFadedCallback(c.handleFaded)
+	c.scope.SetDestroyedCallback(c.handleDestroyed)
+	c.scope.SetLaunchedCallback(c.handleLaunched)
 	c.scope.SetRemovedCallback(c.handleRemoved)
 	c.scope.SetStartedCallback(c.handleStarted)
 
-	c.broadcastSunrise(ctx)
+	if c.onStation.Load() {
+		c.broadcastSunrise(ctx)
+	} else {
+		log.Info().Msg("waiting for AWACS aircraft before going on station")
+	}
 
 	ticker := time.NewTicker(15 * time.Second)
 	defer ticker.Stop()
@@ -130,12 +197,26 @@ func (c *Controller) Run(ctx context.Context, calls chan<- Call) {
 		case <-ctx.Done():
 			log.Info().Msg("detaching callbacks")
 			c.scope.SetFadedCallback(nil)
+			c.scope.SetDestroyedCallback(nil)
+			c.scope.SetLaunchedCallback(nil)
 			c.scope.SetRemovedCallback(nil)
 			c.scope.SetStartedCallback(nil)
 			return
 		case <-ticker.C:
+			c.updateStation(traces.WithTraceID(ctx, shortuuid.New()))
+			if !c.onStation.Load() {
+				continue
+			}
+			c.updateSRSPosition()
+			// Group changes are broadcast first, since this is where group labels are updated for the tick.
+			c.broadcastGroupChanges(traces.WithTraceID(ctx, shortuuid.New()))
 			c.broadcastMerges(traces.WithTraceID(ctx, shortuuid.New()))
 			c.broadcastThreats(traces.WithTraceID(ctx, shortuuid.New()))
+			c.broadcastTargets(traces.WithTraceID(ctx, shortuuid.New()))
+			c.broadcastIntercepts(traces.WithTraceID(ctx, shortuuid.New()))
+			c.broadcastTripwires(traces.WithTraceID(ctx, shortuuid.New()))
+			c.broadcastPopUps(traces.WithTraceID(ctx, shortuuid.New()))
+			c.broadcastLeakers(traces.WithTraceID(ctx, shortuuid.New()))
 			c.broadcastAutomaticPicture(traces.WithTraceID(ctx, shortuuid.New()))
 		}
 	}
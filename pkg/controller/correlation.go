@@ -25,7 +25,7 @@ func (c *Controller) correlate(logger zerolog.Logger, callsign string, bearing b
 		return correlation{}
 	}
 
-	origin := trackfile.LastKnown().Point
+	origin := c.scope.Position(trackfile)
 	arc := unit.Angle(30) * unit.Degree
 	distance := unit.Length(120) * unit.NauticalMile
 	nearestGroup := c.scope.FindNearestGroupInSector(
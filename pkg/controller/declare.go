@@ -15,6 +15,9 @@ import (
 func (c *Controller) HandleDeclare(ctx context.Context, request *brevity.DeclareRequest) {
 	logger := log.With().Str("callsign", request.Callsign).Type("type", request).Logger()
 	logger.Debug().Msg("handling request")
+	if c.isOffStation(ctx, request.Callsign) {
+		return
+	}
 
 	foundCallsign, trackfile, ok := c.findCallsign(request.Callsign)
 	if !ok {
@@ -62,7 +65,7 @@ func (c *Controller) HandleDeclare(ctx context.Context, request *brevity.Declare
 		if !request.Bearing.IsMagnetic() {
 			logger.Warn().Stringer("bearing", request.Bearing).Msg("bearing provided to HandleDeclare should be magnetic")
 		}
-		origin = trackfile.LastKnown().Point
+		origin = c.scope.Position(trackfile)
 		declination := c.scope.Declination(origin)
 		bearing = request.Bearing.True(declination)
 		distance = request.Range
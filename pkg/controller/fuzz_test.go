@@ -372,3 +372,42 @@ func TestFuzz_HandleVector(t *testing.T) {
 		}
 	})
 }
+
+func TestFuzz_HandleTargeted(t *testing.T) {
+	t.Parallel()
+	runFuzz(t, 55, nil, func(t *testing.T, h *controllerTestHarness, rng *rand.Rand) {
+		t.Helper()
+		h.ctrl.HandleTargeted(h.ctx, &brevity.TargetedRequest{
+			Callsign: randomCallsign(rng),
+			Bullseye: brevity.NewBullseye(randomBearing(rng), randomRange(rng)),
+		})
+		got := h.expectResponse(t)
+		switch got.(type) {
+		case brevity.TargetedResponse, brevity.NegativeRadarContactResponse:
+		default:
+			t.Fatalf("unexpected response type %T", got)
+		}
+	})
+}
+
+func TestFuzz_HandleIntercept(t *testing.T) {
+	t.Parallel()
+	runFuzz(t, 56, nil, func(t *testing.T, h *controllerTestHarness, rng *rand.Rand) {
+		t.Helper()
+		request := &brevity.InterceptRequest{
+			Callsign:  randomCallsign(rng),
+			Terminate: rng.IntN(4) == 0,
+		}
+		if rng.IntN(2) == 0 {
+			request.Bullseye = brevity.NewBullseye(randomBearing(rng), randomRange(rng))
+		}
+		h.ctrl.HandleIntercept(h.ctx, request)
+		got := h.expectResponse(t)
+		switch got.(type) {
+		case brevity.InterceptResponse, brevity.NegativeRadarContactResponse:
+		default:
+			t.Fatalf("unexpected response type %T", got)
+		}
+		h.ctrl.broadcastIntercepts(h.ctx)
+	})
+}
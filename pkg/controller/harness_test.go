@@ -34,7 +34,9 @@ func newControllerTestHarness(t *testing.T, locs []locations.Location) *controll
 	starts := make(chan sim.Started)
 	updates := make(chan sim.Updated, 16)
 	fades := make(chan sim.Faded)
-	rdr := radar.New(coalitions.Blue, starts, updates, fades, 25*unit.NauticalMile, 5*unit.Degree, 1*unit.NauticalMile, false, nil)
+	destroys := make(chan sim.Destroyed)
+	launches := make(chan sim.Launched)
+	rdr := radar.New(coalitions.Blue, starts, updates, fades, destroys, launches, 25*unit.NauticalMile, 5*unit.Degree, 1*unit.NauticalMile, false, nil)
 	rdr.SetBullseye(orb.Point{30.0, 40.0}, coalitions.Blue)
 	rdr.SetBullseye(orb.Point{35.0, 33.0}, coalitions.Red)
 	rdr.SetMissionTime(time.Now())
@@ -50,7 +52,9 @@ func newControllerTestHarness(t *testing.T, locs []locations.Location) *controll
 		false, 0,
 		false, 0,
 		false,
+		0,
 		locs,
+		nil,
 	)
 	calls := make(chan Call, 8)
 	ctrl.calls = calls
@@ -90,6 +94,7 @@ type insertOption func(*insertConfig)
 
 type insertConfig struct {
 	heading unit.Angle
+	time    time.Time
 }
 
 func withHeading(heading unit.Angle) insertOption {
@@ -98,6 +103,12 @@ func withHeading(heading unit.Angle) insertOption {
 	}
 }
 
+func withTime(t time.Time) insertOption {
+	return func(c *insertConfig) {
+		c.time = t
+	}
+}
+
 // insertAircraft pushes updates into the radar's channel and waits for the
 // trackfile to appear with a populated frame. Two updates are sent because
 // the radar's handleUpdate path only applies a frame when the trackfile
@@ -106,7 +117,7 @@ func (h *controllerTestHarness) insertAircraft(t *testing.T, name, acmiName stri
 	t.Helper()
 	h.nextID++
 	id := h.nextID
-	cfg := insertConfig{heading: 90 * unit.Degree}
+	cfg := insertConfig{heading: 90 * unit.Degree, time: time.Now()}
 	for _, opt := range opts {
 		opt(&cfg)
 	}
@@ -126,7 +137,7 @@ func (h *controllerTestHarness) insertAircraft(t *testing.T, name, acmiName stri
 		point[1] - offset*math.Cos(rad),
 	}
 	frame := trackfiles.Frame{
-		Time:     time.Now(),
+		Time:     cfg.time,
 		Point:    prevPoint,
 		Altitude: 20000 * unit.Foot,
 		AGL:      &agl,
@@ -0,0 +1,173 @@
+package controller
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// interceptSearchRadius is the distance from the requesting aircraft within which the nearest hostile group may be
+	// intercepted, when the pilot does not specify a group.
+	interceptSearchRadius = 300 * unit.NauticalMile
+	// interceptCooldown is the interval between steering calls to the same friendly aircraft.
+	interceptCooldown = 30 * time.Second
+	// interceptTurnThreshold is the smallest heading change which is called as a turn. Smaller corrections are called
+	// as steady.
+	interceptTurnThreshold = 10 * unit.Degree
+)
+
+// HandleIntercept handles an INTERCEPT request by steering the requesting aircraft onto a hostile group, or by
+// ending a previous intercept.
+func (c *Controller) HandleIntercept(ctx context.Context, request *brevity.InterceptRequest) {
+	logger := log.With().Str("callsign", request.Callsign).Type("type", request).Logger()
+	logger.Debug().Msg("handling request")
+	if c.isOffStation(ctx, request.Callsign) {
+		return
+	}
+
+	foundCallsign, trackfile, ok := c.findCallsign(request.Callsign)
+	if !ok {
+		c.calls <- NewCall(ctx, brevity.NegativeRadarContactResponse{Callsign: request.Callsign})
+		return
+	}
+	friendID := trackfile.Contact.ID
+
+	if request.Terminate {
+		logger.Info().Str("callsign", foundCallsign).Msg("terminating intercept")
+		c.intercepts.clear(friendID)
+		c.calls <- NewCall(ctx, brevity.InterceptResponse{Callsign: foundCallsign, Terminate: true})
+		return
+	}
+
+	origin := c.scope.Position(trackfile)
+	var group brevity.Group
+	if request.Bullseye != nil {
+		logger.Debug().Msg("locating group to intercept using bullseye")
+		group = c.findHostileGroupAtBullseye(trackfile, request.Bullseye)
+	} else if _, hostileIDs, ok := c.targets.get(friendID); ok {
+		logger.Debug().Msg("intercepting targeted group")
+		group = c.scope.FindGroupWithBRAA(origin, hostileIDs)
+	} else {
+		logger.Debug().Msg("intercepting nearest hostile group")
+		group = c.scope.FindNearestGroupWithBRAA(
+			origin,
+			lowestAltitude,
+			highestAltitude,
+			interceptSearchRadius,
+			c.coalition.Opposite(),
+			brevity.Aircraft,
+		)
+	}
+	if group == nil {
+		logger.Info().Msg("no group found to intercept")
+		c.calls <- NewCall(ctx, brevity.InterceptResponse{Callsign: foundCallsign})
+		return
+	}
+
+	group.SetDeclaration(brevity.Hostile)
+	c.fillInMergeDetails(group)
+	steering := c.computeSteering(trackfile, group)
+	logger.Info().Stringer("group", group).Stringer("heading", steering.Heading).Msg("starting intercept")
+	c.intercepts.set(friendID, foundCallsign, group.ObjectIDs()...)
+	c.interceptCooldowns.extendCooldown(friendID)
+	c.calls <- NewCall(ctx, brevity.InterceptResponse{Callsign: foundCallsign, Group: group, Steering: steering})
+}
+
+// computeSteering computes the heading the given friendly aircraft should fly to intercept the given group. A lead
+// intercept is used if the friendly aircraft is fast enough to catch the group; otherwise, the friendly aircraft is
+// steered directly at the group.
+func (c *Controller) computeSteering(friendly *trackfiles.Trackfile, group brevity.Group) brevity.Steering {
+	origin := c.scope.Position(friendly)
+	course, speed := friendly.Velocity(c.withProjection())
+
+	var heading bearings.Bearing
+	lead := c.scope.FindUnit(group.ObjectIDs()[0])
+	if lead != nil {
+		target := c.scope.Position(lead)
+		targetCourse, targetSpeed := lead.Velocity(c.withProjection())
+		bearing, _, ok := spatial.LeadIntercept(origin, speed, target, targetCourse, targetSpeed, c.withProjection())
+		if !ok {
+			bearing = spatial.TrueBearing(origin, target, c.withProjection())
+		}
+		heading = bearing
+	} else {
+		heading = group.BRAA().Bearing().True(c.scope.Declination(origin))
+	}
+
+	// Normalize the heading change to the range (-180, 180], where positive values are to the right.
+	change := math.Mod(heading.Degrees()-course.Degrees()+540, 360) - 180
+	turn := brevity.NoTurn
+	if math.Abs(change) >= interceptTurnThreshold.Degrees() {
+		if change > 0 {
+			turn = brevity.RightTurn
+		} else {
+			turn = brevity.LeftTurn
+		}
+	}
+	return brevity.Steering{
+		Turn:    turn,
+		Heading: heading.Magnetic(c.scope.Declination(origin)),
+	}
+}
+
+// broadcastIntercepts broadcasts steering calls to every friendly with an intercept in progress.
+func (c *Controller) broadcastIntercepts(ctx context.Context) {
+	for _, friendID := range c.intercepts.friendIDs() {
+		c.broadcastIntercept(ctx, friendID)
+	}
+}
+
+func (c *Controller) broadcastIntercept(ctx context.Context, friendID uint64) {
+	callsign, hostileIDs, ok := c.intercepts.get(friendID)
+	if !ok {
+		return
+	}
+	logger := log.With().Uint64("friendID", friendID).Str("callsign", callsign).Logger()
+
+	friendly := c.scope.FindUnit(friendID)
+	if friendly == nil {
+		logger.Info().Msg("ending intercept because the friendly is no longer on the scope")
+		c.intercepts.remove(friendID)
+		return
+	}
+	if friendly.IsLastKnownPointZero() {
+		return
+	}
+
+	group := c.scope.FindGroupWithBRAA(c.scope.Position(friendly), hostileIDs)
+	if group == nil {
+		logger.Info().Msg("ending intercept because the group is no longer on the scope")
+		c.intercepts.clear(friendID)
+		return
+	}
+	if c.isGroupMergedWithFriendly(group, friendID) || group.BRAA().Range() < brevity.MergeEntryDistance {
+		logger.Info().Stringer("group", group).Msg("ending intercept at the merge")
+		c.intercepts.clear(friendID)
+		return
+	}
+	// Follow the group as contacts join or leave it.
+	c.intercepts.set(friendID, callsign, group.ObjectIDs()...)
+
+	if c.interceptCooldowns.isOnCooldown(friendID) {
+		return
+	}
+	if c.threatMonitoringRequiresSRS && !c.srsClient.IsOnFrequency(friendly.Contact.Name) {
+		logger.Debug().Msg("skipping steering call because the friendly is not on frequency")
+		return
+	}
+
+	group.SetDeclaration(brevity.Hostile)
+	c.fillInMergeDetails(group)
+	steering := c.computeSteering(friendly, group)
+	logger.Info().Stringer("group", group).Stringer("heading", steering.Heading).Msg("broadcasting steering call")
+	c.calls <- NewCall(ctx, brevity.InterceptCall{Callsign: callsign, Steering: steering, Group: group})
+	c.interceptCooldowns.extendCooldown(friendID)
+}
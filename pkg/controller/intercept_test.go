@@ -0,0 +1,153 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleIntercept_NearestGroup(t *testing.T) {
+	t.Parallel()
+	h := newControllerTestHarness(t, nil)
+	h.insertAircraft(t, "Viper 1 Reaper", acmiF16C, coalitions.Blue, orb.Point{30.0, 40.0})
+	h.insertAircraft(t, "Bandit 1", acmiSu27, coalitions.Red, orb.Point{30.5, 40.0}, withHeading(270*unit.Degree))
+
+	h.ctrl.HandleIntercept(h.ctx, &brevity.InterceptRequest{Callsign: "viper 1"})
+	got := h.expectResponse(t)
+	resp, ok := got.(brevity.InterceptResponse)
+	require.True(t, ok)
+	assert.Equal(t, "viper 1", resp.Callsign)
+	assert.False(t, resp.Terminate)
+	require.NotNil(t, resp.Group)
+	assert.Equal(t, brevity.Hostile, resp.Group.Declaration())
+	assert.Equal(t, brevity.NoTurn, resp.Steering.Turn)
+	assert.True(t, resp.Steering.Heading.IsMagnetic())
+	assert.True(t, h.ctrl.intercepts.isTargeting(1, 2))
+}
+
+func TestHandleIntercept_LeadTurn(t *testing.T) {
+	t.Parallel()
+	h := newControllerTestHarness(t, nil)
+	h.insertAircraft(t, "Viper 1 Reaper", acmiF16C, coalitions.Blue, orb.Point{30.0, 40.0})
+	h.insertAircraft(t, "Bandit 1", acmiSu27, coalitions.Red, orb.Point{30.0, 40.3}, withHeading(270*unit.Degree))
+	for id, point := range map[uint64]orb.Point{1: {30.03, 40.0}, 2: {29.98, 40.3}} {
+		trackfile := h.rdr.FindUnit(id)
+		require.NotNil(t, trackfile)
+		frame := trackfile.LastKnown()
+		frame.Time = frame.Time.Add(10 * time.Second)
+		frame.Point = point
+		trackfile.Update(frame)
+	}
+
+	h.ctrl.HandleIntercept(h.ctx, &brevity.InterceptRequest{Callsign: "viper 1"})
+	got := h.expectResponse(t)
+	resp, ok := got.(brevity.InterceptResponse)
+	require.True(t, ok)
+	require.NotNil(t, resp.Group)
+	// The bandit is north of the eastbound friendly, heading west and slower than the friendly. The lead heading is
+	// ahead of the bandit, to the northwest.
+	assert.Equal(t, brevity.LeftTurn, resp.Steering.Turn)
+	heading := resp.Steering.Heading.True(h.rdr.Declination(orb.Point{30.0, 40.0}))
+	assert.Greater(t, heading.Degrees(), 270.0)
+	assert.Less(t, heading.Degrees(), 360.0)
+}
+
+func TestHandleIntercept_TargetedGroup(t *testing.T) {
+	t.Parallel()
+	h := newControllerTestHarness(t, nil)
+	h.insertAircraft(t, "Viper 1 Reaper", acmiF16C, coalitions.Blue, orb.Point{30.0, 40.0})
+	h.insertAircraft(t, "Bandit 1", acmiSu27, coalitions.Red, orb.Point{30.2, 40.0})
+	h.insertAircraft(t, "Bandit 2", acmiSu27, coalitions.Red, orb.Point{30.5, 40.0})
+
+	h.ctrl.HandleTargeted(h.ctx, &brevity.TargetedRequest{
+		Callsign: "viper 1",
+		Bullseye: brevity.NewBullseye(bearings.NewMagneticBearing(90*unit.Degree), 25*unit.NauticalMile),
+	})
+	_ = h.expectResponse(t)
+
+	h.ctrl.HandleIntercept(h.ctx, &brevity.InterceptRequest{Callsign: "viper 1"})
+	resp, ok := h.expectResponse(t).(brevity.InterceptResponse)
+	require.True(t, ok)
+	require.NotNil(t, resp.Group)
+	assert.Equal(t, []uint64{3}, resp.Group.ObjectIDs())
+}
+
+func TestHandleIntercept_Clean(t *testing.T) {
+	t.Parallel()
+	h := newControllerTestHarness(t, nil)
+	h.insertAircraft(t, "Viper 1 Reaper", acmiF16C, coalitions.Blue, orb.Point{30.0, 40.0})
+
+	h.ctrl.HandleIntercept(h.ctx, &brevity.InterceptRequest{Callsign: "viper 1"})
+	resp, ok := h.expectResponse(t).(brevity.InterceptResponse)
+	require.True(t, ok)
+	assert.Nil(t, resp.Group)
+	assert.False(t, resp.Terminate)
+	assert.Empty(t, h.ctrl.intercepts.friendIDs())
+}
+
+func TestHandleIntercept_Terminate(t *testing.T) {
+	t.Parallel()
+	h := newControllerTestHarness(t, nil)
+	h.insertAircraft(t, "Viper 1 Reaper", acmiF16C, coalitions.Blue, orb.Point{30.0, 40.0})
+	h.insertAircraft(t, "Bandit 1", acmiSu27, coalitions.Red, orb.Point{30.5, 40.0})
+
+	h.ctrl.HandleIntercept(h.ctx, &brevity.InterceptRequest{Callsign: "viper 1"})
+	_ = h.expectResponse(t)
+	require.NotEmpty(t, h.ctrl.intercepts.friendIDs())
+
+	h.ctrl.HandleIntercept(h.ctx, &brevity.InterceptRequest{Callsign: "viper 1", Terminate: true})
+	resp, ok := h.expectResponse(t).(brevity.InterceptResponse)
+	require.True(t, ok)
+	assert.True(t, resp.Terminate)
+	assert.Empty(t, h.ctrl.intercepts.friendIDs())
+}
+
+func TestHandleIntercept_CallsignNotOnRadar(t *testing.T) {
+	t.Parallel()
+	h := newControllerTestHarness(t, nil)
+
+	h.ctrl.HandleIntercept(h.ctx, &brevity.InterceptRequest{Callsign: "viper 1"})
+	resp, ok := h.expectResponse(t).(brevity.NegativeRadarContactResponse)
+	require.True(t, ok)
+	assert.Equal(t, "viper 1", resp.Callsign)
+}
+
+func TestBroadcastIntercepts(t *testing.T) {
+	t.Parallel()
+	h := newControllerTestHarness(t, nil)
+	h.insertAircraft(t, "Viper 1 Reaper", acmiF16C, coalitions.Blue, orb.Point{30.0, 40.0})
+	h.insertAircraft(t, "Bandit 1", acmiSu27, coalitions.Red, orb.Point{30.5, 40.0}, withHeading(270*unit.Degree))
+
+	h.ctrl.HandleIntercept(h.ctx, &brevity.InterceptRequest{Callsign: "viper 1"})
+	_ = h.expectResponse(t)
+
+	// The initial response counts as the first steering call.
+	h.ctrl.broadcastIntercepts(h.ctx)
+	assert.Empty(t, h.calls)
+
+	h.ctrl.interceptCooldowns.remove(1)
+	h.ctrl.broadcastIntercepts(h.ctx)
+	call, ok := h.expectResponse(t).(brevity.InterceptCall)
+	require.True(t, ok)
+	assert.Equal(t, "viper 1", call.Callsign)
+	assert.Equal(t, []uint64{2}, call.Group.ObjectIDs())
+	require.NotNil(t, call.Group.BRAA())
+
+	// The intercept ends at the merge.
+	bandit := h.rdr.FindUnit(2)
+	frame := bandit.LastKnown()
+	frame.Time = frame.Time.Add(time.Second)
+	frame.Point = orb.Point{30.02, 40.0}
+	bandit.Update(frame)
+	h.ctrl.interceptCooldowns.remove(1)
+	h.ctrl.broadcastIntercepts(h.ctx)
+	assert.Empty(t, h.calls)
+	assert.Empty(t, h.ctrl.intercepts.friendIDs())
+}
@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/paulmach/orb"
+	"github.com/rs/zerolog/log"
+)
+
+// leakerCooldown suppresses repeated LEAKER calls for the same contact, such as when a hostile flies along a line.
+const leakerCooldown = 5 * time.Minute
+
+// leakerTracker records the position of each hostile contact when the lines were last checked, so that crossings
+// can be detected between checks.
+type leakerTracker struct {
+	positions map[uint64]orb.Point
+	lock      sync.RWMutex
+}
+
+func newLeakerTracker() *leakerTracker {
+	return &leakerTracker{
+		positions: make(map[uint64]orb.Point),
+	}
+}
+
+// previous returns a copy of the recorded positions.
+func (t *leakerTracker) previous() map[uint64]orb.Point {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	positions := make(map[uint64]orb.Point, len(t.positions))
+	for id, point := range t.positions {
+		positions[id] = point
+	}
+	return positions
+}
+
+// record replaces the recorded positions with the last known positions of the given trackfiles.
+func (t *leakerTracker) record(trackfiles []*trackfiles.Trackfile) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.positions = make(map[uint64]orb.Point, len(trackfiles))
+	for _, trackfile := range trackfiles {
+		if trackfile.IsLastKnownPointZero() {
+			continue
+		}
+		t.positions[trackfile.Contact.ID] = trackfile.LastKnown().Point
+	}
+}
+
+func (t *leakerTracker) remove(id uint64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	delete(t.positions, id)
+}
+
+func (t *leakerTracker) reset() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.positions = make(map[uint64]orb.Point)
+}
+
+// broadcastLeakers broadcasts LEAKER calls for hostile groups which have crossed any of the configured lines since
+// the previous check.
+func (c *Controller) broadcastLeakers(ctx context.Context) {
+	if len(c.leakerLines) == 0 {
+		return
+	}
+	previous := c.leakers.previous()
+	defer c.leakers.record(c.scope.FindByCoalition(c.coalition.Opposite()))
+
+	if c.threatMonitoringRequiresSRS && c.srsClient.HumansOnFrequency() == 0 {
+		log.Debug().Msg("skipping leaker calls because no humans are on frequency")
+		return
+	}
+
+	for _, line := range c.leakerLines {
+		for _, group := range c.scope.Leakers(c.coalition.Opposite(), line.LineString(), previous) {
+			c.broadcastLeaker(ctx, line.Name, group)
+		}
+	}
+}
+
+func (c *Controller) broadcastLeaker(ctx context.Context, line string, group brevity.Group) {
+	logger := log.With().Str("line", line).Stringer("group", group).Logger()
+
+	recentlyNotified := true
+	for _, id := range group.ObjectIDs() {
+		if !c.leakerCooldowns.isOnCooldown(id) {
+			recentlyNotified = false
+			break
+		}
+	}
+	if recentlyNotified {
+		logger.Debug().Msg("suppressing leaker call because a call was recently broadcast for all contacts within the group")
+		return
+	}
+
+	group.SetDeclaration(brevity.Hostile)
+	c.fillInMergeDetails(group)
+	logger.Info().Msg("broadcasting leaker call")
+	c.calls <- NewCall(ctx, brevity.LeakerCall{Line: line, Group: group})
+
+	for _, id := range group.ObjectIDs() {
+		c.leakerCooldowns.extendCooldown(id)
+	}
+}
@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/locations"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroadcastLeakers(t *testing.T) {
+	t.Parallel()
+	h := newControllerTestHarness(t, nil)
+	h.ctrl.leakerLines = []locations.Line{
+		{Name: "Alpha", Points: []locations.Coordinates{{Longitude: 30.2, Latitude: 39.5}, {Longitude: 30.2, Latitude: 40.5}}},
+	}
+	h.insertAircraft(t, "Flanker", acmiSu27, coalitions.Red, orb.Point{30.1, 40.1})
+
+	// The first check only records positions.
+	h.ctrl.broadcastLeakers(h.ctx)
+	assert.Empty(t, h.calls)
+
+	hostile := h.rdr.FindUnit(h.nextID)
+	require.NotNil(t, hostile)
+	frame := hostile.LastKnown()
+	frame.Time = frame.Time.Add(15 * time.Second)
+	frame.Point = orb.Point{30.3, 40.1}
+	hostile.Update(frame)
+
+	h.ctrl.broadcastLeakers(h.ctx)
+	got := h.expectResponse(t)
+	call, ok := got.(brevity.LeakerCall)
+	require.True(t, ok)
+	assert.Equal(t, "Alpha", call.Line)
+	assert.Equal(t, brevity.Hostile, call.Group.Declaration())
+	require.NotNil(t, call.Group.Bullseye())
+
+	// Crossing back outbound does not trigger another call.
+	frame.Time = frame.Time.Add(15 * time.Second)
+	frame.Point = orb.Point{30.1, 40.1}
+	hostile.Update(frame)
+	h.ctrl.broadcastLeakers(h.ctx)
+	assert.Empty(t, h.calls)
+}
+
+func TestBroadcastLeakers_Outbound(t *testing.T) {
+	t.Parallel()
+	h := newControllerTestHarness(t, nil)
+	h.ctrl.leakerLines = []locations.Line{
+		{Name: "Alpha", Points: []locations.Coordinates{{Longitude: 30.2, Latitude: 39.5}, {Longitude: 30.2, Latitude: 40.5}}},
+	}
+	h.insertAircraft(t, "Flanker", acmiSu27, coalitions.Red, orb.Point{30.3, 40.1}, withHeading(270*unit.Degree))
+	h.ctrl.broadcastLeakers(h.ctx)
+
+	// The hostile egresses from the protected side back across the line.
+	hostile := h.rdr.FindUnit(h.nextID)
+	require.NotNil(t, hostile)
+	frame := hostile.LastKnown()
+	frame.Time = frame.Time.Add(15 * time.Second)
+	frame.Point = orb.Point{30.1, 40.1}
+	hostile.Update(frame)
+
+	h.ctrl.broadcastLeakers(h.ctx)
+	assert.Empty(t, h.calls)
+}
+
+func TestLeakerTracker(t *testing.T) {
+	t.Parallel()
+	tracker := newLeakerTracker()
+	trackfile := trackfiles.New(trackfiles.Labels{ID: 1})
+	trackfile.Update(trackfiles.Frame{Time: time.Now(), Point: orb.Point{30, 40}, Altitude: 20000 * unit.Foot})
+	empty := trackfiles.New(trackfiles.Labels{ID: 2})
+
+	tracker.record([]*trackfiles.Trackfile{trackfile, empty})
+	assert.Equal(t, map[uint64]orb.Point{1: {30, 40}}, tracker.previous())
+
+	tracker.remove(1)
+	assert.Empty(t, tracker.previous())
+}
@@ -125,21 +125,34 @@ func (c *Controller) broadcastMerges(ctx context.Context) {
 		logger := log.With().Stringer("group", hostileGroup).Logger()
 		newMergedFriendlies := c.updateMergesForGroup(hostileGroup, friendlies)
 		friendliesToNotify := make([]*trackfiles.Trackfile, 0)
+		// Friendlies which merged with their targeted group are notified separately, so that the call can reference
+		// the targeted group.
+		targetingFriendlies := make([]*trackfiles.Trackfile, 0)
 		for _, friendly := range newMergedFriendlies {
 			if c.mergeCooldowns.isOnCooldown(friendly.Contact.ID) {
 				logger.Info().Uint64("friendID", friendly.Contact.ID).Msg("removing friend from pending merged call because another merged call was recently broadcast for this friend")
 				continue
 			}
-			friendliesToNotify = append(friendliesToNotify, friendly)
+			if c.targets.isTargeting(friendly.Contact.ID, hostileGroup.ObjectIDs()...) {
+				targetingFriendlies = append(targetingFriendlies, friendly)
+			} else {
+				friendliesToNotify = append(friendliesToNotify, friendly)
+			}
 			c.mergeCooldowns.extendCooldown(friendly.Contact.ID)
 		}
 
+		targetedCall := c.createMergedCall(targetingFriendlies)
+		targetedCall.Target = hostileGroup
 		mergedCall := c.createMergedCall(friendliesToNotify)
-		if len(mergedCall.Callsigns) > 0 {
-			logger.Info().Strs("callsigns", mergedCall.Callsigns).Msg("broadcasting merged call")
-			c.calls <- NewCall(ctx, mergedCall)
-		} else {
+		if len(targetedCall.Callsigns)+len(mergedCall.Callsigns) == 0 {
 			logger.Debug().Msg("skipping merged call because no relevant clients are on frequency")
+			continue
+		}
+		for _, call := range []brevity.MergedCall{targetedCall, mergedCall} {
+			if len(call.Callsigns) > 0 {
+				logger.Info().Strs("callsigns", call.Callsigns).Msg("broadcasting merged call")
+				c.calls <- NewCall(ctx, call)
+			}
 		}
 	}
 }
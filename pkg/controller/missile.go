@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/callsigns"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/dharmab/skyeye/pkg/traces"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/rs/zerolog/log"
+)
+
+// missileCooldown is the minimum interval between MISSILE calls to the same friendly aircraft. Missiles are often
+// fired in salvos, so this prevents the same warning from being repeated for each missile.
+const missileCooldown = 15 * time.Second
+
+// handleLaunched broadcasts a MISSILE call to a friendly aircraft targeted by a hostile air-to-air missile.
+func (c *Controller) handleLaunched(missile trackfiles.Labels, launcher, target *trackfiles.Trackfile) {
+	logger := log.With().
+		Uint64("id", missile.ID).
+		Str("missile", missile.ACMIName).
+		Uint64("launcherID", launcher.Contact.ID).
+		Uint64("targetID", target.Contact.ID).
+		Str("target", target.Contact.Name).
+		Logger()
+
+	if !c.onStation.Load() {
+		logger.Debug().Msg("skipping MISSILE call because controller is off station")
+		return
+	}
+	if launcher.Contact.Coalition != c.coalition.Opposite() || target.Contact.Coalition != c.coalition {
+		logger.Debug().Msg("skipping MISSILE call because the missile was not fired by a hostile at a friendly")
+		return
+	}
+	if c.threatMonitoringRequiresSRS && !c.srsClient.IsOnFrequency(target.Contact.Name) {
+		logger.Debug().Msg("skipping MISSILE call because the target is not on frequency")
+		return
+	}
+	callsign, ok := callsigns.ParsePilotCallsign(target.Contact.Name)
+	if !ok {
+		logger.Debug().Msg("skipping MISSILE call because the target's callsign could not be parsed")
+		return
+	}
+	if c.missileCooldowns.isOnCooldown(target.Contact.ID) {
+		logger.Debug().Msg("suppressing MISSILE call because a call was recently broadcast to this friendly")
+		return
+	}
+
+	origin := target.LastKnown().Point
+	launchPoint := launcher.LastKnown().Point
+	call := brevity.MissileCall{
+		Callsign: callsign,
+		Bearing:  spatial.TrueBearing(origin, launchPoint, c.withProjection()).Magnetic(c.scope.Declination(origin)),
+		Range:    spatial.Distance(origin, launchPoint, c.withProjection()),
+	}
+	logger.Info().Stringer("bearing", call.Bearing).Float64("rangeNM", call.Range.NauticalMiles()).Msg("broadcasting MISSILE call")
+	c.missileCooldowns.extendCooldown(target.Contact.ID)
+	c.calls <- NewCall(traces.NewRequestContext(), call)
+}
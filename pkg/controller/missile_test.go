@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleLaunched_HostileMissileAtFriendly(t *testing.T) {
+	t.Parallel()
+	h := newControllerTestHarness(t, nil)
+	h.insertAircraft(t, "Eagle 1 Reaper", acmiF15C, coalitions.Blue, orb.Point{30.1, 40.1})
+	h.insertAircraft(t, "Flanker", acmiSu27, coalitions.Red, orb.Point{30.4, 40.1})
+
+	friendly := h.rdr.FindUnit(1)
+	hostile := h.rdr.FindUnit(2)
+	require.NotNil(t, friendly)
+	require.NotNil(t, hostile)
+	missile := trackfiles.Labels{ID: 100, Name: "R_77", Coalition: coalitions.Red, ACMIName: "R_77"}
+
+	h.ctrl.handleLaunched(missile, hostile, friendly)
+	got := h.expectResponse(t)
+	call, ok := got.(brevity.MissileCall)
+	require.True(t, ok)
+	assert.Equal(t, "eagle 1", call.Callsign)
+	assert.InDelta(t, 84.0, call.Bearing.Degrees(), bearingDeltaDegrees)
+	assert.InDelta(t, 14.0, call.Range.NauticalMiles(), 2)
+
+	// A second missile in the same salvo should not trigger another call.
+	h.ctrl.handleLaunched(missile, hostile, friendly)
+	assert.Empty(t, h.calls)
+}
+
+func TestHandleLaunched_FriendlyMissile(t *testing.T) {
+	t.Parallel()
+	h := newControllerTestHarness(t, nil)
+	h.insertAircraft(t, "Eagle 1 Reaper", acmiF15C, coalitions.Blue, orb.Point{30.1, 40.1})
+	h.insertAircraft(t, "Flanker", acmiSu27, coalitions.Red, orb.Point{30.4, 40.1})
+
+	friendly := h.rdr.FindUnit(1)
+	hostile := h.rdr.FindUnit(2)
+	missile := trackfiles.Labels{ID: 100, Name: "AIM_120C", Coalition: coalitions.Blue, ACMIName: "AIM_120C"}
+
+	h.ctrl.handleLaunched(missile, friendly, hostile)
+	assert.Empty(t, h.calls)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/dharmab/skyeye/internal/conf"
 	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/traces"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
@@ -14,11 +15,16 @@ import (
 func (c *Controller) HandlePicture(ctx context.Context, request *brevity.PictureRequest) {
 	logger := log.With().Str("callsign", request.Callsign).Type("type", request).Logger()
 	logger.Debug().Msg("handling request")
+	if c.isOffStation(ctx, request.Callsign) {
+		return
+	}
 
 	c.broadcastPicture(ctx, &logger, true)
 }
 
 func (c *Controller) broadcastPicture(ctx context.Context, logger *zerolog.Logger, forceBroadcast bool) {
+	// A PICTURE is broadcast on every frequency, even when requested on a single frequency.
+	ctx = traces.WithoutRadioFrequency(ctx)
 	if !forceBroadcast {
 		if c.srsClient.ClientsOnFrequency() == 0 {
 			logger.Debug().Msg("skipping PICTURE broadcast because no clients are on frequency")
@@ -26,7 +32,7 @@ func (c *Controller) broadcastPicture(ctx context.Context, logger *zerolog.Logge
 		}
 		c.scope.WaitUntilFadesResolve(ctx)
 	}
-	count, groups := c.scope.Picture(conf.DefaultPictureRadius, c.coalition.Opposite(), brevity.FixedWing)
+	count, groups, formation := c.scope.Picture(conf.DefaultPictureRadius, c.coalition.Opposite(), brevity.FixedWing)
 	isPictureClean := count == 0
 	for _, group := range groups {
 		group.SetDeclaration(brevity.Hostile)
@@ -36,8 +42,8 @@ func (c *Controller) broadcastPicture(ctx context.Context, logger *zerolog.Logge
 	if c.wasLastPictureClean && isPictureClean && !forceBroadcast {
 		logger.Info().Msg("skipping PICTURE broadcast because situation has not changed since last broadcast")
 	} else {
-		logger.Info().Int("groups", len(groups)).Int("count", count).Msg("broadcasting PICTURE")
-		c.calls <- NewCall(ctx, brevity.PictureResponse{Count: count, Groups: groups})
+		logger.Info().Int("groups", len(groups)).Int("count", count).Str("formation", string(formation)).Msg("broadcasting PICTURE")
+		c.calls <- NewCall(ctx, brevity.PictureResponse{Count: count, Formation: formation, Groups: groups})
 	}
 
 	func() {
@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/callsigns"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/rs/zerolog/log"
+)
+
+// popUpCooldown suppresses repeated POP-UP calls for the same contact. It is longer than the radar's pop-up window,
+// so each contact is announced at most once.
+const popUpCooldown = 5 * time.Minute
+
+// broadcastPopUps broadcasts POP-UP calls for hostile groups which have suddenly appeared near friendly aircraft.
+func (c *Controller) broadcastPopUps(ctx context.Context) {
+	if c.popUpRadius <= 0 {
+		return
+	}
+	popUps := c.scope.PopUps(c.coalition.Opposite(), c.popUpRadius)
+	for hostileGroup, friendlies := range popUps {
+		c.broadcastPopUp(ctx, hostileGroup, friendlies)
+	}
+}
+
+func (c *Controller) broadcastPopUp(ctx context.Context, hostileGroup brevity.Group, friendlies []*trackfiles.Trackfile) {
+	logger := log.With().Stringer("group", hostileGroup).Logger()
+
+	recentlyNotified := true
+	for _, id := range hostileGroup.ObjectIDs() {
+		if !c.popUpCooldowns.isOnCooldown(id) {
+			recentlyNotified = false
+			break
+		}
+	}
+	if recentlyNotified {
+		logger.Debug().Msg("suppressing pop-up call because a call was recently broadcast for all contacts within the group")
+		return
+	}
+
+	call := brevity.PopUpCall{
+		Callsigns: make([]string, 0),
+		Group:     hostileGroup,
+	}
+	for _, friendly := range friendlies {
+		if c.threatMonitoringRequiresSRS && !c.srsClient.IsOnFrequency(friendly.Contact.Name) {
+			continue
+		}
+		callsign, ok := callsigns.ParsePilotCallsign(friendly.Contact.Name)
+		if !ok {
+			logger.Debug().Str("contact_name", friendly.Contact.Name).Msg("could not parse callsign")
+			continue
+		}
+		if !slices.Contains(call.Callsigns, callsign) {
+			call.Callsigns = append(call.Callsigns, callsign)
+		}
+	}
+	if len(call.Callsigns) == 0 {
+		logger.Debug().Msg("skipping pop-up call because no relevant clients are on frequency")
+		return
+	}
+	call.Callsigns = collateCallsigns(call.Callsigns, c.getFriendlyCallsigns())
+
+	hostileGroup.SetDeclaration(brevity.Hostile)
+	c.fillInMergeDetails(hostileGroup)
+	logger.Info().Strs("callsigns", call.Callsigns).Msg("broadcasting pop-up call")
+	c.calls <- NewCall(ctx, call)
+
+	for _, id := range hostileGroup.ObjectIDs() {
+		c.popUpCooldowns.extendCooldown(id)
+	}
+}
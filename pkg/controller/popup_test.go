@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newPopUpTestHarness returns a harness with POP-UP calls enabled. A distant contact is inserted first with an old
+// timestamp, so that contacts inserted afterwards are past the radar's pop-up grace period.
+func newPopUpTestHarness(t *testing.T) *controllerTestHarness {
+	t.Helper()
+	h := newControllerTestHarness(t, nil)
+	h.ctrl.popUpRadius = 30 * unit.NauticalMile
+	h.insertAircraft(t, "Shell 1 1", acmiKC135, coalitions.Blue, orb.Point{20.0, 30.0}, withTime(time.Now().Add(-10*time.Minute)))
+	return h
+}
+
+func TestBroadcastPopUps_SingleFriendly(t *testing.T) {
+	t.Parallel()
+	h := newPopUpTestHarness(t)
+	h.insertAircraft(t, "Eagle 1 1", acmiF15C, coalitions.Blue, orb.Point{30.1, 40.1})
+	h.insertAircraft(t, "Flanker", acmiSu27, coalitions.Red, orb.Point{30.3, 40.1}, withHeading(270*unit.Degree))
+
+	h.ctrl.broadcastPopUps(h.ctx)
+	got := h.expectResponse(t)
+	call, ok := got.(brevity.PopUpCall)
+	require.True(t, ok, "expected PopUpCall, got %T", got)
+	assert.Equal(t, []string{"eagle 1 1"}, call.Callsigns)
+	assert.Equal(t, brevity.Hostile, call.Group.Declaration())
+	require.NotNil(t, call.Group.BRAA(), "single friendly should receive BRAA")
+	assert.Nil(t, call.Group.Bullseye())
+	assert.InDelta(t, 9, call.Group.BRAA().Range().NauticalMiles(), rangeDeltaNauticalMiles)
+
+	// The same group is not announced again.
+	h.ctrl.broadcastPopUps(h.ctx)
+	assert.Empty(t, h.calls)
+}
+
+func TestBroadcastPopUps_MultipleFriendlies(t *testing.T) {
+	t.Parallel()
+	h := newPopUpTestHarness(t)
+	h.insertAircraft(t, "Eagle 1 1", acmiF15C, coalitions.Blue, orb.Point{30.1, 40.1})
+	h.insertAircraft(t, "Viper 2 1", acmiF16C, coalitions.Blue, orb.Point{30.5, 40.1})
+	h.insertAircraft(t, "Flanker", acmiSu27, coalitions.Red, orb.Point{30.3, 40.1}, withHeading(270*unit.Degree))
+
+	h.ctrl.broadcastPopUps(h.ctx)
+	got := h.expectResponse(t)
+	call, ok := got.(brevity.PopUpCall)
+	require.True(t, ok, "expected PopUpCall, got %T", got)
+	assert.ElementsMatch(t, []string{"eagle 1 1", "viper 2 1"}, call.Callsigns)
+	require.NotNil(t, call.Group.Bullseye(), "multiple friendlies should receive bullseye")
+	assert.Nil(t, call.Group.BRAA())
+}
+
+func TestBroadcastPopUps_NoFriendlyInRange(t *testing.T) {
+	t.Parallel()
+	h := newPopUpTestHarness(t)
+	h.insertAircraft(t, "Eagle 1 1", acmiF15C, coalitions.Blue, orb.Point{30.1, 40.1})
+	h.insertAircraft(t, "Flanker", acmiSu27, coalitions.Red, orb.Point{32.0, 42.0}, withHeading(270*unit.Degree))
+
+	h.ctrl.broadcastPopUps(h.ctx)
+	assert.Empty(t, h.calls)
+}
@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"context"
+	"slices"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/simpleradio"
+	"github.com/dharmab/skyeye/pkg/traces"
+	"github.com/rs/zerolog/log"
+)
+
+// callFilter returns true if the given call should be transmitted on a frequency.
+type callFilter func(call any) bool
+
+// callFilters are the call filters for each frequency role. Replies to requests bypass the filters and are
+// transmitted only on the frequency the request was received on.
+var callFilters = map[simpleradio.Role]callFilter{
+	simpleradio.RoleTactical:  func(any) bool { return true },
+	simpleradio.RoleBroadcast: isBroadcastCall,
+	simpleradio.RoleGuard:     isEmergencyCall,
+}
+
+// isBroadcastCall returns true if the call is of interest to every friendly aircraft in the area.
+func isBroadcastCall(call any) bool {
+	switch call.(type) {
+	case brevity.PictureResponse, brevity.ThreatCall, brevity.LeakerCall, brevity.SunriseCall, brevity.OffStationCall:
+		return true
+	default:
+		return false
+	}
+}
+
+// isEmergencyCall returns true if the call warns of an imminent threat to a friendly aircraft.
+func isEmergencyCall(call any) bool {
+	switch call.(type) {
+	case brevity.MissileCall, brevity.PopUpCall:
+		return true
+	default:
+		return false
+	}
+}
+
+// SetFrequencies sets the SRS frequencies and their roles, which are used to route calls to frequencies. This must be
+// called before Run.
+func (c *Controller) SetFrequencies(frequencies []simpleradio.RadioFrequency) {
+	c.frequencies = frequencies
+}
+
+// role returns the role of the given frequency. Unknown frequencies are tactical frequencies.
+func (c *Controller) role(frequency simpleradio.RadioFrequency) simpleradio.Role {
+	i := slices.IndexFunc(c.frequencies, frequency.IsSameFrequency)
+	if i < 0 {
+		return simpleradio.RoleTactical
+	}
+	return c.frequencies[i].Role
+}
+
+// IsListening returns true if requests received on the given frequency should be handled.
+func (c *Controller) IsListening(frequency simpleradio.RadioFrequency) bool {
+	return c.role(frequency) == simpleradio.RoleTactical
+}
+
+// Route returns the frequencies on which to transmit the call in the given context. A reply to a request is
+// transmitted only on the frequency the request was received on. Any other call is transmitted on every frequency
+// whose role's call filter accepts the call. If no frequencies are returned, the call should not be transmitted.
+func (c *Controller) Route(ctx context.Context) []simpleradio.RadioFrequency {
+	if frequency, ok := traces.GetRadioFrequency(ctx).(simpleradio.RadioFrequency); ok {
+		return []simpleradio.RadioFrequency{frequency}
+	}
+	call := traces.GetCall(ctx)
+	frequencies := make([]simpleradio.RadioFrequency, 0, len(c.frequencies))
+	for _, frequency := range c.frequencies {
+		filter, ok := callFilters[frequency.Role]
+		if !ok {
+			log.Warn().Stringer("frequency", frequency).Stringer("role", frequency.Role).Msg("no call filter for frequency role")
+			continue
+		}
+		if filter(call) {
+			frequencies = append(frequencies, frequency)
+		}
+	}
+	return frequencies
+}
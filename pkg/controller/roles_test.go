@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/simpleradio"
+	"github.com/dharmab/skyeye/pkg/simpleradio/types"
+	"github.com/dharmab/skyeye/pkg/traces"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	tacticalFrequency  = simpleradio.RadioFrequency{Frequency: 251 * unit.Megahertz, Modulation: types.ModulationAM}
+	otherTactical      = simpleradio.RadioFrequency{Frequency: 133 * unit.Megahertz, Modulation: types.ModulationAM}
+	broadcastFrequency = simpleradio.RadioFrequency{Frequency: 264 * unit.Megahertz, Modulation: types.ModulationAM, Role: simpleradio.RoleBroadcast}
+	guardFrequency     = simpleradio.RadioFrequency{Frequency: 243 * unit.Megahertz, Modulation: types.ModulationAM, Role: simpleradio.RoleGuard}
+)
+
+func newRolesTestController() *Controller {
+	c := &Controller{}
+	c.SetFrequencies([]simpleradio.RadioFrequency{tacticalFrequency, otherTactical, broadcastFrequency, guardFrequency})
+	return c
+}
+
+func TestControllerIsListening(t *testing.T) {
+	t.Parallel()
+	c := newRolesTestController()
+	assert.True(t, c.IsListening(tacticalFrequency))
+	assert.True(t, c.IsListening(otherTactical))
+	assert.False(t, c.IsListening(broadcastFrequency))
+	assert.False(t, c.IsListening(guardFrequency))
+	// Received frequencies do not carry a role, so the configured role is used.
+	assert.False(t, c.IsListening(simpleradio.RadioFrequency{Frequency: 243 * unit.Megahertz, Modulation: types.ModulationAM}))
+}
+
+func TestControllerRoute(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		call     any
+		expected []simpleradio.RadioFrequency
+	}{
+		{
+			name:     "reply to request",
+			ctx:      traces.WithRadioFrequency(context.Background(), otherTactical),
+			call:     brevity.BogeyDopeResponse{Callsign: "eagle 1"},
+			expected: []simpleradio.RadioFrequency{otherTactical},
+		},
+		{
+			name:     "requested picture",
+			ctx:      traces.WithoutRadioFrequency(traces.WithRadioFrequency(context.Background(), otherTactical)),
+			call:     brevity.PictureResponse{},
+			expected: []simpleradio.RadioFrequency{tacticalFrequency, otherTactical, broadcastFrequency},
+		},
+		{
+			name:     "reply to chat request",
+			ctx:      context.Background(),
+			call:     brevity.BogeyDopeResponse{Callsign: "eagle 1"},
+			expected: []simpleradio.RadioFrequency{tacticalFrequency, otherTactical},
+		},
+		{
+			name:     "threat",
+			ctx:      context.Background(),
+			call:     brevity.ThreatCall{},
+			expected: []simpleradio.RadioFrequency{tacticalFrequency, otherTactical, broadcastFrequency},
+		},
+		{
+			name:     "missile",
+			ctx:      context.Background(),
+			call:     brevity.MissileCall{},
+			expected: []simpleradio.RadioFrequency{tacticalFrequency, otherTactical, guardFrequency},
+		},
+		{
+			name:     "pop-up",
+			ctx:      context.Background(),
+			call:     brevity.PopUpCall{},
+			expected: []simpleradio.RadioFrequency{tacticalFrequency, otherTactical, guardFrequency},
+		},
+		{
+			name:     "split",
+			ctx:      context.Background(),
+			call:     brevity.SplitCall{},
+			expected: []simpleradio.RadioFrequency{tacticalFrequency, otherTactical},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			c := newRolesTestController()
+			assert.Equal(t, test.expected, c.Route(traces.WithCall(test.ctx, test.call)))
+		})
+	}
+}
+
+func TestControllerRouteWithoutTacticalFrequencies(t *testing.T) {
+	t.Parallel()
+	c := &Controller{}
+	c.SetFrequencies([]simpleradio.RadioFrequency{guardFrequency})
+	assert.Empty(t, c.Route(traces.WithCall(context.Background(), brevity.SplitCall{})))
+	assert.Equal(t, []simpleradio.RadioFrequency{guardFrequency}, c.Route(traces.WithCall(context.Background(), brevity.MissileCall{})))
+}
@@ -4,16 +4,34 @@ import (
 	"context"
 
 	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/martinlindhe/unit"
 	"github.com/rs/zerolog/log"
 )
 
-// HandleShopping handles a SHOPPING request... by not implementing it, since it's not an air-to-air call!
+const (
+	// shoppingSearchRadius is the radius around the requestor within which hostile surface contacts are reported.
+	shoppingSearchRadius = 100 * unit.NauticalMile
+	// maxShoppingGroups is the maximum number of surface groups reported in a SHOPPING response.
+	maxShoppingGroups = 3
+)
+
+// HandleShopping handles a SHOPPING request by reporting the nearest hostile surface groups to the requestor.
 func (c *Controller) HandleShopping(ctx context.Context, request *brevity.ShoppingRequest) {
-	log.Debug().Str("callsign", request.Callsign).Type("type", request).Msg("handling request")
-	foundCallsign, _, ok := c.findCallsign(request.Callsign)
+	logger := log.With().Str("callsign", request.Callsign).Type("type", request).Logger()
+	logger.Debug().Msg("handling request")
+	if c.isOffStation(ctx, request.Callsign) {
+		return
+	}
+	foundCallsign, trackfile, ok := c.findCallsign(request.Callsign)
 	if !ok {
 		c.calls <- NewCall(ctx, brevity.NegativeRadarContactResponse{Callsign: request.Callsign})
 		return
 	}
-	c.calls <- NewCall(ctx, brevity.ShoppingResponse{Callsign: foundCallsign})
+
+	groups := c.scope.FindNearbySurfaceGroups(trackfile.LastKnown().Point, shoppingSearchRadius, c.coalition.Opposite())
+	if len(groups) > maxShoppingGroups {
+		groups = groups[:maxShoppingGroups]
+	}
+	logger.Info().Int("groups", len(groups)).Msg("responding to SHOPPING request")
+	c.calls <- NewCall(ctx, brevity.ShoppingResponse{Callsign: foundCallsign, Groups: groups})
 }
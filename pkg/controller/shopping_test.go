@@ -2,14 +2,39 @@ package controller
 
 import (
 	"testing"
+	"time"
 
 	"github.com/dharmab/skyeye/pkg/brevity"
 	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
 	"github.com/paulmach/orb"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// insertSurface pushes updates for a sea or ground object into the radar's channel and waits for the radar to report
+// it in a surface group.
+func (h *controllerTestHarness) insertSurface(t *testing.T, acmiName string, class trackfiles.Class, coalition coalitions.Coalition, point orb.Point) {
+	t.Helper()
+	h.nextID++
+	labels := trackfiles.Labels{
+		ID:        h.nextID,
+		Name:      acmiName,
+		Coalition: coalition,
+		ACMIName:  acmiName,
+		Class:     class,
+	}
+	frame := trackfiles.Frame{Time: time.Now(), Point: point}
+	h.updates <- sim.Updated{Labels: labels, Frame: frame}
+	frame.Time = frame.Time.Add(time.Second)
+	h.updates <- sim.Updated{Labels: labels, Frame: frame}
+	assert.Eventually(t, func() bool {
+		return len(h.rdr.FindNearbySurfaceGroups(point, 1*unit.NauticalMile, coalition)) > 0
+	}, time.Second, 5*time.Millisecond, "radar did not ingest surface trackfile for %s in time", acmiName)
+}
+
 func TestHandleShopping_CallsignOnRadar(t *testing.T) {
 	t.Parallel()
 	h := newControllerTestHarness(t, nil)
@@ -20,6 +45,23 @@ func TestHandleShopping_CallsignOnRadar(t *testing.T) {
 	resp, ok := got.(brevity.ShoppingResponse)
 	require.True(t, ok)
 	assert.Equal(t, "eagle 1", resp.Callsign)
+	assert.Empty(t, resp.Groups)
+}
+
+func TestHandleShopping_SurfaceTargets(t *testing.T) {
+	t.Parallel()
+	h := newControllerTestHarness(t, nil)
+	h.insertAircraft(t, "Hawg 1 Reaper", acmiA10C, coalitions.Blue, orb.Point{30.0, 40.0})
+	h.insertSurface(t, "SA-11 Buk LN 9A310M1", trackfiles.AirDefense, coalitions.Red, orb.Point{30.3, 40.0})
+	h.insertSurface(t, "MOSCOW", trackfiles.Watercraft, coalitions.Red, orb.Point{30.0, 40.5})
+	h.insertSurface(t, "CVN_71", trackfiles.Watercraft, coalitions.Blue, orb.Point{30.0, 40.1})
+
+	h.ctrl.HandleShopping(h.ctx, &brevity.ShoppingRequest{Callsign: "hawg 1"})
+	resp, ok := h.expectResponse(t).(brevity.ShoppingResponse)
+	require.True(t, ok)
+	require.Len(t, resp.Groups, 2)
+	assert.Equal(t, brevity.AirDefenses, resp.Groups[0].Category)
+	assert.Equal(t, brevity.Ships, resp.Groups[1].Category)
 }
 
 func TestHandleShopping_CallsignNotOnRadar(t *testing.T) {
@@ -20,6 +20,10 @@ func (c *Controller) HandleSnaplock(ctx context.Context, request *brevity.Snaplo
 		Float64("altitude", request.BRA.Altitude().Feet()).
 		Msg("received request")
 
+	if c.isOffStation(ctx, request.Callsign) {
+		return
+	}
+
 	if !request.BRA.Bearing().IsMagnetic() {
 		logger.Error().Stringer("bearing", request.BRA.Bearing()).Msg("bearing provided to HandleSnaplock should be magnetic")
 	}
@@ -30,7 +34,7 @@ func (c *Controller) HandleSnaplock(ctx context.Context, request *brevity.Snaplo
 		return
 	}
 
-	origin := trackfile.LastKnown().Point
+	origin := c.scope.Position(trackfile)
 	pointOfInterest := spatial.PointAtBearingAndDistance(
 		origin,
 		request.BRA.Bearing().True(c.scope.Declination(origin)),
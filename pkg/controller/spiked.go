@@ -10,6 +10,9 @@ import (
 // HandleSpiked handles a SPIKED request by reporting any enemy groups in the direction of the radar spike.
 func (c *Controller) HandleSpiked(ctx context.Context, request *brevity.SpikedRequest) {
 	logger := log.With().Str("callsign", request.Callsign).Type("type", request).Float64("bearing", request.Bearing.Degrees()).Logger()
+	if c.isOffStation(ctx, request.Callsign) {
+		return
+	}
 	correlation := c.correlate(logger, request.Callsign, request.Bearing)
 	if correlation.Callsign == "" {
 		c.calls <- NewCall(ctx, brevity.NegativeRadarContactResponse{Callsign: request.Callsign})
@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/traces"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/rs/zerolog/log"
+)
+
+// splashBroadcastRadius is the distance from a kill within which friendly aircraft receive the SPLASH call.
+const splashBroadcastRadius = 30 * unit.NauticalMile
+
+// handleDestroyed broadcasts a SPLASH call to friendly aircraft near a destroyed hostile group.
+func (c *Controller) handleDestroyed(location orb.Point, group brevity.Group, coalition coalitions.Coalition) {
+	for _, id := range group.ObjectIDs() {
+		c.remove(id)
+	}
+	c.updateStation(traces.NewRequestContext())
+
+	if !c.onStation.Load() {
+		log.Debug().Stringer("group", group).Msg("skipping SPLASH call because controller is off station")
+		return
+	}
+	if coalition != c.coalition.Opposite() {
+		log.Debug().Stringer("group", group).Msg("skipping SPLASH call because the destroyed group is not hostile")
+		return
+	}
+
+	nearbyFriendlies := c.scope.FindNearbyGroupsWithBullseye(
+		location,
+		lowestAltitude,
+		highestAltitude,
+		splashBroadcastRadius,
+		c.coalition,
+		brevity.Aircraft,
+		[]uint64{},
+	)
+
+	call := brevity.SplashCall{
+		Callsigns: make([]string, 0),
+		Group:     group,
+	}
+	for _, friendlyGroup := range nearbyFriendlies {
+		for _, id := range friendlyGroup.ObjectIDs() {
+			if friendly := c.scope.FindUnit(id); friendly != nil {
+				call.Callsigns = c.addFriendlyToBroadcast(call.Callsigns, friendly)
+			}
+		}
+	}
+	call.Callsigns = collateCallsigns(call.Callsigns, c.getFriendlyCallsigns())
+
+	if len(call.Callsigns) == 0 {
+		log.Debug().Stringer("group", group).Msg("skipping SPLASH call because no relevant friendlies are on frequency")
+		return
+	}
+
+	log.Info().Stringer("group", group).Strs("callsigns", call.Callsigns).Msg("broadcasting SPLASH call")
+	group.SetDeclaration(brevity.Hostile)
+	c.calls <- NewCall(traces.NewRequestContext(), call)
+}
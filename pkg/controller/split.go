@@ -0,0 +1,176 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/dharmab/collections/sets"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/callsigns"
+	"github.com/rs/zerolog/log"
+)
+
+// groupChangeCooldown suppresses repeated SPLIT and MERGED calls for the same contacts, such as when a group flies
+// at the edge of the grouping distance.
+const groupChangeCooldown = 2 * time.Minute
+
+// audienceTracker records which friendly aircraft have been warned about each hostile contact, so that they can be
+// told when the contact's group changes.
+type audienceTracker struct {
+	// audiences maps hostile unit IDs to the unit IDs of friendlies which were warned about the hostile.
+	audiences map[uint64]sets.Set[uint64]
+	lock      sync.RWMutex
+}
+
+func newAudienceTracker() *audienceTracker {
+	return &audienceTracker{
+		audiences: make(map[uint64]sets.Set[uint64]),
+	}
+}
+
+// add records that the given friendlies were warned about the given hostiles.
+func (t *audienceTracker) add(hostileIDs []uint64, friendIDs ...uint64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	for _, hostileID := range hostileIDs {
+		if _, ok := t.audiences[hostileID]; !ok {
+			t.audiences[hostileID] = sets.New[uint64]()
+		}
+		sets.Add(t.audiences[hostileID], friendIDs...)
+	}
+}
+
+// get returns the IDs of friendlies which were warned about any of the given hostiles.
+func (t *audienceTracker) get(hostileIDs ...uint64) []uint64 {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	friendIDs := sets.New[uint64]()
+	for _, hostileID := range hostileIDs {
+		if audience, ok := t.audiences[hostileID]; ok {
+			sets.Add(friendIDs, slices.Collect(sets.All(audience))...)
+		}
+	}
+	return slices.Sorted(sets.All(friendIDs))
+}
+
+// remove forgets the given ID, whether it is a hostile or a friendly.
+func (t *audienceTracker) remove(id uint64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	delete(t.audiences, id)
+	for _, audience := range t.audiences {
+		sets.Remove(audience, id)
+	}
+}
+
+func (t *audienceTracker) reset() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.audiences = make(map[uint64]sets.Set[uint64])
+}
+
+// broadcastGroupChanges broadcasts SPLIT and MERGED calls for hostile groups which have split or joined, to the
+// friendlies which were warned about the original groups.
+func (c *Controller) broadcastGroupChanges(ctx context.Context) {
+	splits, joins := c.scope.GroupChanges(c.coalition.Opposite())
+	for label, groups := range splits {
+		c.broadcastSplit(ctx, label, groups)
+	}
+	for group, labels := range joins {
+		c.broadcastGroupsMerged(ctx, group, labels)
+	}
+}
+
+func (c *Controller) broadcastSplit(ctx context.Context, label string, groups []brevity.Group) {
+	logger := log.With().Str("label", label).Logger()
+	hostileIDs := make([]uint64, 0)
+	for _, group := range groups {
+		hostileIDs = append(hostileIDs, group.ObjectIDs()...)
+	}
+	if c.isGroupChangeOnCooldown(hostileIDs) {
+		logger.Debug().Msg("suppressing split call because a call was recently broadcast for all contacts within the groups")
+		return
+	}
+
+	call := brevity.SplitCall{
+		Callsigns: c.collectAudienceCallsigns(hostileIDs),
+		Label:     label,
+		Groups:    groups,
+	}
+	if len(call.Callsigns) == 0 {
+		logger.Debug().Msg("skipping split call because no relevant clients are on frequency")
+		return
+	}
+	for _, group := range groups {
+		group.SetDeclaration(brevity.Hostile)
+		c.fillInMergeDetails(group)
+	}
+	logger.Info().Strs("callsigns", call.Callsigns).Int("groups", len(groups)).Msg("broadcasting split call")
+	c.calls <- NewCall(ctx, call)
+	c.extendGroupChangeCooldown(hostileIDs)
+}
+
+func (c *Controller) broadcastGroupsMerged(ctx context.Context, group brevity.Group, labels []string) {
+	logger := log.With().Stringer("group", group).Strs("labels", labels).Logger()
+	hostileIDs := group.ObjectIDs()
+	if c.isGroupChangeOnCooldown(hostileIDs) {
+		logger.Debug().Msg("suppressing merged call because a call was recently broadcast for all contacts within the group")
+		return
+	}
+
+	call := brevity.GroupsMergedCall{
+		Callsigns: c.collectAudienceCallsigns(hostileIDs),
+		Labels:    labels,
+		Group:     group,
+	}
+	if len(call.Callsigns) == 0 {
+		logger.Debug().Msg("skipping merged call because no relevant clients are on frequency")
+		return
+	}
+	group.SetDeclaration(brevity.Hostile)
+	c.fillInMergeDetails(group)
+	logger.Info().Strs("callsigns", call.Callsigns).Msg("broadcasting groups merged call")
+	c.calls <- NewCall(ctx, call)
+	c.extendGroupChangeCooldown(hostileIDs)
+}
+
+// collectAudienceCallsigns returns the callsigns of the friendlies on frequency which were warned about any of the
+// given hostiles.
+func (c *Controller) collectAudienceCallsigns(hostileIDs []uint64) []string {
+	result := make([]string, 0)
+	for _, friendID := range c.audiences.get(hostileIDs...) {
+		friendly := c.scope.FindUnit(friendID)
+		if friendly == nil {
+			continue
+		}
+		if c.threatMonitoringRequiresSRS && !c.srsClient.IsOnFrequency(friendly.Contact.Name) {
+			continue
+		}
+		callsign, ok := callsigns.ParsePilotCallsign(friendly.Contact.Name)
+		if !ok {
+			log.Debug().Str("contact_name", friendly.Contact.Name).Msg("could not parse callsign")
+			continue
+		}
+		if !slices.Contains(result, callsign) {
+			result = append(result, callsign)
+		}
+	}
+	return collateCallsigns(result, c.getFriendlyCallsigns())
+}
+
+func (c *Controller) isGroupChangeOnCooldown(hostileIDs []uint64) bool {
+	for _, id := range hostileIDs {
+		if !c.groupChangeCooldowns.isOnCooldown(id) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Controller) extendGroupChangeCooldown(hostileIDs []uint64) {
+	for _, id := range hostileIDs {
+		c.groupChangeCooldowns.extendCooldown(id)
+	}
+}
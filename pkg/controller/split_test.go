@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroadcastGroupChanges(t *testing.T) {
+	t.Parallel()
+	h := newControllerTestHarness(t, nil)
+	h.insertAircraft(t, "Eagle 1 Reaper", acmiF15C, coalitions.Blue, orb.Point{30.1, 40.1})
+	friendID := h.nextID
+	h.insertAircraft(t, "Flanker 1", acmiSu27, coalitions.Red, orb.Point{30.4, 40.1})
+	leadID := h.nextID
+	h.insertAircraft(t, "Flanker 2", acmiSu27, coalitions.Red, orb.Point{30.41, 40.1})
+	wingmanID := h.nextID
+
+	// Nobody has been warned about the hostiles yet, so there is nothing to broadcast.
+	h.ctrl.broadcastGroupChanges(h.ctx)
+	assert.Empty(t, h.calls)
+	h.ctrl.audiences.add([]uint64{leadID, wingmanID}, friendID)
+
+	wingman := h.rdr.FindUnit(wingmanID)
+	require.NotNil(t, wingman)
+	frame := wingman.LastKnown()
+	frame.Time = frame.Time.Add(5 * time.Second)
+	frame.Point = orb.Point{30.4, 40.4}
+	wingman.Update(frame)
+
+	h.ctrl.broadcastGroupChanges(h.ctx)
+	got := h.expectResponse(t)
+	split, ok := got.(brevity.SplitCall)
+	require.True(t, ok)
+	assert.Equal(t, []string{"eagle 1"}, split.Callsigns)
+	assert.Equal(t, "Alpha", split.Label)
+	require.Len(t, split.Groups, 2)
+	assert.Equal(t, []uint64{leadID}, split.Groups[0].ObjectIDs())
+	assert.Equal(t, []uint64{wingmanID}, split.Groups[1].ObjectIDs())
+	assert.Equal(t, brevity.Hostile, split.Groups[1].Declaration())
+
+	frame.Time = frame.Time.Add(5 * time.Second)
+	frame.Point = orb.Point{30.41, 40.1}
+	wingman.Update(frame)
+
+	// The rejoin is suppressed because a call was just broadcast for the same contacts.
+	h.ctrl.broadcastGroupChanges(h.ctx)
+	assert.Empty(t, h.calls)
+}
+
+func TestAudienceTracker(t *testing.T) {
+	t.Parallel()
+	tracker := newAudienceTracker()
+	tracker.add([]uint64{1, 2}, 10)
+	tracker.add([]uint64{2}, 11)
+	assert.Equal(t, []uint64{10}, tracker.get(1))
+	assert.Equal(t, []uint64{10, 11}, tracker.get(1, 2))
+
+	tracker.remove(10)
+	assert.Equal(t, []uint64{11}, tracker.get(1, 2))
+	tracker.remove(2)
+	assert.Empty(t, tracker.get(1, 2))
+}
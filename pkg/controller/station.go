@@ -0,0 +1,48 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/rs/zerolog/log"
+)
+
+// updateStation checks whether the bound AWACS aircraft is airborne. When the aircraft is lost, the controller
+// announces it is going off station. When a replacement aircraft is airborne, the controller announces sunrise.
+func (c *Controller) updateStation(ctx context.Context) {
+	isOnStation := c.scope.IsOnStation()
+	if !c.onStation.CompareAndSwap(!isOnStation, isOnStation) {
+		return
+	}
+	if isOnStation {
+		log.Info().Msg("AWACS aircraft is on station")
+		c.broadcastSunrise(ctx)
+	} else {
+		log.Info().Msg("AWACS aircraft is lost, going off station")
+		c.calls <- NewCall(ctx, brevity.OffStationCall{})
+	}
+}
+
+// updateSRSPosition moves the SRS client to the bound AWACS aircraft's position, so that SRS line of sight and radio
+// range are checked from the aircraft.
+func (c *Controller) updateSRSPosition() {
+	awacs := c.scope.FindAWACS()
+	if awacs == nil || c.srsClient == nil {
+		return
+	}
+	frame := awacs.LastKnown()
+	if err := c.srsClient.SetPosition(frame.Point, frame.Altitude); err != nil {
+		log.Error().Err(err).Msg("failed to update SRS position")
+	}
+}
+
+// isOffStation returns true if the controller is off station. If so, the caller is told the request cannot be
+// answered.
+func (c *Controller) isOffStation(ctx context.Context, callsign string) bool {
+	if c.onStation.Load() {
+		return false
+	}
+	log.Info().Str("callsign", callsign).Msg("unable to handle request because controller is off station")
+	c.calls <- NewCall(ctx, brevity.OffStationResponse{Callsign: callsign})
+	return true
+}
@@ -0,0 +1,50 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateStation_AWACSAirborne(t *testing.T) {
+	t.Parallel()
+	h := newControllerTestHarness(t, nil)
+	h.rdr.SetAWACS("Overlord 1-1")
+	h.insertAircraft(t, "Overlord 1-1", "E-3A", coalitions.Blue, orb.Point{30.0, 40.0})
+
+	h.ctrl.updateStation(h.ctx)
+	assert.True(t, h.ctrl.onStation.Load())
+	assert.Empty(t, h.calls)
+}
+
+func TestUpdateStation_AWACSLost(t *testing.T) {
+	t.Parallel()
+	h := newControllerTestHarness(t, nil)
+	h.insertAircraft(t, "Eagle 1", acmiF15C, coalitions.Blue, orb.Point{30.1, 40.1})
+	h.insertAircraft(t, "Bandit 1", acmiSu27, coalitions.Red, orb.Point{30.3, 40.1})
+	h.rdr.SetAWACS("Overlord 1-1")
+
+	h.ctrl.updateStation(h.ctx)
+	assert.False(t, h.ctrl.onStation.Load())
+	_, ok := h.expectResponse(t).(brevity.OffStationCall)
+	require.True(t, ok)
+
+	// The announcement is not repeated.
+	h.ctrl.updateStation(h.ctx)
+	assert.Empty(t, h.calls)
+
+	// Radar requests are declined.
+	h.ctrl.HandleBogeyDope(h.ctx, &brevity.BogeyDopeRequest{Callsign: "eagle 1", Filter: brevity.Aircraft})
+	resp, ok := h.expectResponse(t).(brevity.OffStationResponse)
+	require.True(t, ok)
+	assert.Equal(t, "eagle 1", resp.Callsign)
+
+	// Radio checks are still answered.
+	h.ctrl.HandleRadioCheck(h.ctx, &brevity.RadioCheckRequest{Callsign: "eagle 1"})
+	_, ok = h.expectResponse(t).(brevity.RadioCheckResponse)
+	assert.True(t, ok)
+}
@@ -0,0 +1,251 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/dharmab/collections/sets"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// targetedSearchRadius is the distance around the requested position within which a group may be targeted.
+	targetedSearchRadius = 7 * unit.NauticalMile
+	// targetedCooldown is the interval between TARGETED updates to the same friendly aircraft.
+	targetedCooldown = 1 * time.Minute
+)
+
+// target is a hostile group assigned to a friendly aircraft.
+type target struct {
+	// callsign of the friendly aircraft which was assigned the group.
+	callsign string
+	// hostileIDs contains the IDs of the contacts in the targeted group. It is updated as contacts join or leave the
+	// group.
+	hostileIDs sets.Set[uint64]
+}
+
+// targetTracker tracks the groups assigned to friendly aircraft, keyed by the friendly's unit ID.
+type targetTracker struct {
+	targets map[uint64]*target
+	lock    sync.RWMutex
+}
+
+func newTargetTracker() *targetTracker {
+	return &targetTracker{
+		targets: make(map[uint64]*target),
+	}
+}
+
+// set creates or replaces the target for the given friendly.
+func (t *targetTracker) set(friendID uint64, callsign string, hostileIDs ...uint64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.targets[friendID] = &target{
+		callsign:   callsign,
+		hostileIDs: sets.Of(hostileIDs...),
+	}
+}
+
+// friendIDs returns the IDs of all friendlies with a target assigned.
+func (t *targetTracker) friendIDs() []uint64 {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	ids := make([]uint64, 0, len(t.targets))
+	for id := range t.targets {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// get returns the callsign of the given friendly and the IDs of the contacts in its targeted group.
+func (t *targetTracker) get(friendID uint64) (string, []uint64, bool) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	tgt, ok := t.targets[friendID]
+	if !ok {
+		return "", nil, false
+	}
+	return tgt.callsign, slices.Sorted(sets.All(tgt.hostileIDs)), true
+}
+
+// isTargeting checks if the given friendly has been assigned a group containing any of the given hostiles.
+func (t *targetTracker) isTargeting(friendID uint64, hostileIDs ...uint64) bool {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	tgt, ok := t.targets[friendID]
+	if !ok {
+		return false
+	}
+	for _, id := range hostileIDs {
+		if sets.Contains(tgt.hostileIDs, id) {
+			return true
+		}
+	}
+	return false
+}
+
+// targetedBy returns the IDs of the friendlies which have been assigned a group containing any of the given hostiles.
+func (t *targetTracker) targetedBy(hostileIDs ...uint64) []uint64 {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	friendIDs := make([]uint64, 0)
+	for friendID, tgt := range t.targets {
+		for _, id := range hostileIDs {
+			if sets.Contains(tgt.hostileIDs, id) {
+				friendIDs = append(friendIDs, friendID)
+				break
+			}
+		}
+	}
+	slices.Sort(friendIDs)
+	return friendIDs
+}
+
+// clear removes the target assigned to the given friendly.
+func (t *targetTracker) clear(friendID uint64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	delete(t.targets, friendID)
+}
+
+// remove removes the target belonging to the given ID, and removes the ID from all other targets. Targets with no
+// remaining contacts are removed.
+func (t *targetTracker) remove(id uint64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	delete(t.targets, id)
+	for friendID, tgt := range t.targets {
+		sets.Remove(tgt.hostileIDs, id)
+		if sets.Len(tgt.hostileIDs) == 0 {
+			delete(t.targets, friendID)
+		}
+	}
+}
+
+func (t *targetTracker) reset() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.targets = make(map[uint64]*target)
+}
+
+// HandleTargeted handles a TARGETED request by assigning the hostile group nearest the given position to the
+// requesting aircraft.
+func (c *Controller) HandleTargeted(ctx context.Context, request *brevity.TargetedRequest) {
+	logger := log.With().Str("callsign", request.Callsign).Type("type", request).Logger()
+	logger.Debug().Msg("handling request")
+	if c.isOffStation(ctx, request.Callsign) {
+		return
+	}
+
+	foundCallsign, trackfile, ok := c.findCallsign(request.Callsign)
+	if !ok {
+		c.calls <- NewCall(ctx, brevity.NegativeRadarContactResponse{Callsign: request.Callsign})
+		return
+	}
+
+	if request.Bullseye == nil {
+		logger.Error().Msg("TARGETED request missing bullseye")
+		c.calls <- NewCall(ctx, brevity.TargetedResponse{Callsign: foundCallsign})
+		return
+	}
+	if !request.Bullseye.Bearing().IsMagnetic() {
+		logger.Warn().Stringer("bearing", request.Bullseye.Bearing()).Msg("bearing provided to HandleTargeted should be magnetic")
+	}
+
+	group := c.findHostileGroupAtBullseye(trackfile, request.Bullseye)
+	if group == nil {
+		logger.Info().Msg("no group found near requested position")
+		c.calls <- NewCall(ctx, brevity.TargetedResponse{Callsign: foundCallsign})
+		return
+	}
+
+	group.SetDeclaration(brevity.Hostile)
+	c.fillInMergeDetails(group)
+	logger.Info().Str("callsign", foundCallsign).Stringer("group", group).Msg("assigning target")
+	c.targets.set(trackfile.Contact.ID, foundCallsign, group.ObjectIDs()...)
+	c.audiences.add(group.ObjectIDs(), trackfile.Contact.ID)
+	c.targetCooldowns.extendCooldown(trackfile.Contact.ID)
+	c.calls <- NewCall(ctx, brevity.TargetedResponse{Callsign: foundCallsign, Group: group})
+}
+
+// findHostileGroupAtBullseye returns the hostile group nearest the given bullseye position, or nil if there is no
+// hostile group near the position. The group has BRAA set relative to the given friendly aircraft.
+func (c *Controller) findHostileGroupAtBullseye(friendly *trackfiles.Trackfile, bullseye *brevity.Bullseye) brevity.Group {
+	reference := c.scope.Bullseye(friendly.Contact.Coalition)
+	declination := c.scope.Declination(reference)
+	bearing := bullseye.Bearing().True(declination)
+	pointOfInterest := spatial.PointAtBearingAndDistance(reference, bearing, bullseye.Distance(), c.withProjection())
+	groups := c.scope.FindNearbyGroupsWithBRAA(
+		c.scope.Position(friendly),
+		pointOfInterest,
+		lowestAltitude,
+		highestAltitude,
+		targetedSearchRadius,
+		c.coalition.Opposite(),
+		brevity.Aircraft,
+		[]uint64{},
+	)
+	if len(groups) == 0 {
+		return nil
+	}
+	return groups[0]
+}
+
+// broadcastTargets broadcasts TARGETED updates to every friendly with an assigned target.
+func (c *Controller) broadcastTargets(ctx context.Context) {
+	for _, friendID := range c.targets.friendIDs() {
+		c.broadcastTarget(ctx, friendID)
+	}
+}
+
+func (c *Controller) broadcastTarget(ctx context.Context, friendID uint64) {
+	callsign, hostileIDs, ok := c.targets.get(friendID)
+	if !ok {
+		return
+	}
+	logger := log.With().Uint64("friendID", friendID).Str("callsign", callsign).Logger()
+
+	friendly := c.scope.FindUnit(friendID)
+	if friendly == nil {
+		logger.Info().Msg("removing target because the friendly is no longer on the scope")
+		c.targets.remove(friendID)
+		return
+	}
+	if friendly.IsLastKnownPointZero() {
+		return
+	}
+
+	group := c.scope.FindGroupWithBRAA(c.scope.Position(friendly), hostileIDs)
+	if group == nil {
+		logger.Info().Msg("removing target because the targeted group is no longer on the scope")
+		c.targets.clear(friendID)
+		return
+	}
+	// Follow the group as contacts join or leave it.
+	c.targets.set(friendID, callsign, group.ObjectIDs()...)
+	c.audiences.add(group.ObjectIDs(), friendID)
+
+	if c.targetCooldowns.isOnCooldown(friendID) {
+		return
+	}
+	if c.isGroupMergedWithFriendly(group, friendID) {
+		logger.Debug().Stringer("group", group).Msg("skipping targeted update because the friendly is merged with the group")
+		return
+	}
+	if c.threatMonitoringRequiresSRS && !c.srsClient.IsOnFrequency(friendly.Contact.Name) {
+		logger.Debug().Msg("skipping targeted update because the friendly is not on frequency")
+		return
+	}
+
+	group.SetDeclaration(brevity.Hostile)
+	c.fillInMergeDetails(group)
+	logger.Info().Stringer("group", group).Msg("broadcasting targeted update")
+	c.calls <- NewCall(ctx, brevity.TargetedCall{Callsign: callsign, Group: group})
+	c.targetCooldowns.extendCooldown(friendID)
+}
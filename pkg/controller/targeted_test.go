@@ -0,0 +1,134 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleTargeted_Hostile(t *testing.T) {
+	t.Parallel()
+	h := newControllerTestHarness(t, nil)
+	h.insertAircraft(t, "Viper 1 Reaper", acmiF16C, coalitions.Blue, orb.Point{30.0, 40.0})
+	// ~25nm east of bullseye
+	h.insertAircraft(t, "Bandit 1", acmiSu27, coalitions.Red, orb.Point{30.5, 40.0}, withHeading(270*unit.Degree))
+	// Group labels are assigned on the controller's tick.
+	h.ctrl.broadcastGroupChanges(h.ctx)
+
+	h.ctrl.HandleTargeted(h.ctx, &brevity.TargetedRequest{
+		Callsign: "viper 1",
+		Bullseye: brevity.NewBullseye(bearings.NewMagneticBearing(90*unit.Degree), 25*unit.NauticalMile),
+	})
+	got := h.expectResponse(t)
+	resp, ok := got.(brevity.TargetedResponse)
+	require.True(t, ok)
+	assert.Equal(t, "viper 1", resp.Callsign)
+	require.NotNil(t, resp.Group)
+	assert.Equal(t, brevity.Hostile, resp.Group.Declaration())
+	assert.NotEmpty(t, resp.Group.Label())
+	require.NotNil(t, resp.Group.BRAA())
+	assert.InDelta(t, 25, resp.Group.BRAA().Range().NauticalMiles(), 2)
+
+	assert.True(t, h.ctrl.targets.isTargeting(1, 2))
+	assert.Equal(t, []uint64{1}, h.ctrl.audiences.get(2), "the friendly is told if the targeted group splits or joins another group")
+}
+
+func TestHandleTargeted_Clean(t *testing.T) {
+	t.Parallel()
+	h := newControllerTestHarness(t, nil)
+	h.insertAircraft(t, "Viper 1 Reaper", acmiF16C, coalitions.Blue, orb.Point{30.0, 40.0})
+	h.insertAircraft(t, "Bandit 1", acmiSu27, coalitions.Red, orb.Point{30.5, 40.0})
+
+	h.ctrl.HandleTargeted(h.ctx, &brevity.TargetedRequest{
+		Callsign: "viper 1",
+		Bullseye: brevity.NewBullseye(bearings.NewMagneticBearing(270*unit.Degree), 25*unit.NauticalMile),
+	})
+	got := h.expectResponse(t)
+	resp, ok := got.(brevity.TargetedResponse)
+	require.True(t, ok)
+	assert.Nil(t, resp.Group)
+	assert.Empty(t, h.ctrl.targets.friendIDs())
+}
+
+func TestHandleTargeted_CallsignNotOnRadar(t *testing.T) {
+	t.Parallel()
+	h := newControllerTestHarness(t, nil)
+
+	h.ctrl.HandleTargeted(h.ctx, &brevity.TargetedRequest{
+		Callsign: "viper 1",
+		Bullseye: brevity.NewBullseye(bearings.NewMagneticBearing(90*unit.Degree), 25*unit.NauticalMile),
+	})
+	got := h.expectResponse(t)
+	resp, ok := got.(brevity.NegativeRadarContactResponse)
+	require.True(t, ok)
+	assert.Equal(t, "viper 1", resp.Callsign)
+}
+
+func TestBroadcastTargets(t *testing.T) {
+	t.Parallel()
+	h := newControllerTestHarness(t, nil)
+	h.insertAircraft(t, "Viper 1 Reaper", acmiF16C, coalitions.Blue, orb.Point{30.0, 40.0})
+	h.insertAircraft(t, "Bandit 1", acmiSu27, coalitions.Red, orb.Point{30.5, 40.0}, withHeading(270*unit.Degree))
+
+	h.ctrl.HandleTargeted(h.ctx, &brevity.TargetedRequest{
+		Callsign: "viper 1",
+		Bullseye: brevity.NewBullseye(bearings.NewMagneticBearing(90*unit.Degree), 25*unit.NauticalMile),
+	})
+	resp, ok := h.expectResponse(t).(brevity.TargetedResponse)
+	require.True(t, ok)
+	require.NotNil(t, resp.Group)
+
+	// The commit response counts as the first update.
+	h.ctrl.broadcastTargets(h.ctx)
+	assert.Empty(t, h.calls)
+
+	h.ctrl.targetCooldowns.remove(1)
+	h.ctrl.audiences.reset()
+	h.ctrl.broadcastTargets(h.ctx)
+	assert.Equal(t, []uint64{1}, h.ctrl.audiences.get(2))
+	got := h.expectResponse(t)
+	call, ok := got.(brevity.TargetedCall)
+	require.True(t, ok)
+	assert.Equal(t, "viper 1", call.Callsign)
+	assert.Equal(t, resp.Group.Label(), call.Group.Label())
+	require.NotNil(t, call.Group.BRAA())
+	assert.InDelta(t, 25, call.Group.BRAA().Range().NauticalMiles(), 2)
+
+	h.ctrl.broadcastTargets(h.ctx)
+	assert.Empty(t, h.calls)
+
+	// Once the targeted group is gone, the target is dropped.
+	h.ctrl.remove(2)
+	assert.Empty(t, h.ctrl.targets.friendIDs())
+}
+
+func TestTargetTracker(t *testing.T) {
+	t.Parallel()
+	tracker := newTargetTracker()
+	tracker.set(1, "viper 1", 10, 11)
+	tracker.set(2, "viper 2", 11, 12)
+
+	assert.True(t, tracker.isTargeting(1, 11))
+	assert.False(t, tracker.isTargeting(1, 12))
+	assert.Equal(t, []uint64{1, 2}, tracker.targetedBy(11))
+	assert.Equal(t, []uint64{2}, tracker.targetedBy(12))
+
+	tracker.remove(11)
+	assert.Equal(t, []uint64{1}, tracker.targetedBy(10))
+	_, ids, ok := tracker.get(2)
+	require.True(t, ok)
+	assert.Equal(t, []uint64{12}, ids)
+
+	tracker.remove(12)
+	_, _, ok = tracker.get(2)
+	assert.False(t, ok)
+
+	tracker.remove(1)
+	assert.Empty(t, tracker.friendIDs())
+}
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"context"
+	"maps"
 	"slices"
 	"sync"
 	"time"
@@ -63,8 +64,20 @@ func (c *Controller) broadcastThreats(ctx context.Context) {
 		return
 	}
 	threats := c.scope.Threats(c.coalition.Opposite())
-	for hostileGroup, friendIDs := range threats {
-		c.broadcastThreat(ctx, hostileGroup, friendIDs)
+	// Call targeted groups first, since they are the most relevant to the friendlies they threaten.
+	hostileGroups := slices.SortedFunc(maps.Keys(threats), func(a, b brevity.Group) int {
+		aTargeted := len(c.targets.targetedBy(a.ObjectIDs()...)) > 0
+		bTargeted := len(c.targets.targetedBy(b.ObjectIDs()...)) > 0
+		switch {
+		case aTargeted && !bTargeted:
+			return -1
+		case bTargeted && !aTargeted:
+			return 1
+		}
+		return 0
+	})
+	for _, hostileGroup := range hostileGroups {
+		c.broadcastThreat(ctx, hostileGroup, threats[hostileGroup])
 	}
 }
 
@@ -92,6 +105,9 @@ func (c *Controller) broadcastThreat(ctx context.Context, hostileGroup brevity.G
 		Group:     hostileGroup,
 	}
 
+	receivers := make([]uint64, 0, len(friendIDs))
+	// The call is only worded as a targeted threat if every friendly in the call has been assigned the group.
+	isTargeted := true
 	for _, friendID := range friendIDs {
 		if c.isGroupMergedWithFriendly(hostileGroup, friendID) {
 			logger.Debug().Msg("omitting friendly from threat call because the threat is already merged")
@@ -109,6 +125,10 @@ func (c *Controller) broadcastThreat(ctx context.Context, hostileGroup brevity.G
 		if !slices.Contains(threatCall.Callsigns, callsign) {
 			threatCall.Callsigns = append(threatCall.Callsigns, callsign)
 		}
+		if !c.targets.isTargeting(friendID, hostileGroup.ObjectIDs()...) {
+			isTargeted = false
+		}
+		receivers = append(receivers, friendID)
 	}
 
 	if len(threatCall.Callsigns) == 0 {
@@ -116,10 +136,12 @@ func (c *Controller) broadcastThreat(ctx context.Context, hostileGroup brevity.G
 		return
 	}
 
+	threatCall.Targeted = isTargeted
 	threatCall.Callsigns = collateCallsigns(threatCall.Callsigns, c.getFriendlyCallsigns())
 
 	logger.Info().Any("call", threatCall).Msg("broadcasting threat call for group")
 	c.calls <- NewCall(ctx, threatCall)
+	c.audiences.add(hostileGroup.ObjectIDs(), receivers...)
 
 	for _, threatID := range hostileGroup.ObjectIDs() {
 		c.threatCooldowns.extendCooldown(threatID)
@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroadcastThreat_Targeted(t *testing.T) {
+	t.Parallel()
+	h := newControllerTestHarness(t, nil)
+	h.insertAircraft(t, "Viper 1 Reaper", acmiF16C, coalitions.Blue, orb.Point{30.0, 40.0})
+	h.insertAircraft(t, "Eagle 1 1", acmiF15C, coalitions.Blue, orb.Point{30.0, 40.2})
+	// ~25nm east of bullseye
+	h.insertAircraft(t, "Bandit 1", acmiSu27, coalitions.Red, orb.Point{30.5, 40.0}, withHeading(270*unit.Degree))
+
+	h.ctrl.HandleTargeted(h.ctx, &brevity.TargetedRequest{
+		Callsign: "viper 1",
+		Bullseye: brevity.NewBullseye(bearings.NewMagneticBearing(90*unit.Degree), 25*unit.NauticalMile),
+	})
+	_, ok := h.expectResponse(t).(brevity.TargetedResponse)
+	require.True(t, ok)
+
+	testCases := []struct {
+		name      string
+		friendIDs []uint64
+		targeted  bool
+	}{
+		{name: "only the targeting friendly", friendIDs: []uint64{1}, targeted: true},
+		{name: "targeting and other friendlies", friendIDs: []uint64{1, 2}, targeted: false},
+		{name: "only other friendlies", friendIDs: []uint64{2}, targeted: false},
+	}
+	for _, test := range testCases {
+		h.ctrl.threatCooldowns.remove(3)
+		group := h.rdr.FindGroupWithBRAA(orb.Point{30.0, 40.0}, []uint64{3})
+		require.NotNil(t, group)
+		h.ctrl.broadcastThreat(h.ctx, group, test.friendIDs)
+		call, ok := h.expectResponse(t).(brevity.ThreatCall)
+		require.True(t, ok, test.name)
+		assert.Len(t, call.Callsigns, len(test.friendIDs), test.name)
+		assert.Equal(t, test.targeted, call.Targeted, test.name)
+	}
+}
@@ -2,18 +2,231 @@ package controller
 
 import (
 	"context"
+	"sync"
+	"time"
 
+	"github.com/dharmab/collections/sets"
 	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/martinlindhe/unit"
 	"github.com/rs/zerolog/log"
 )
 
-// HandleTripwire handles a TRIPWIRE... by not implementing it LOL!
+const (
+	// defaultTripwireRange is used when a TRIPWIRE request does not specify a range.
+	defaultTripwireRange = 40 * unit.NauticalMile
+	// minTripwireRange is the shortest range a tripwire may be set to. Anything shorter is effectively a MERGED call.
+	minTripwireRange = 5 * unit.NauticalMile
+	// maxTripwireRange is the longest range a tripwire may be set to.
+	maxTripwireRange = 150 * unit.NauticalMile
+	// tripwireCooldown is the minimum interval between TRIPWIRE calls to the same friendly aircraft.
+	tripwireCooldown = 1 * time.Minute
+)
+
+// tripwire is a range around a friendly aircraft. Hostile groups which close inside this range trigger a TRIPWIRE call.
+type tripwire struct {
+	// callsign of the friendly aircraft which set the tripwire.
+	callsign string
+	// rangeLimit is the distance from the friendly aircraft at which the tripwire is crossed.
+	rangeLimit unit.Length
+	// crossed contains the IDs of hostile contacts which have already been called inside the tripwire.
+	crossed sets.Set[uint64]
+}
+
+// tripwireTracker tracks the tripwires set by friendly aircraft, keyed by the friendly's unit ID.
+type tripwireTracker struct {
+	tripwires map[uint64]*tripwire
+	lock      sync.RWMutex
+}
+
+func newTripwireTracker() *tripwireTracker {
+	return &tripwireTracker{
+		tripwires: make(map[uint64]*tripwire),
+	}
+}
+
+// set creates or replaces the tripwire for the given friendly.
+func (t *tripwireTracker) set(friendID uint64, callsign string, rangeLimit unit.Length) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.tripwires[friendID] = &tripwire{
+		callsign:   callsign,
+		rangeLimit: rangeLimit,
+		crossed:    sets.New[uint64](),
+	}
+}
+
+// friendIDs returns the IDs of all friendlies with a tripwire set.
+func (t *tripwireTracker) friendIDs() []uint64 {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	ids := make([]uint64, 0, len(t.tripwires))
+	for id := range t.tripwires {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// get returns the callsign and range of the given friendly's tripwire.
+func (t *tripwireTracker) get(friendID uint64) (string, unit.Length, bool) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	tw, ok := t.tripwires[friendID]
+	if !ok {
+		return "", 0, false
+	}
+	return tw.callsign, tw.rangeLimit, true
+}
+
+// isCrossed checks if all of the given hostiles have already been called inside the given friendly's tripwire.
+func (t *tripwireTracker) isCrossed(friendID uint64, hostileIDs ...uint64) bool {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	tw, ok := t.tripwires[friendID]
+	if !ok {
+		return false
+	}
+	for _, id := range hostileIDs {
+		if !sets.Contains(tw.crossed, id) {
+			return false
+		}
+	}
+	return true
+}
+
+// cross records that the given hostiles have been called inside the given friendly's tripwire.
+func (t *tripwireTracker) cross(friendID uint64, hostileIDs ...uint64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	tw, ok := t.tripwires[friendID]
+	if !ok {
+		return
+	}
+	sets.Add(tw.crossed, hostileIDs...)
+}
+
+// keepCrossed forgets any hostiles which are no longer inside the given friendly's tripwire, so that they trigger a
+// new call if they cross it again.
+func (t *tripwireTracker) keepCrossed(friendID uint64, hostileIDs ...uint64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	tw, ok := t.tripwires[friendID]
+	if !ok {
+		return
+	}
+	inside := sets.Of(hostileIDs...)
+	for id := range sets.All(tw.crossed) {
+		if !sets.Contains(inside, id) {
+			sets.Remove(tw.crossed, id)
+		}
+	}
+}
+
+// remove removes the tripwire belonging to the given ID, and removes the ID from all other tripwires.
+func (t *tripwireTracker) remove(id uint64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	delete(t.tripwires, id)
+	for _, tw := range t.tripwires {
+		sets.Remove(tw.crossed, id)
+	}
+}
+
+func (t *tripwireTracker) reset() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.tripwires = make(map[uint64]*tripwire)
+}
+
+// HandleTripwire handles a TRIPWIRE by setting a tripwire around the requesting aircraft.
 func (c *Controller) HandleTripwire(ctx context.Context, request *brevity.TripwireRequest) {
-	log.Debug().Str("callsign", request.Callsign).Type("type", request).Msg("handling request")
-	foundCallsign, _, ok := c.findCallsign(request.Callsign)
+	logger := log.With().Str("callsign", request.Callsign).Type("type", request).Logger()
+	logger.Debug().Msg("handling request")
+	if c.isOffStation(ctx, request.Callsign) {
+		return
+	}
+	foundCallsign, trackfile, ok := c.findCallsign(request.Callsign)
 	if !ok {
 		c.calls <- NewCall(ctx, brevity.NegativeRadarContactResponse{Callsign: request.Callsign})
 		return
 	}
-	c.calls <- NewCall(ctx, brevity.TripwireResponse{Callsign: foundCallsign})
+
+	rangeLimit := request.Range
+	if rangeLimit == 0 {
+		rangeLimit = defaultTripwireRange
+	}
+	rangeLimit = max(minTripwireRange, min(rangeLimit, maxTripwireRange))
+
+	logger.Info().Str("callsign", foundCallsign).Float64("rangeNM", rangeLimit.NauticalMiles()).Msg("setting tripwire")
+	c.tripwires.set(trackfile.Contact.ID, foundCallsign, rangeLimit)
+	c.tripwireCooldowns.remove(trackfile.Contact.ID)
+	c.calls <- NewCall(ctx, brevity.TripwireResponse{Callsign: foundCallsign, Range: rangeLimit})
+}
+
+// broadcastTripwires checks every tripwire and broadcasts TRIPWIRE calls for any hostile groups which have crossed them.
+func (c *Controller) broadcastTripwires(ctx context.Context) {
+	for _, friendID := range c.tripwires.friendIDs() {
+		c.broadcastTripwire(ctx, friendID)
+	}
+}
+
+func (c *Controller) broadcastTripwire(ctx context.Context, friendID uint64) {
+	callsign, rangeLimit, ok := c.tripwires.get(friendID)
+	if !ok {
+		return
+	}
+	logger := log.With().Uint64("friendID", friendID).Str("callsign", callsign).Logger()
+
+	friendly := c.scope.FindUnit(friendID)
+	if friendly == nil {
+		logger.Info().Msg("removing tripwire because the friendly is no longer on the scope")
+		c.tripwires.remove(friendID)
+		return
+	}
+	if friendly.IsLastKnownPointZero() {
+		return
+	}
+
+	origin := c.scope.Position(friendly)
+	groups := c.scope.FindNearbyGroupsWithBRAA(
+		origin,
+		origin,
+		lowestAltitude,
+		highestAltitude,
+		rangeLimit,
+		c.coalition.Opposite(),
+		brevity.Aircraft,
+		[]uint64{},
+	)
+
+	inside := make([]brevity.Group, 0, len(groups))
+	insideIDs := make([]uint64, 0)
+	for _, group := range groups {
+		if group.BRAA() == nil || group.BRAA().Range() > rangeLimit {
+			continue
+		}
+		inside = append(inside, group)
+		insideIDs = append(insideIDs, group.ObjectIDs()...)
+	}
+	c.tripwires.keepCrossed(friendID, insideIDs...)
+
+	if c.threatMonitoringRequiresSRS && !c.srsClient.IsOnFrequency(friendly.Contact.Name) {
+		logger.Debug().Msg("skipping tripwire call because the friendly is not on frequency")
+		return
+	}
+
+	for _, group := range inside {
+		if c.tripwires.isCrossed(friendID, group.ObjectIDs()...) {
+			continue
+		}
+		if c.tripwireCooldowns.isOnCooldown(friendID) {
+			logger.Debug().Stringer("group", group).Msg("suppressing tripwire call because a call was recently broadcast to this friendly")
+			return
+		}
+		group.SetDeclaration(brevity.Hostile)
+		c.fillInMergeDetails(group)
+		logger.Info().Stringer("group", group).Msg("broadcasting tripwire call")
+		c.calls <- NewCall(ctx, brevity.TripwireCall{Callsign: callsign, Group: group})
+		c.tripwires.cross(friendID, group.ObjectIDs()...)
+		c.tripwireCooldowns.extendCooldown(friendID)
+	}
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/dharmab/skyeye/pkg/brevity"
 	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/martinlindhe/unit"
 	"github.com/paulmach/orb"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -20,6 +21,25 @@ func TestHandleTripwire_CallsignOnRadar(t *testing.T) {
 	resp, ok := got.(brevity.TripwireResponse)
 	require.True(t, ok)
 	assert.Equal(t, "eagle 1", resp.Callsign)
+	assert.InDelta(t, defaultTripwireRange.NauticalMiles(), resp.Range.NauticalMiles(), 0.1)
+}
+
+func TestHandleTripwire_WithRange(t *testing.T) {
+	t.Parallel()
+	h := newControllerTestHarness(t, nil)
+	h.insertAircraft(t, "Eagle 1 Reaper", acmiF15C, coalitions.Blue, orb.Point{30.1, 40.1})
+
+	h.ctrl.HandleTripwire(h.ctx, &brevity.TripwireRequest{Callsign: "eagle 1", Range: 25 * unit.NauticalMile})
+	got := h.expectResponse(t)
+	resp, ok := got.(brevity.TripwireResponse)
+	require.True(t, ok)
+	assert.InDelta(t, 25, resp.Range.NauticalMiles(), 0.1)
+
+	h.ctrl.HandleTripwire(h.ctx, &brevity.TripwireRequest{Callsign: "eagle 1", Range: 1000 * unit.NauticalMile})
+	got = h.expectResponse(t)
+	resp, ok = got.(brevity.TripwireResponse)
+	require.True(t, ok)
+	assert.InDelta(t, maxTripwireRange.NauticalMiles(), resp.Range.NauticalMiles(), 0.1)
 }
 
 func TestHandleTripwire_CallsignNotOnRadar(t *testing.T) {
@@ -32,3 +52,34 @@ func TestHandleTripwire_CallsignNotOnRadar(t *testing.T) {
 	require.True(t, ok)
 	assert.Equal(t, "eagle 1", resp.Callsign)
 }
+
+func TestBroadcastTripwires(t *testing.T) {
+	t.Parallel()
+	h := newControllerTestHarness(t, nil)
+	h.insertAircraft(t, "Eagle 1 Reaper", acmiF15C, coalitions.Blue, orb.Point{30.1, 40.1})
+	h.insertAircraft(t, "Flanker", acmiSu27, coalitions.Red, orb.Point{30.4, 40.1}, withHeading(270*unit.Degree))
+
+	h.ctrl.HandleTripwire(h.ctx, &brevity.TripwireRequest{Callsign: "eagle 1", Range: 10 * unit.NauticalMile})
+	_ = h.expectResponse(t)
+
+	// Hostile is about 14 miles away, outside the tripwire.
+	h.ctrl.broadcastTripwires(h.ctx)
+	assert.Empty(t, h.calls)
+
+	h.ctrl.HandleTripwire(h.ctx, &brevity.TripwireRequest{Callsign: "eagle 1", Range: 20 * unit.NauticalMile})
+	_ = h.expectResponse(t)
+
+	h.ctrl.broadcastTripwires(h.ctx)
+	got := h.expectResponse(t)
+	call, ok := got.(brevity.TripwireCall)
+	require.True(t, ok)
+	assert.Equal(t, "eagle 1", call.Callsign)
+	require.NotNil(t, call.Group.BRAA())
+	assert.InDelta(t, 84.0, call.Group.BRAA().Bearing().Degrees(), bearingDeltaDegrees)
+	assert.InDelta(t, 14, call.Group.BRAA().Range().NauticalMiles(), 2)
+	assert.Equal(t, brevity.Hostile, call.Group.Declaration())
+
+	// The same group should not trigger a second call while it remains inside the tripwire.
+	h.ctrl.broadcastTripwires(h.ctx)
+	assert.Empty(t, h.calls)
+}
@@ -4,13 +4,15 @@ import (
 	"context"
 
 	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/traces"
 	"github.com/rs/zerolog/log"
 )
 
 // HandleUnableToUnderstand handles requests where the wake word was recognized but the request could not be understood, by asking players on the channel to repeat their message.
+// If the request was stepped on by another transmission, the response says so.
 func (c *Controller) HandleUnableToUnderstand(ctx context.Context, request *brevity.UnableToUnderstandRequest) {
 	log.Debug().Str("callsign", request.Callsign).Type("type", request).Msg("handling request")
-	response := brevity.SayAgainResponse{Callsign: brevity.LastCaller}
+	response := brevity.SayAgainResponse{Callsign: brevity.LastCaller, SteppedOn: traces.IsSteppedOn(ctx)}
 	if callsign, trackfile := c.scope.FindCallsign(request.Callsign, c.coalition); trackfile != nil {
 		response.Callsign = callsign
 	}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/dharmab/skyeye/pkg/brevity"
 	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/traces"
 	"github.com/paulmach/orb"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -32,3 +33,16 @@ func TestHandleUnableToUnderstand_CallsignNotOnRadar(t *testing.T) {
 	require.True(t, ok)
 	assert.Equal(t, brevity.LastCaller, resp.Callsign)
 }
+
+func TestHandleUnableToUnderstand_SteppedOn(t *testing.T) {
+	t.Parallel()
+	h := newControllerTestHarness(t, nil)
+	h.insertAircraft(t, "Eagle 1 Reaper", acmiF15C, coalitions.Blue, orb.Point{30.1, 40.1})
+
+	h.ctrl.HandleUnableToUnderstand(traces.WithSteppedOn(h.ctx, true), &brevity.UnableToUnderstandRequest{Callsign: "eagle 1"})
+	got := h.expectResponse(t)
+	resp, ok := got.(brevity.SayAgainResponse)
+	require.True(t, ok)
+	assert.Equal(t, "eagle 1", resp.Callsign)
+	assert.True(t, resp.SteppedOn)
+}
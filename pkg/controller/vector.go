@@ -16,6 +16,9 @@ import (
 func (c *Controller) HandleVector(ctx context.Context, request *brevity.VectorRequest) {
 	logger := log.With().Str("callsign", request.Callsign).Type("type", request).Logger()
 	logger.Debug().Msg("handling request")
+	if c.isOffStation(ctx, request.Callsign) {
+		return
+	}
 
 	response := brevity.VectorResponse{
 		Callsign: request.Callsign,
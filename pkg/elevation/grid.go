@@ -0,0 +1,126 @@
+// Package elevation provides terrain elevation data.
+package elevation
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+)
+
+// Grid is a regular grid of terrain elevations in geographic coordinates.
+type Grid struct {
+	// corner is the southwest corner of the grid.
+	corner orb.Point
+	// cellSize is the width and height of each cell in decimal degrees.
+	cellSize float64
+	columns  int
+	rows     int
+	// noData is the value of cells which have no elevation data.
+	noData float64
+	// elevations contains the elevation of each cell in meters, in row-major order from north to south.
+	elevations []float64
+}
+
+// LoadASCIIGrid parses an elevation grid in ESRI ASCII grid format. Coordinates must be WGS 84 longitude and latitude
+// in decimal degrees, and elevations must be in meters above mean sea level.
+func LoadASCIIGrid(r io.Reader) (*Grid, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Split(bufio.ScanWords)
+	next := func() (string, bool) {
+		if !scanner.Scan() {
+			return "", false
+		}
+		return scanner.Text(), true
+	}
+
+	header := make(map[string]float64)
+	var first string
+	for {
+		key, ok := next()
+		if !ok {
+			return nil, errors.New("unexpected end of grid header")
+		}
+		key = strings.ToLower(key)
+		if _, err := strconv.ParseFloat(key, 64); err == nil {
+			// The header is followed directly by the first elevation value.
+			first = key
+			break
+		}
+		value, ok := next()
+		if !ok {
+			return nil, fmt.Errorf("missing value for grid header %q", key)
+		}
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse grid header %q: %w", key, err)
+		}
+		header[key] = f
+	}
+
+	g := &Grid{noData: -9999}
+	for _, key := range []string{"ncols", "nrows", "cellsize"} {
+		if _, ok := header[key]; !ok {
+			return nil, fmt.Errorf("missing grid header %q", key)
+		}
+	}
+	g.columns = int(header["ncols"])
+	g.rows = int(header["nrows"])
+	g.cellSize = header["cellsize"]
+	if g.columns <= 0 || g.rows <= 0 || g.cellSize <= 0 {
+		return nil, errors.New("grid dimensions and cell size must be positive")
+	}
+	if noData, ok := header["nodata_value"]; ok {
+		g.noData = noData
+	}
+	xCorner, xCornerOK := header["xllcorner"]
+	yCorner, yCornerOK := header["yllcorner"]
+	xCenter, xCenterOK := header["xllcenter"]
+	yCenter, yCenterOK := header["yllcenter"]
+	switch {
+	case xCornerOK && yCornerOK:
+		g.corner = orb.Point{xCorner, yCorner}
+	case xCenterOK && yCenterOK:
+		g.corner = orb.Point{xCenter - g.cellSize/2, yCenter - g.cellSize/2}
+	default:
+		return nil, errors.New("missing grid corner or center coordinates")
+	}
+
+	g.elevations = make([]float64, 0, g.columns*g.rows)
+	for value, ok := first, true; ok; value, ok = next() {
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse elevation: %w", err)
+		}
+		g.elevations = append(g.elevations, f)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read grid: %w", err)
+	}
+	if len(g.elevations) != g.columns*g.rows {
+		return nil, fmt.Errorf("expected %d elevations, found %d", g.columns*g.rows, len(g.elevations))
+	}
+	return g, nil
+}
+
+// Elevation returns the elevation of the grid cell containing the given point. The second return value is false if
+// the point is outside the grid or the cell has no data.
+func (g *Grid) Elevation(p orb.Point) (unit.Length, bool) {
+	column := int(math.Floor((p.Lon() - g.corner.Lon()) / g.cellSize))
+	rowFromSouth := int(math.Floor((p.Lat() - g.corner.Lat()) / g.cellSize))
+	if column < 0 || column >= g.columns || rowFromSouth < 0 || rowFromSouth >= g.rows {
+		return 0, false
+	}
+	row := g.rows - 1 - rowFromSouth
+	elevation := g.elevations[row*g.columns+column]
+	if elevation == g.noData {
+		return 0, false
+	}
+	return unit.Length(elevation) * unit.Meter, true
+}
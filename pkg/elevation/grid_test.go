@@ -0,0 +1,71 @@
+package elevation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testGrid = `ncols 3
+nrows 2
+xllcorner 40.0
+yllcorner 42.0
+cellsize 0.5
+NODATA_value -9999
+100 200 300
+400 500 -9999
+`
+
+func TestLoadASCIIGrid(t *testing.T) {
+	t.Parallel()
+	grid, err := LoadASCIIGrid(strings.NewReader(testGrid))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		point    orb.Point
+		expected float64
+		ok       bool
+	}{
+		{point: orb.Point{40.1, 42.9}, expected: 100, ok: true},
+		{point: orb.Point{41.4, 42.6}, expected: 300, ok: true},
+		{point: orb.Point{40.1, 42.1}, expected: 400, ok: true},
+		{point: orb.Point{40.7, 42.4}, expected: 500, ok: true},
+		{point: orb.Point{41.2, 42.2}, ok: false},
+		{point: orb.Point{39.9, 42.2}, ok: false},
+		{point: orb.Point{40.1, 43.1}, ok: false},
+	}
+	for _, test := range testCases {
+		elevation, ok := grid.Elevation(test.point)
+		assert.Equal(t, test.ok, ok, test.point)
+		if test.ok {
+			assert.InDelta(t, test.expected, elevation.Meters(), 0.001, test.point)
+		}
+	}
+}
+
+func TestLoadASCIIGridCellCenter(t *testing.T) {
+	t.Parallel()
+	data := "ncols 1\nnrows 1\nxllcenter 10\nyllcenter 20\ncellsize 1\n1234\n"
+	grid, err := LoadASCIIGrid(strings.NewReader(data))
+	require.NoError(t, err)
+	elevation, ok := grid.Elevation(orb.Point{9.6, 20.4})
+	require.True(t, ok)
+	assert.InDelta(t, (1234 * unit.Meter).Meters(), elevation.Meters(), 0.001)
+}
+
+func TestLoadASCIIGridInvalid(t *testing.T) {
+	t.Parallel()
+	for _, data := range []string{
+		"",
+		"ncols 2\nnrows 1\nxllcorner 0\nyllcorner 0\ncellsize 1\n1\n",
+		"ncols 1\nnrows 1\ncellsize 1\n1\n",
+		"ncols 1\nnrows 1\nxllcorner 0\nyllcorner 0\ncellsize 1\nabc\n",
+	} {
+		_, err := LoadASCIIGrid(strings.NewReader(data))
+		assert.Error(t, err, data)
+	}
+}
@@ -1,4 +1,4 @@
-// Package encyclopedia is a database of aircraft data.
+// Package encyclopedia is a database of aircraft and weapon data.
 package encyclopedia
 
 import (
@@ -30,6 +30,8 @@ const (
 	Fighter
 	// Attack indicates an attack aircraft with self-defense air-to-air missiles.
 	Attack
+	// AirborneEarlyWarning indicates an aircraft with a long-range surveillance radar.
+	AirborneEarlyWarning
 )
 
 // AirRefuelingMethod describes a type of aerial refueling.
@@ -714,7 +716,7 @@ var aircraftData = []Aircraft{
 	},
 	{
 		ACMIShortName:       "A-50",
-		tags:                sets.Of(FixedWing, Unarmed),
+		tags:                sets.Of(FixedWing, Unarmed, AirborneEarlyWarning),
 		PlatformDesignation: "A-50",
 		TypeDesignation:     "A-50",
 		NATOReportingName:   "Mainstay",
@@ -801,14 +803,14 @@ var aircraftData = []Aircraft{
 	},
 	{
 		ACMIShortName:       "E-2C",
-		tags:                sets.Of(FixedWing, Unarmed),
+		tags:                sets.Of(FixedWing, Unarmed, AirborneEarlyWarning),
 		PlatformDesignation: "E-2",
 		TypeDesignation:     "E-2C",
 		OfficialName:        "Hawkeye",
 	},
 	{
 		ACMIShortName:       "E-3A",
-		tags:                sets.Of(FixedWing, Unarmed),
+		tags:                sets.Of(FixedWing, Unarmed, AirborneEarlyWarning),
 		PlatformDesignation: "E-3",
 		TypeDesignation:     "E-3A",
 		OfficialName:        "Sentry",
@@ -887,7 +889,7 @@ var aircraftData = []Aircraft{
 	},
 	{
 		ACMIShortName:       "KJ-2000",
-		tags:                sets.Of(FixedWing, Unarmed),
+		tags:                sets.Of(FixedWing, Unarmed, AirborneEarlyWarning),
 		PlatformDesignation: "KJ-2000",
 		TypeDesignation:     "KJ-2000",
 		OfficialName:        "Mainring",
@@ -29,11 +29,12 @@ type serializedAircraft struct {
 
 // tagsByName maps the tag names accepted in custom aircraft files to their AircraftTag values.
 var tagsByName = map[string]AircraftTag{
-	"fixed-wing":  FixedWing,
-	"rotary-wing": RotaryWing,
-	"unarmed":     Unarmed,
-	"fighter":     Fighter,
-	"attack":      Attack,
+	"fixed-wing":             FixedWing,
+	"rotary-wing":            RotaryWing,
+	"unarmed":                Unarmed,
+	"fighter":                Fighter,
+	"attack":                 Attack,
+	"airborne-early-warning": AirborneEarlyWarning,
 }
 
 // refuelingByName maps the refueling method names accepted in custom aircraft files to their
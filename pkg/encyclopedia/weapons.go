@@ -0,0 +1,45 @@
+package encyclopedia
+
+import "strings"
+
+// Data source:
+// https://github.com/Quaggles/dcs-lua-datamine/tree/master/_G/db/Weapons
+
+// airToAirMissilePrefixes are prefixes of the ACMI names of air-to-air missiles, in upper case with underscores
+// replaced by hyphens. DCS names some Soviet missiles by their internal designations, e.g. P_27PE for the R-27ER.
+var airToAirMissilePrefixes = []string{
+	"AIM-",
+	"R-",
+	"P-24",
+	"P-27",
+	"P-33",
+	"P-37",
+	"P-40",
+	"P-60",
+	"P-73",
+	"P-77",
+	"PL-",
+	"SD-10",
+	"MICA",
+	"SUPER-530",
+	"MATRA-",
+	"MAGIC",
+	"METEOR",
+	"PYTHON",
+	"DERBY",
+	"RB-24",
+	"RB-74",
+	"RB-99",
+}
+
+// IsAirToAirMissile returns true if the given ACMI name belongs to an air-to-air missile.
+func IsAirToAirMissile(acmiName string) bool {
+	name := strings.ReplaceAll(strings.ToUpper(strings.TrimSpace(acmiName)), "_", "-")
+	name = strings.ReplaceAll(name, " ", "-")
+	for _, prefix := range airToAirMissilePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
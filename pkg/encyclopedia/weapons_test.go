@@ -0,0 +1,34 @@
+package encyclopedia
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsAirToAirMissile(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		acmiName string
+		expected bool
+	}{
+		{acmiName: "AIM_120C", expected: true},
+		{acmiName: "AIM-9X", expected: true},
+		{acmiName: "R_77", expected: true},
+		{acmiName: "P_27PE", expected: true},
+		{acmiName: "SD-10", expected: true},
+		{acmiName: "Super 530D", expected: true},
+		{acmiName: "AGM_88C", expected: false},
+		{acmiName: "AGM-65D", expected: false},
+		{acmiName: "Kh-31P", expected: false},
+		{acmiName: "P_700", expected: false},
+		{acmiName: "RB-05A", expected: false},
+		{acmiName: "", expected: false},
+	}
+	for _, test := range testCases {
+		t.Run(test.acmiName, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, IsAirToAirMissile(test.acmiName))
+		})
+	}
+}
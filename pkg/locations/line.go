@@ -0,0 +1,82 @@
+package locations
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/paulmach/orb"
+	"gopkg.in/yaml.v3"
+)
+
+// Coordinates are a geographic position in decimal degrees.
+type Coordinates struct {
+	Longitude float64 `json:"longitude" yaml:"longitude"`
+	Latitude  float64 `json:"latitude" yaml:"latitude"`
+}
+
+// Point returns the coordinates as an orb.Point.
+func (c Coordinates) Point() orb.Point {
+	return orb.Point{c.Longitude, c.Latitude}
+}
+
+// Validate checks that the coordinates are within valid bounds.
+func (c Coordinates) Validate() error {
+	if c.Latitude < -90 || c.Latitude > 90 {
+		return fmt.Errorf("latitude %v is outside the valid range [-90, 90]", c.Latitude)
+	}
+	if c.Longitude < -180 || c.Longitude > 180 {
+		return fmt.Errorf("longitude %v is outside the valid range [-180, 180]", c.Longitude)
+	}
+	return nil
+}
+
+// Line is a named polyline, such as a CAP line or a defensive line. Hostile aircraft crossing a line into its protected
+// side, which is on the right when facing from the first point to the last point, trigger LEAKER calls.
+type Line struct {
+	Name   string        `json:"name" yaml:"name"`
+	Points []Coordinates `json:"points" yaml:"points"`
+}
+
+// LineString returns the line as an orb.LineString.
+func (l Line) LineString() orb.LineString {
+	ls := make(orb.LineString, 0, len(l.Points))
+	for _, p := range l.Points {
+		ls = append(ls, p.Point())
+	}
+	return ls
+}
+
+// Validate checks that the line has a non-empty name and at least two points with coordinates within valid bounds.
+func (l Line) Validate() error {
+	if strings.TrimSpace(l.Name) == "" {
+		return errors.New("line name must not be empty or whitespace")
+	}
+	if len(l.Points) < 2 {
+		return fmt.Errorf("line %q must have at least two points", l.Name)
+	}
+	for _, p := range l.Points {
+		if err := p.Validate(); err != nil {
+			return fmt.Errorf("line %q has an invalid point: %w", l.Name, err)
+		}
+	}
+	return nil
+}
+
+// LoadLines parses line data from JSON or YAML. It tries JSON first, then falls back to YAML.
+func LoadLines(data []byte) ([]Line, error) {
+	var lines []Line
+	if err := json.Unmarshal(data, &lines); err != nil {
+		lines = nil
+		if yamlErr := yaml.Unmarshal(data, &lines); yamlErr != nil {
+			return nil, fmt.Errorf("failed to parse lines as JSON or YAML: json: %w, yaml: %w", err, yamlErr)
+		}
+	}
+	for _, line := range lines {
+		if err := line.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return lines, nil
+}
@@ -0,0 +1,89 @@
+package locations
+
+import (
+	"testing"
+
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLineValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		line    Line
+		wantErr bool
+	}{
+		{name: "valid", line: Line{Name: "cap", Points: []Coordinates{{0, 0}, {1, 1}}}, wantErr: false},
+		{name: "three points valid", line: Line{Name: "cap", Points: []Coordinates{{0, 0}, {1, 1}, {2, 0}}}, wantErr: false},
+		{name: "empty name rejected", line: Line{Name: "", Points: []Coordinates{{0, 0}, {1, 1}}}, wantErr: true},
+		{name: "whitespace name rejected", line: Line{Name: "  ", Points: []Coordinates{{0, 0}, {1, 1}}}, wantErr: true},
+		{name: "no points rejected", line: Line{Name: "cap"}, wantErr: true},
+		{name: "one point rejected", line: Line{Name: "cap", Points: []Coordinates{{0, 0}}}, wantErr: true},
+		{name: "latitude out of range", line: Line{Name: "cap", Points: []Coordinates{{0, 0}, {0, 90.1}}}, wantErr: true},
+		{name: "longitude out of range", line: Line{Name: "cap", Points: []Coordinates{{-180.1, 0}, {0, 0}}}, wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			err := test.line.Validate()
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestLoadLines(t *testing.T) {
+	t.Parallel()
+	want := []Line{{Name: "Alpha", Points: []Coordinates{{Longitude: 35.4, Latitude: 37.0}, {Longitude: 36.2, Latitude: 36.3}}}}
+	tests := []struct {
+		name    string
+		data    string
+		want    []Line
+		wantErr bool
+	}{
+		{
+			name: "json",
+			data: `[{"name":"Alpha","points":[{"latitude":37.0,"longitude":35.4},{"latitude":36.3,"longitude":36.2}]}]`,
+			want: want,
+		},
+		{
+			name: "yaml",
+			data: "- name: Alpha\n  points:\n  - latitude: 37.0\n    longitude: 35.4\n  - latitude: 36.3\n    longitude: 36.2\n",
+			want: want,
+		},
+		{
+			name:    "invalid",
+			data:    "not valid json or yaml [[[",
+			wantErr: true,
+		},
+		{
+			name:    "too few points",
+			data:    `[{"name":"Alpha","points":[{"latitude":37.0,"longitude":35.4}]}]`,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := LoadLines([]byte(test.data))
+			if test.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.want, got)
+			}
+		})
+	}
+}
+
+func TestLineLineString(t *testing.T) {
+	t.Parallel()
+	line := Line{Name: "test", Points: []Coordinates{{Longitude: -117.5, Latitude: 34.0}, {Longitude: -117.0, Latitude: 34.5}}}
+	expected := orb.LineString{{-117.5, 34.0}, {-117.0, 34.5}}
+	assert.Equal(t, expected, line.LineString())
+}
@@ -0,0 +1,52 @@
+package locations
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RadarSite is a fixed friendly surveillance radar, such as an early warning radar which is not present in the
+// telemetry. Radar sites are used by the radar sensor model.
+type RadarSite struct {
+	Name        string      `json:"name" yaml:"name"`
+	Coordinates Coordinates `json:"coordinates" yaml:"coordinates"`
+	// AltitudeFeet is the height of the radar antenna above mean sea level, in feet.
+	AltitudeFeet float64 `json:"altitude_ft" yaml:"altitude_ft"`
+	// RangeNM is the instrumented range of the radar, in nautical miles.
+	RangeNM float64 `json:"range_nm" yaml:"range_nm"`
+}
+
+// Validate checks that the radar site has a non-empty name, coordinates within valid bounds and a positive range.
+func (s RadarSite) Validate() error {
+	if strings.TrimSpace(s.Name) == "" {
+		return errors.New("radar site name must not be empty or whitespace")
+	}
+	if err := s.Coordinates.Validate(); err != nil {
+		return fmt.Errorf("radar site %q has invalid coordinates: %w", s.Name, err)
+	}
+	if s.RangeNM <= 0 {
+		return fmt.Errorf("radar site %q must have a positive range", s.Name)
+	}
+	return nil
+}
+
+// LoadRadarSites parses radar site data from JSON or YAML. It tries JSON first, then falls back to YAML.
+func LoadRadarSites(data []byte) ([]RadarSite, error) {
+	var sites []RadarSite
+	if err := json.Unmarshal(data, &sites); err != nil {
+		sites = nil
+		if yamlErr := yaml.Unmarshal(data, &sites); yamlErr != nil {
+			return nil, fmt.Errorf("failed to parse radar sites as JSON or YAML: json: %w, yaml: %w", err, yamlErr)
+		}
+	}
+	for _, site := range sites {
+		if err := site.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return sites, nil
+}
@@ -0,0 +1,62 @@
+package locations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadRadarSites(t *testing.T) {
+	t.Parallel()
+	want := []RadarSite{{Name: "Mount Hermon", Coordinates: Coordinates{Longitude: 35.77, Latitude: 33.41}, AltitudeFeet: 7300, RangeNM: 150}}
+	tests := []struct {
+		name    string
+		data    string
+		want    []RadarSite
+		wantErr bool
+	}{
+		{
+			name: "json",
+			data: `[{"name":"Mount Hermon","coordinates":{"latitude":33.41,"longitude":35.77},"altitude_ft":7300,"range_nm":150}]`,
+			want: want,
+		},
+		{
+			name: "yaml",
+			data: "- name: Mount Hermon\n  coordinates:\n    latitude: 33.41\n    longitude: 35.77\n  altitude_ft: 7300\n  range_nm: 150\n",
+			want: want,
+		},
+		{
+			name:    "invalid",
+			data:    "not valid json or yaml [[[",
+			wantErr: true,
+		},
+		{
+			name:    "empty name",
+			data:    `[{"name":" ","coordinates":{"latitude":33.41,"longitude":35.77},"range_nm":150}]`,
+			wantErr: true,
+		},
+		{
+			name:    "latitude out of range",
+			data:    `[{"name":"Mount Hermon","coordinates":{"latitude":93.41,"longitude":35.77},"range_nm":150}]`,
+			wantErr: true,
+		},
+		{
+			name:    "missing range",
+			data:    `[{"name":"Mount Hermon","coordinates":{"latitude":33.41,"longitude":35.77}}]`,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := LoadRadarSites([]byte(test.data))
+			if test.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package parser
+
+import (
+	"slices"
+
+	"github.com/dharmab/skyeye/internal/parser/token"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/rs/zerolog/log"
+)
+
+var terminateWords = []string{"terminate", "cancel", "abort"}
+
+// parseIntercept parses an INTERCEPT request. The group's position is optional; if no position is found, the request
+// is returned without a bullseye so that the controller can choose a group.
+func parseIntercept(callsign string, stream *token.Stream) (*brevity.InterceptRequest, bool) {
+	start := stream.SavePosition()
+	for !stream.AtEnd() {
+		text := stream.Text()
+		if slices.ContainsFunc(terminateWords, func(word string) bool { return isSimilar(text, word) }) {
+			return &brevity.InterceptRequest{Callsign: callsign, Terminate: true}, true
+		}
+		stream.Advance()
+	}
+	stream.RestorePosition(start)
+
+	bullseye, ok := findBullseye(stream)
+	if !ok {
+		log.Debug().Msg("failed to parse bullseye in INTERCEPT request")
+		return nil, false
+	}
+	return &brevity.InterceptRequest{Callsign: callsign, Bullseye: bullseye}, true
+}
@@ -0,0 +1,57 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParserIntercept(t *testing.T) {
+	t.Parallel()
+	testCases := []parserTestCase{
+		{
+			text:     "anyface, viper 1, request intercept",
+			expected: &brevity.InterceptRequest{Callsign: "viper 1"},
+		},
+		{
+			text: "anyface, viper 1, intercept group bullseye 090/40",
+			expected: &brevity.InterceptRequest{
+				Callsign: "viper 1",
+				Bullseye: brevity.NewBullseye(bearings.NewMagneticBearing(90*unit.Degree), 40*unit.NauticalMile),
+			},
+		},
+		{
+			text: "anyface eagle 1 1 intercept 270 25",
+			expected: &brevity.InterceptRequest{
+				Callsign: "eagle 1 1",
+				Bullseye: brevity.NewBullseye(bearings.NewMagneticBearing(270*unit.Degree), 25*unit.NauticalMile),
+			},
+		},
+		{
+			text:     "anyface, viper 1, intercept terminate",
+			expected: &brevity.InterceptRequest{Callsign: "viper 1", Terminate: true},
+		},
+		{
+			text:     "anyface hornet 2, intercept, abort",
+			expected: &brevity.InterceptRequest{Callsign: "hornet 2", Terminate: true},
+		},
+	}
+	runParserTestCases(t, New(TestCallsign, []string{}, true), testCases, func(t *testing.T, test parserTestCase, request any) {
+		t.Helper()
+		expected := test.expected.(*brevity.InterceptRequest)
+		actual := request.(*brevity.InterceptRequest)
+		assert.Equal(t, expected.Callsign, actual.Callsign)
+		assert.Equal(t, expected.Terminate, actual.Terminate)
+		if expected.Bullseye == nil {
+			assert.Nil(t, actual.Bullseye)
+			return
+		}
+		require.NotNil(t, actual.Bullseye)
+		assert.InDelta(t, expected.Bullseye.Bearing().Degrees(), actual.Bullseye.Bearing().Degrees(), 0.5)
+		assert.InDelta(t, expected.Bullseye.Distance().NauticalMiles(), actual.Bullseye.Distance().NauticalMiles(), 0.5)
+	})
+}
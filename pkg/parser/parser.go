@@ -53,17 +53,20 @@ const (
 	bogeyDope  string = "bogey"
 	checkIn    string = "check in"
 	declare    string = "declare"
+	intercept  string = "intercept"
 	picture    string = "picture"
 	radioCheck string = "radio"
 	shopping   string = "shopping"
 	snaplock   string = "snaplock"
 	spiked     string = "spiked"
 	strobe     string = "strobe"
+	surface    string = "surface"
+	targeted   string = "targeted"
 	tripwire   string = "tripwire"
 	vector     string = "vector"
 )
 
-var requestWords = []string{radioCheck, alphaCheck, bogeyDope, declare, picture, spiked, strobe, snaplock, tripwire, shopping, vector}
+var requestWords = []string{radioCheck, alphaCheck, bogeyDope, declare, picture, spiked, strobe, snaplock, targeted, intercept, tripwire, shopping, surface, vector}
 
 // findControllerCallsign searches for the GCI callsign in the given fields.
 // Returns the heard callsign, remaining text after it, and whether it was found.
@@ -117,6 +120,18 @@ func (p *Parser) parseRequestWithArgs(requestWord, pilotCallsign string, request
 		if request, ok := parseSnaplock(pilotCallsign, stream); ok {
 			return request
 		}
+	case intercept:
+		if request, ok := parseIntercept(pilotCallsign, stream); ok {
+			return request
+		}
+	case targeted:
+		if request, ok := parseTargeted(pilotCallsign, stream); ok {
+			return request
+		}
+	case tripwire:
+		if request, ok := parseTripwire(pilotCallsign, stream); ok {
+			return request
+		}
 	case vector:
 		if request, ok := parseVector(pilotCallsign, p.vectorLocations, stream); ok {
 			return request
@@ -247,9 +262,7 @@ func (p *Parser) Parse(tx string) any {
 		return &brevity.RadioCheckRequest{Callsign: pilotCallsign}
 	case picture:
 		return &brevity.PictureRequest{Callsign: pilotCallsign}
-	case tripwire:
-		return &brevity.TripwireRequest{Callsign: pilotCallsign}
-	case shopping:
+	case shopping, surface:
 		return &brevity.ShoppingRequest{Callsign: pilotCallsign}
 	}
 
@@ -0,0 +1,32 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParserShopping(t *testing.T) {
+	t.Parallel()
+	testCases := []parserTestCase{
+		{
+			text: "anyface, hawg 1-1 shopping",
+			expected: &brevity.ShoppingRequest{
+				Callsign: "hawg 1 1",
+			},
+		},
+		{
+			text: "anyface, hawg 1-1 request surface picture",
+			expected: &brevity.ShoppingRequest{
+				Callsign: "hawg 1 1",
+			},
+		},
+	}
+	runParserTestCases(t, New(TestCallsign, []string{}, true), testCases, func(t *testing.T, test parserTestCase, request any) {
+		t.Helper()
+		expected := test.expected.(*brevity.ShoppingRequest)
+		actual := request.(*brevity.ShoppingRequest)
+		assert.Equal(t, expected.Callsign, actual.Callsign)
+	})
+}
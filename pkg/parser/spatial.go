@@ -1,6 +1,10 @@
 package parser
 
 import (
+	"slices"
+	"strings"
+	"unicode"
+
 	"github.com/dharmab/numwords"
 	"github.com/dharmab/skyeye/internal/normalize"
 	"github.com/dharmab/skyeye/internal/parser/token"
@@ -35,6 +39,25 @@ func parseBullseye(stream *token.Stream) *brevity.Bullseye {
 	return brevity.NewBullseye(b, r)
 }
 
+// findBullseye skips ahead to the first bullseye keyword or numeric token and parses a bullseye from there. The
+// first return value is nil if the stream ends before a bullseye is found. The second return value is false if a
+// bullseye was started but could not be parsed.
+func findBullseye(stream *token.Stream) (*brevity.Bullseye, bool) {
+	for !stream.AtEnd() {
+		text := stream.Text()
+		isBullseye := slices.ContainsFunc(bullseyeWords, func(word string) bool {
+			return isSimilar(text, word)
+		})
+		isNumeric := text != "" && strings.IndexFunc(text, func(r rune) bool { return !unicode.IsDigit(r) }) == -1
+		if isBullseye || isNumeric {
+			bullseye := parseBullseye(stream)
+			return bullseye, bullseye != nil
+		}
+		stream.Advance()
+	}
+	return nil, true
+}
+
 var braaWords = []string{"bra", "brah", "braa"}
 
 func parseBRA(stream *token.Stream) (*brevity.BRA, bool) {
@@ -0,0 +1,17 @@
+package parser
+
+import (
+	"github.com/dharmab/skyeye/internal/parser/token"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/rs/zerolog/log"
+)
+
+// parseTargeted parses a TARGETED request. The group's position must be given in bullseye format.
+func parseTargeted(callsign string, stream *token.Stream) (*brevity.TargetedRequest, bool) {
+	bullseye, ok := findBullseye(stream)
+	if !ok || bullseye == nil {
+		log.Debug().Msg("no bullseye found in TARGETED request")
+		return nil, false
+	}
+	return &brevity.TargetedRequest{Callsign: callsign, Bullseye: bullseye}, true
+}
@@ -0,0 +1,63 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParserTargeted(t *testing.T) {
+	t.Parallel()
+	testCases := []parserTestCase{
+		{
+			text: "anyface, viper 1, targeted group bullseye 090/40",
+			expected: &brevity.TargetedRequest{
+				Callsign: "viper 1",
+				Bullseye: brevity.NewBullseye(bearings.NewMagneticBearing(90*unit.Degree), 40*unit.NauticalMile),
+			},
+		},
+		{
+			text: "anyface eagle 1 1 targeted bulls 270 for 25",
+			expected: &brevity.TargetedRequest{
+				Callsign: "eagle 1 1",
+				Bullseye: brevity.NewBullseye(bearings.NewMagneticBearing(270*unit.Degree), 25*unit.NauticalMile),
+			},
+		},
+		{
+			text: "anyface, hornet 2, targeted 180 30",
+			expected: &brevity.TargetedRequest{
+				Callsign: "hornet 2",
+				Bullseye: brevity.NewBullseye(bearings.NewMagneticBearing(180*unit.Degree), 30*unit.NauticalMile),
+			},
+		},
+	}
+	runParserTestCases(t, New(TestCallsign, []string{}, true), testCases, func(t *testing.T, test parserTestCase, request any) {
+		t.Helper()
+		expected := test.expected.(*brevity.TargetedRequest)
+		actual := request.(*brevity.TargetedRequest)
+		assert.Equal(t, expected.Callsign, actual.Callsign)
+		require.NotNil(t, actual.Bullseye)
+		assert.InDelta(t, expected.Bullseye.Bearing().Degrees(), actual.Bullseye.Bearing().Degrees(), 0.5)
+		assert.InDelta(t, expected.Bullseye.Distance().NauticalMiles(), actual.Bullseye.Distance().NauticalMiles(), 0.5)
+	})
+}
+
+func TestParserTargetedWithoutPosition(t *testing.T) {
+	t.Parallel()
+	testCases := []parserTestCase{
+		{
+			text:     "anyface, viper 1, targeted",
+			expected: &brevity.UnableToUnderstandRequest{Callsign: "viper 1"},
+		},
+	}
+	runParserTestCases(t, New(TestCallsign, []string{}, true), testCases, func(t *testing.T, test parserTestCase, request any) {
+		t.Helper()
+		expected := test.expected.(*brevity.UnableToUnderstandRequest)
+		actual := request.(*brevity.UnableToUnderstandRequest)
+		assert.Equal(t, expected.Callsign, actual.Callsign)
+	})
+}
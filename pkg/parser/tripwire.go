@@ -0,0 +1,23 @@
+package parser
+
+import (
+	"github.com/dharmab/numwords"
+	"github.com/dharmab/skyeye/internal/parser/token"
+	"github.com/dharmab/skyeye/pkg/brevity"
+)
+
+// parseTripwire parses a TRIPWIRE request. The range is optional; if no range is found, the request is returned with
+// a zero range so that the controller can apply a default.
+func parseTripwire(callsign string, stream *token.Stream) (*brevity.TripwireRequest, bool) {
+	request := &brevity.TripwireRequest{Callsign: callsign}
+	for !stream.AtEnd() {
+		if _, err := numwords.ParseInt(stream.Text()); err == nil {
+			if r, ok := parseRange(stream); ok {
+				request.Range = r
+			}
+			break
+		}
+		stream.Advance()
+	}
+	return request, true
+}
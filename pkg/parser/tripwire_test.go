@@ -0,0 +1,49 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParserTripwire(t *testing.T) {
+	t.Parallel()
+	testCases := []parserTestCase{
+		{
+			text: "anyface, eagle 1, tripwire",
+			expected: &brevity.TripwireRequest{
+				Callsign: "eagle 1",
+			},
+		},
+		{
+			text: "anyface, eagle 1, tripwire 40 miles",
+			expected: &brevity.TripwireRequest{
+				Callsign: "eagle 1",
+				Range:    40 * unit.NauticalMile,
+			},
+		},
+		{
+			text: "anyface viper 2 1 set threat range 25",
+			expected: &brevity.TripwireRequest{
+				Callsign: "viper 2 1",
+				Range:    25 * unit.NauticalMile,
+			},
+		},
+		{
+			text: "anyface hornet 1 3 trip wire at 60 miles please",
+			expected: &brevity.TripwireRequest{
+				Callsign: "hornet 1 3",
+				Range:    60 * unit.NauticalMile,
+			},
+		},
+	}
+	runParserTestCases(t, New(TestCallsign, []string{}, true), testCases, func(t *testing.T, test parserTestCase, request any) {
+		t.Helper()
+		expected := test.expected.(*brevity.TripwireRequest)
+		actual := request.(*brevity.TripwireRequest)
+		assert.Equal(t, expected.Callsign, actual.Callsign)
+		assert.InDelta(t, expected.Range.NauticalMiles(), actual.Range.NauticalMiles(), 0.5)
+	})
+}
@@ -0,0 +1,39 @@
+package radar
+
+import (
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+)
+
+// SetAWACS binds the radar to the friendly aircraft with the given unit or group name. While the bound aircraft is
+// airborne, it is a radar emitter in the sensor model. This must be called before Run.
+func (r *Radar) SetAWACS(name string) {
+	r.awacs = name
+}
+
+// isAWACS returns true if the given trackfile is the bound AWACS aircraft.
+func (r *Radar) isAWACS(trackfile *trackfiles.Trackfile) bool {
+	if r.awacs == "" || trackfile.Contact.Coalition != r.coalition {
+		return false
+	}
+	return trackfile.Contact.Name == r.awacs || trackfile.Contact.Group == r.awacs
+}
+
+// FindAWACS returns the trackfile of the bound AWACS aircraft, or nil if no AWACS is bound or the bound aircraft is
+// not airborne. If several aircraft match, the one with the lowest ID is returned.
+func (r *Radar) FindAWACS() *trackfiles.Trackfile {
+	var awacs *trackfiles.Trackfile
+	for trackfile := range r.contacts.values() {
+		if !r.isAWACS(trackfile) || !isValidTrack(trackfile) {
+			continue
+		}
+		if awacs == nil || trackfile.Contact.ID < awacs.Contact.ID {
+			awacs = trackfile
+		}
+	}
+	return awacs
+}
+
+// IsOnStation returns true if no AWACS is bound, or if the bound AWACS aircraft is airborne.
+func (r *Radar) IsOnStation() bool {
+	return r.awacs == "" || r.FindAWACS() != nil
+}
@@ -0,0 +1,101 @@
+package radar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func insertGroupMember(r *Radar, id uint64, name, group string, coalition coalitions.Coalition, point orb.Point, altitude unit.Length) {
+	tf := trackfiles.New(trackfiles.Labels{
+		ID:        id,
+		Name:      name,
+		Coalition: coalition,
+		ACMIName:  "C-130",
+		Group:     group,
+	})
+	agl := altitude
+	tf.Update(trackfiles.Frame{
+		Time:     time.Now(),
+		Point:    point,
+		Altitude: altitude,
+		AGL:      &agl,
+	})
+	r.contacts.set(tf)
+}
+
+func TestFindAWACS(t *testing.T) {
+	t.Parallel()
+	origin := orb.Point{30.0, 40.0}
+	testCases := []struct {
+		name       string
+		awacs      string
+		expectedID uint64
+		onStation  bool
+	}{
+		{name: "not bound", awacs: "", expectedID: 0, onStation: true},
+		{name: "unit name", awacs: "Overlord 1-1", expectedID: 1, onStation: true},
+		{name: "group name", awacs: "Overlord", expectedID: 1, onStation: true},
+		{name: "hostile aircraft", awacs: "Bandit", expectedID: 0, onStation: false},
+		{name: "on the ground", awacs: "Parked", expectedID: 0, onStation: false},
+		{name: "absent", awacs: "Darkstar", expectedID: 0, onStation: false},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			r := newTestRadarWithContacts()
+			insertGroupMember(r, 2, "Overlord 1-2", "Overlord", coalitions.Blue, origin, 30000*unit.Foot)
+			insertGroupMember(r, 1, "Overlord 1-1", "Overlord", coalitions.Blue, origin, 30000*unit.Foot)
+			insertGroupMember(r, 3, "Bandit 1-1", "Bandit", coalitions.Red, origin, 30000*unit.Foot)
+			insertGroupMember(r, 4, "Parked 1-1", "Parked", coalitions.Blue, origin, 0)
+			r.SetAWACS(test.awacs)
+
+			awacs := r.FindAWACS()
+			if test.expectedID == 0 {
+				assert.Nil(t, awacs)
+			} else {
+				require.NotNil(t, awacs)
+				assert.Equal(t, test.expectedID, awacs.Contact.ID)
+			}
+			assert.Equal(t, test.onStation, r.IsOnStation())
+		})
+	}
+}
+
+func TestSensorModelUsesAWACS(t *testing.T) {
+	t.Parallel()
+	r := newTestRadarWithContacts()
+	r.SetSensorModel(NewSensorModel(nil, nil))
+	origin := orb.Point{30.0, 40.0}
+	insertGroupMember(r, 1, "Overlord 1-1", "Overlord", coalitions.Blue, origin, 30000*unit.Foot)
+	insertAircraft(r, 2, "MiG-29S", coalitions.Red, pointAt(origin, 90, 100*unit.NauticalMile), 25000*unit.Foot)
+	tf, ok := r.contacts.getByID(2)
+	require.True(t, ok)
+
+	// A transport aircraft is not an early warning aircraft.
+	assert.False(t, r.isDetected(tf))
+
+	// The bound AWACS is an emitter regardless of its airframe.
+	r.SetAWACS("Overlord")
+	r.resetSensors()
+	assert.True(t, r.isDetected(tf))
+}
+
+func TestAWACSWithoutSensorModel(t *testing.T) {
+	t.Parallel()
+	r := newTestRadarWithContacts()
+	r.SetAWACS("Overlord")
+	origin := orb.Point{30.0, 40.0}
+	insertGroupMember(r, 1, "Overlord 1-1", "Overlord", coalitions.Blue, origin, 30000*unit.Foot)
+	insertAircraft(r, 2, "MiG-29S", coalitions.Red, pointAt(origin, 90, 300*unit.NauticalMile), 200*unit.Foot)
+
+	// Binding an AWACS does not enable the sensor model, so a hostile contact far beyond the AWACS's radar is visible.
+	assert.True(t, r.IsOnStation())
+	assert.Len(t, r.enumerateGroups(coalitions.Red), 1)
+}
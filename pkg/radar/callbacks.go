@@ -28,6 +28,28 @@ func (r *Radar) SetFadedCallback(callback FadedCallback) {
 	r.fadedCallback = callback
 }
 
+// DestroyedCallback is a callback function that is called when a group has been destroyed.
+// The group contains only the destroyed contacts. The group and its coalition are provided.
+type DestroyedCallback func(location orb.Point, group brevity.Group, coalition coalitions.Coalition)
+
+// SetDestroyedCallback sets the callback function to be called when a trackfile is destroyed.
+func (r *Radar) SetDestroyedCallback(callback DestroyedCallback) {
+	r.callbackLock.Lock()
+	defer r.callbackLock.Unlock()
+	r.destroyedCallback = callback
+}
+
+// LaunchedCallback is a callback function that is called when an aircraft launches a missile at another aircraft.
+// The missile's identity and copies of the launching and targeted aircraft's trackfiles are provided.
+type LaunchedCallback func(missile trackfiles.Labels, launcher, target *trackfiles.Trackfile)
+
+// SetLaunchedCallback sets the callback function to be called when a missile launch is correlated.
+func (r *Radar) SetLaunchedCallback(callback LaunchedCallback) {
+	r.callbackLock.Lock()
+	defer r.callbackLock.Unlock()
+	r.launchedCallback = callback
+}
+
 // RemovedCallback is a callback function that is called when a trackfile is aged out and removed.
 // A copy of the trackfile is provided.
 type RemovedCallback func(trackfile *trackfiles.Trackfile)
@@ -5,6 +5,7 @@ import (
 	"slices"
 	"time"
 
+	"github.com/dharmab/collections/sets"
 	"github.com/dharmab/skyeye/pkg/sim"
 	"github.com/dharmab/skyeye/pkg/trackfiles"
 	"github.com/rs/zerolog"
@@ -37,6 +38,11 @@ func (r *Radar) collectFadedTrackfiles(ctx context.Context) {
 			return
 		case fade := <-r.fades:
 			logger := log.With().Uint64("id", fade.ID).Logger()
+			// Sea and ground objects are removed immediately, without a FADED call.
+			if r.surface.delete(fade.ID) {
+				logger.Info().Msg("removed faded surface trackfile")
+				continue
+			}
 			if _, ok := r.contacts.getByID(fade.ID); !ok {
 				logger.Trace().Msg("ignoring fade notification because it was not correlated to a trackfile")
 				continue
@@ -55,6 +61,27 @@ func (r *Radar) collectFadedTrackfiles(ctx context.Context) {
 				defer r.pendingFadesLock.Unlock()
 				r.pendingFades = append(r.pendingFades, fade)
 			}()
+		case destroy := <-r.destroys:
+			logger := log.With().Uint64("id", destroy.ID).Logger()
+			if _, ok := r.contacts.getByID(destroy.ID); !ok {
+				logger.Trace().Msg("ignoring destroyed notification because it was not correlated to a trackfile")
+				continue
+			}
+			// Destroyed contacts are collected alongside faded contacts, so that multiple kills in quick
+			// succession are announced together.
+			extension := shortExtension
+			if extensions < maxLongExtensions {
+				extension = longExtension
+			}
+			deadline = time.Now().Add(extension)
+			extensions++
+			logger.Debug().Time("deadline", deadline).Int("extensions", extensions).Msg("received destroyed trackfile and extended faded call collection deadline")
+			func() {
+				r.pendingFadesLock.Lock()
+				defer r.pendingFadesLock.Unlock()
+				r.pendingFades = append(r.pendingFades, sim.Faded{ID: destroy.ID})
+				sets.Add(r.pendingDestroys, destroy.ID)
+			}()
 		case <-ticker.C:
 			// Regularly handle pending fades.
 			func() {
@@ -62,8 +89,9 @@ func (r *Radar) collectFadedTrackfiles(ctx context.Context) {
 				defer r.pendingFadesLock.Unlock()
 				if len(r.pendingFades) > 0 && (time.Now().After(deadline)) {
 					log.Info().Int("count", len(r.pendingFades)).Msg("handling pending faded trackfiles")
-					r.handleFaded(r.pendingFades)
+					r.handleFaded(r.pendingFades, r.pendingDestroys)
 					r.pendingFades = []sim.Faded{}
+					r.pendingDestroys = sets.New[uint64]()
 				}
 			}()
 			// Periodically clean up completed fades.
@@ -131,21 +159,61 @@ func (r *Radar) collectFadedGroups(fades []sim.Faded) []group {
 	return groups
 }
 
-// handleFaded collects faded contacts into groups, removes the contacts from the database, and calls the fadedCallback.
-func (r *Radar) handleFaded(fades []sim.Faded) {
-	groups := r.collectFadedGroups(fades)
+// collectDestroyedGroups collects destroyed contacts into groups. Unlike faded groups, each destroyed group contains
+// only the destroyed contacts, so that surviving wingmen are not announced as kills.
+func (r *Radar) collectDestroyedGroups(fades []sim.Faded, destroyedIDs sets.Set[uint64]) []group {
+	var groups []group
+	for _, grp := range r.collectFadedGroups(fades) {
+		contacts := make([]*trackfiles.Trackfile, 0, len(grp.contacts))
+		for _, trackfile := range grp.contacts {
+			if sets.Contains(destroyedIDs, trackfile.Contact.ID) && !slices.ContainsFunc(groups, func(g group) bool {
+				return isTrackfileInGroup(trackfile, &g)
+			}) {
+				contacts = append(contacts, trackfile)
+			}
+		}
+		if len(contacts) == 0 {
+			continue
+		}
+		grp.contacts = contacts
+		r.setBullseyeForGroup(&grp)
+		groups = append(groups, grp)
+	}
+	return groups
+}
+
+// handleFaded collects faded and destroyed contacts into groups, removes the contacts from the database, and calls the
+// fadedCallback or destroyedCallback for each group.
+func (r *Radar) handleFaded(fades []sim.Faded, destroyedIDs sets.Set[uint64]) {
+	faded := make([]sim.Faded, 0, len(fades))
+	destroyed := make([]sim.Faded, 0, sets.Len(destroyedIDs))
+	for _, fade := range fades {
+		if sets.Contains(destroyedIDs, fade.ID) {
+			destroyed = append(destroyed, fade)
+		} else {
+			faded = append(faded, fade)
+		}
+	}
+	destroyedGroups := r.collectDestroyedGroups(destroyed, destroyedIDs)
+	fadedGroups := r.collectFadedGroups(faded)
 
 	for _, fade := range fades {
 		r.contacts.delete(fade.ID)
+		r.popUps.remove(fade.ID)
+		r.labels.remove(fade.ID)
 	}
 
 	r.callbackLock.RLock()
 	defer r.callbackLock.RUnlock()
-	if r.fadedCallback == nil {
-		return
+	if r.destroyedCallback != nil {
+		for _, grp := range destroyedGroups {
+			r.destroyedCallback(grp.point(), &grp, grp.contacts[0].Contact.Coalition)
+		}
 	}
-	for _, grp := range groups {
-		r.fadedCallback(grp.point(), &grp, grp.contacts[0].Contact.Coalition)
+	if r.fadedCallback != nil {
+		for _, grp := range fadedGroups {
+			r.fadedCallback(grp.point(), &grp, grp.contacts[0].Contact.Coalition)
+		}
 	}
 }
 
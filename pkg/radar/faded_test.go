@@ -0,0 +1,63 @@
+package radar
+
+import (
+	"testing"
+
+	"github.com/dharmab/collections/sets"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleFaded_DestroyedContactOnly(t *testing.T) {
+	t.Parallel()
+	r := newTestRadarWithContacts()
+	insertTanker(t, r, 1, "Flanker 1", "Su-27", coalitions.Red, orb.Point{30.0, 40.0})
+	insertTanker(t, r, 2, "Flanker 2", "Su-27", coalitions.Red, orb.Point{30.01, 40.0})
+
+	var destroyed []brevity.Group
+	var faded []brevity.Group
+	r.SetDestroyedCallback(func(_ orb.Point, group brevity.Group, coalition coalitions.Coalition) {
+		assert.Equal(t, coalitions.Coalition(coalitions.Red), coalition)
+		destroyed = append(destroyed, group)
+	})
+	r.SetFadedCallback(func(_ orb.Point, group brevity.Group, _ coalitions.Coalition) {
+		faded = append(faded, group)
+	})
+
+	// A destroyed contact is usually followed by a removal, so the same ID may appear twice.
+	r.handleFaded([]sim.Faded{{ID: 1}, {ID: 1}}, sets.Of[uint64](1))
+
+	require.Len(t, destroyed, 1)
+	assert.Equal(t, []uint64{1}, destroyed[0].ObjectIDs())
+	assert.Empty(t, faded)
+
+	_, ok := r.contacts.getByID(1)
+	assert.False(t, ok)
+	_, ok = r.contacts.getByID(2)
+	assert.True(t, ok)
+}
+
+func TestHandleFaded_FadedContact(t *testing.T) {
+	t.Parallel()
+	r := newTestRadarWithContacts()
+	insertTanker(t, r, 1, "Flanker 1", "Su-27", coalitions.Red, orb.Point{30.0, 40.0})
+
+	var destroyed []brevity.Group
+	var faded []brevity.Group
+	r.SetDestroyedCallback(func(_ orb.Point, group brevity.Group, _ coalitions.Coalition) {
+		destroyed = append(destroyed, group)
+	})
+	r.SetFadedCallback(func(_ orb.Point, group brevity.Group, _ coalitions.Coalition) {
+		faded = append(faded, group)
+	})
+
+	r.handleFaded([]sim.Faded{{ID: 1}}, sets.New[uint64]())
+
+	assert.Empty(t, destroyed)
+	require.Len(t, faded, 1)
+	assert.Equal(t, []uint64{1}, faded[0].ObjectIDs())
+}
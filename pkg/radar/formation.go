@@ -0,0 +1,160 @@
+package radar
+
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+)
+
+const (
+	// maxFormationSpread is the greatest distance between groups which are described as a formation. Groups which are
+	// further apart are called independently.
+	maxFormationSpread = 40 * unit.NauticalMile
+	// formationLineRatio is the maximum ratio of depth to width (or width to depth) for three groups to be considered
+	// in a line, i.e. a WALL or LADDER.
+	formationLineRatio = 1.0 / 3.0
+)
+
+// formationPosition is a group's position relative to the axis from the friendly aircraft towards the groups.
+type formationPosition struct {
+	grp *group
+	// depth is the distance along the axis, in nautical miles. Larger values are further from the friendly aircraft.
+	depth float64
+	// width is the distance across the axis, in nautical miles. Positive values are to the right of the axis.
+	width float64
+}
+
+// classifyFormation classifies the geometric relationship of the given groups, as seen from the given origin. If the
+// groups form a standard presentation, the groups are named, and are returned in the order they should be called.
+// Otherwise, the groups are returned unchanged in their original order.
+func (r *Radar) classifyFormation(origin orb.Point, groups []*group) (brevity.Formation, []*group) {
+	if len(groups) < 2 || len(groups) > 3 {
+		return brevity.NoFormation, groups
+	}
+	for i, a := range groups {
+		for _, b := range groups[i+1:] {
+			if spatial.Distance(a.point(), b.point(), r.withProjection()) > maxFormationSpread {
+				return brevity.NoFormation, groups
+			}
+		}
+	}
+
+	points := make([]orb.Point, 0, len(groups))
+	for _, grp := range groups {
+		points = append(points, grp.point())
+	}
+	axis := spatial.TrueBearing(origin, centroid(points), r.withProjection())
+
+	positions := make([]formationPosition, 0, len(groups))
+	for _, grp := range groups {
+		distance := spatial.Distance(origin, grp.point(), r.withProjection()).NauticalMiles()
+		theta := (spatial.TrueBearing(origin, grp.point(), r.withProjection()).Degrees() - axis.Degrees()) * math.Pi / 180
+		positions = append(positions, formationPosition{
+			grp:   grp,
+			depth: distance * math.Cos(theta),
+			width: distance * math.Sin(theta),
+		})
+	}
+
+	// Name lateral positions by cardinal direction, e.g. north and south groups for groups approaching from the east.
+	firstName, lastName, isRightFirst := lateralNames(axis)
+	byWidth := func(a, b formationPosition) int {
+		if isRightFirst {
+			return -cmp.Compare(a.width, b.width)
+		}
+		return cmp.Compare(a.width, b.width)
+	}
+	byDepth := func(a, b formationPosition) int {
+		return cmp.Compare(a.depth, b.depth)
+	}
+
+	width, depth := spreads(positions)
+	var formation brevity.Formation
+	var names []string
+	if len(positions) == 2 {
+		if width >= depth {
+			formation = brevity.AzimuthFormation
+			slices.SortFunc(positions, byWidth)
+			names = []string{firstName, lastName}
+		} else {
+			formation = brevity.RangeFormation
+			slices.SortFunc(positions, byDepth)
+			names = []string{"lead", "trail"}
+		}
+	} else {
+		switch {
+		case depth <= width*formationLineRatio:
+			formation = brevity.WallFormation
+			slices.SortFunc(positions, byWidth)
+			names = []string{firstName, "middle", lastName}
+		case width <= depth*formationLineRatio:
+			formation = brevity.LadderFormation
+			slices.SortFunc(positions, byDepth)
+			names = []string{"lead", "middle", "trail"}
+		default:
+			slices.SortFunc(positions, byDepth)
+			frontGap := positions[1].depth - positions[0].depth
+			backGap := positions[2].depth - positions[1].depth
+			if frontGap < backGap {
+				formation = brevity.ChampagneFormation
+				slices.SortFunc(positions[:2], byWidth)
+				names = []string{firstName + " lead", lastName + " lead", "trail"}
+			} else {
+				formation = brevity.VicFormation
+				slices.SortFunc(positions[1:], byWidth)
+				names = []string{"lead", firstName + " trail", lastName + " trail"}
+			}
+		}
+	}
+
+	ordered := make([]*group, 0, len(positions))
+	for i, position := range positions {
+		position.grp.SetName(names[i])
+		ordered = append(ordered, position.grp)
+	}
+	return formation, ordered
+}
+
+// centroid returns the mean of the given points.
+func centroid(points []orb.Point) orb.Point {
+	var lon, lat float64
+	for _, p := range points {
+		lon += p.Lon()
+		lat += p.Lat()
+	}
+	n := float64(len(points))
+	return orb.Point{lon / n, lat / n}
+}
+
+// spreads returns the width and depth of the given positions, in nautical miles.
+func spreads(positions []formationPosition) (width, depth float64) {
+	minWidth, maxWidth := math.Inf(1), math.Inf(-1)
+	minDepth, maxDepth := math.Inf(1), math.Inf(-1)
+	for _, p := range positions {
+		minWidth, maxWidth = math.Min(minWidth, p.width), math.Max(maxWidth, p.width)
+		minDepth, maxDepth = math.Min(minDepth, p.depth), math.Max(maxDepth, p.depth)
+	}
+	return maxWidth - minWidth, maxDepth - minDepth
+}
+
+// lateralNames returns the cardinal directions across the given axis, in the order they are called: north before
+// south, east before west. isRightFirst is true if the first direction is to the right of the axis.
+func lateralNames(axis bearings.Bearing) (firstName, lastName string, isRightFirst bool) {
+	rightBearing := math.Mod(axis.Degrees()+90, 360)
+	switch {
+	case rightBearing >= 315 || rightBearing < 45:
+		return "north", "south", true
+	case rightBearing < 135:
+		return "east", "west", true
+	case rightBearing < 225:
+		return "north", "south", false
+	default:
+		return "east", "west", false
+	}
+}
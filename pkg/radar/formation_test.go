@@ -0,0 +1,123 @@
+package radar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func makeGroupAt(id uint64, point orb.Point) *group {
+	tf := trackfiles.New(trackfiles.Labels{
+		ID:        id,
+		ACMIName:  "Su-27",
+		Name:      "Flanker",
+		Coalition: coalitions.Red,
+	})
+	tf.Update(trackfiles.Frame{
+		Time:     time.Now(),
+		Point:    point,
+		Altitude: 20000 * unit.Foot,
+	})
+	return &group{contacts: []*trackfiles.Trackfile{tf}}
+}
+
+func TestClassifyFormation(t *testing.T) {
+	t.Parallel()
+	// Groups are east of the origin, so lateral positions are named north and south.
+	origin := orb.Point{30.0, 40.0}
+	testCases := []struct {
+		name          string
+		points        []orb.Point
+		expected      brevity.Formation
+		expectedNames []string
+		expectedOrder []uint64
+	}{
+		{
+			name:     "single group",
+			points:   []orb.Point{{31.0, 40.0}},
+			expected: brevity.NoFormation,
+		},
+		{
+			name:          "azimuth",
+			points:        []orb.Point{{31.0, 39.9}, {31.0, 40.1}},
+			expected:      brevity.AzimuthFormation,
+			expectedNames: []string{"north", "south"},
+			expectedOrder: []uint64{2, 1},
+		},
+		{
+			name:          "range",
+			points:        []orb.Point{{31.3, 40.0}, {31.0, 40.0}},
+			expected:      brevity.RangeFormation,
+			expectedNames: []string{"lead", "trail"},
+			expectedOrder: []uint64{2, 1},
+		},
+		{
+			name:          "wall",
+			points:        []orb.Point{{31.0, 40.0}, {31.0, 39.8}, {31.0, 40.2}},
+			expected:      brevity.WallFormation,
+			expectedNames: []string{"north", "middle", "south"},
+			expectedOrder: []uint64{3, 1, 2},
+		},
+		{
+			name:          "ladder",
+			points:        []orb.Point{{31.2, 40.0}, {30.8, 40.0}, {31.0, 40.0}},
+			expected:      brevity.LadderFormation,
+			expectedNames: []string{"lead", "middle", "trail"},
+			expectedOrder: []uint64{2, 3, 1},
+		},
+		{
+			name:          "champagne",
+			points:        []orb.Point{{31.0, 39.9}, {31.3, 40.0}, {31.0, 40.1}},
+			expected:      brevity.ChampagneFormation,
+			expectedNames: []string{"north lead", "south lead", "trail"},
+			expectedOrder: []uint64{3, 1, 2},
+		},
+		{
+			name:          "vic",
+			points:        []orb.Point{{31.3, 39.9}, {31.0, 40.0}, {31.3, 40.1}},
+			expected:      brevity.VicFormation,
+			expectedNames: []string{"lead", "north trail", "south trail"},
+			expectedOrder: []uint64{2, 3, 1},
+		},
+		{
+			name:     "too far apart",
+			points:   []orb.Point{{31.0, 40.0}, {32.0, 40.0}},
+			expected: brevity.NoFormation,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			r := newTestRadarWithContacts()
+			groups := make([]*group, 0, len(test.points))
+			for i, point := range test.points {
+				groups = append(groups, makeGroupAt(uint64(i+1), point))
+			}
+			formation, ordered := r.classifyFormation(origin, groups)
+			assert.Equal(t, test.expected, formation)
+			require.Len(t, ordered, len(groups))
+			if test.expected == brevity.NoFormation {
+				for i, grp := range ordered {
+					assert.Same(t, groups[i], grp)
+					assert.Empty(t, grp.Name())
+				}
+				return
+			}
+			names := make([]string, 0, len(ordered))
+			order := make([]uint64, 0, len(ordered))
+			for _, grp := range ordered {
+				names = append(names, grp.Name())
+				order = append(order, grp.ObjectIDs()[0])
+			}
+			assert.Equal(t, test.expectedNames, names)
+			assert.Equal(t, test.expectedOrder, order)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"math"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/dharmab/collections/sets"
 	"github.com/dharmab/skyeye/pkg/bearings"
@@ -25,6 +26,11 @@ type group struct {
 	aspect      *brevity.Aspect
 	declaration brevity.Declaration
 	mergedWith  int
+	name        string
+	label       string
+	// time is the time to which the group's position is predicted. Contacts updated more recently than this are
+	// predicted to the time of the most recent update instead.
+	time time.Time
 }
 
 var _ brevity.Group = &group{}
@@ -201,6 +207,21 @@ func (g *group) SetMergedWith(mergedWith int) {
 	g.mergedWith = mergedWith
 }
 
+// Name implements [brevity.Group.Name].
+func (g *group) Name() string {
+	return g.name
+}
+
+// SetName implements [brevity.Group.SetName].
+func (g *group) SetName(name string) {
+	g.name = name
+}
+
+// Label implements [brevity.Group.Label].
+func (g *group) Label() string {
+	return g.label
+}
+
 func (g *group) String() string {
 	location := ""
 	if g.braa != nil {
@@ -226,6 +247,9 @@ func (g *group) String() string {
 		g.Contacts(),
 		strings.Join(g.Platforms(), ","),
 	)
+	if g.label != "" {
+		s = g.label + " " + s
+	}
 	if g.isThreat {
 		s = "THREAT " + s
 	}
@@ -271,15 +295,39 @@ func (g *group) isFighter() bool {
 	return result
 }
 
-// point returns the center point of the group.
+// point returns the center point of the group. The positions of contacts are predicted forward to the group's time, or
+// to the time of the most recent update if that is later.
 func (g *group) point() orb.Point {
-	center := g.contacts[0].LastKnown().Point
+	newest := g.time
+	for _, trackfile := range g.contacts {
+		if t := trackfile.LastKnown().Time; t.After(newest) {
+			newest = t
+		}
+	}
+	center := positionAt(g.contacts[0], newest)
 	for _, trackfile := range g.contacts[1:] {
-		center = geo.Midpoint(center, trackfile.LastKnown().Point)
+		center = geo.Midpoint(center, positionAt(trackfile, newest))
 	}
 	return center
 }
 
+// maxPredictionInterval is the longest interval over which a contact's position is predicted. Beyond this, the
+// prediction is less reliable than the last known position.
+const maxPredictionInterval = 30 * time.Second
+
+// positionAt returns the trackfile's position at the given time. If the trackfile was last updated before the given
+// time, the position is predicted from the trackfile's filtered velocity.
+func positionAt(trackfile *trackfiles.Trackfile, at time.Time) orb.Point {
+	latest := trackfile.LastKnown()
+	if !latest.Time.Before(at) || at.Sub(latest.Time) > maxPredictionInterval {
+		return latest.Point
+	}
+	if state, ok := trackfile.Predict(at); ok {
+		return state.Point
+	}
+	return latest.Point
+}
+
 // threatRadius returns the highest threat radius of all contacts in the group.
 func (g *group) threatRadius() unit.Length {
 	highest := unit.Length(0)
@@ -123,3 +123,85 @@ func TestGroupTrack(t *testing.T) {
 		})
 	}
 }
+
+func TestGroupSpeed(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name             string
+		tas              unit.Speed
+		expectedFast     bool
+		expectedVeryFast bool
+	}{
+		{name: "slow", tas: 400 * unit.Knot},
+		{name: "fast", tas: 700 * unit.Knot, expectedFast: true},
+		{name: "very fast", tas: 1000 * unit.Knot, expectedVeryFast: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			// A new trackfile has only one frame, so its speed must come from the reported true airspeed.
+			tf := trackfiles.New(trackfiles.Labels{
+				ID:        1,
+				ACMIName:  "F-15C",
+				Name:      "Eagle",
+				Coalition: coalitions.Blue,
+			})
+			tf.Update(trackfiles.Frame{
+				Time:     time.Now(),
+				Point:    orb.Point{-115.0, 36.0},
+				Altitude: 20000 * unit.Foot,
+				TAS:      &tc.tas,
+			})
+			grp := group{contacts: []*trackfiles.Trackfile{tf}}
+			assert.Equal(t, tc.expectedFast, grp.Fast())
+			assert.Equal(t, tc.expectedVeryFast, grp.VeryFast())
+		})
+	}
+}
+
+func TestGroupPointPredictsStaleContacts(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	origin := orb.Point{-115.0, 36.0}
+	north := bearings.NewTrueBearing(0)
+	newFrame := func(age time.Duration, distance unit.Length) trackfiles.Frame {
+		return trackfiles.Frame{
+			Time:     now.Add(-age),
+			Point:    spatial.PointAtBearingAndDistance(origin, north, distance),
+			Altitude: 20000 * unit.Foot,
+		}
+	}
+
+	// Both contacts fly north at 200 m/s in trail, 2 km apart. The lead was last updated 10 seconds ago.
+	lead := trackfiles.New(trackfiles.Labels{ID: 1, ACMIName: "F-15C", Coalition: coalitions.Blue})
+	lead.Update(newFrame(11*time.Second, 2000*unit.Meter-2200*unit.Meter))
+	lead.Update(newFrame(10*time.Second, 2000*unit.Meter-2000*unit.Meter))
+	trail := trackfiles.New(trackfiles.Labels{ID: 2, ACMIName: "F-15C", Coalition: coalitions.Blue})
+	trail.Update(newFrame(time.Second, -200*unit.Meter))
+	trail.Update(newFrame(0, 0))
+
+	grp := group{contacts: []*trackfiles.Trackfile{lead, trail}}
+	expected := spatial.PointAtBearingAndDistance(origin, north, 1000*unit.Meter)
+	assert.InDelta(t, 0, spatial.Distance(expected, grp.point()).Meters(), 10)
+}
+
+func TestGroupPointPredictsToMissionTime(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	origin := orb.Point{-115.0, 36.0}
+	north := bearings.NewTrueBearing(0)
+
+	// The contact flies north at 200 m/s and was last updated 10 seconds ago.
+	tf := trackfiles.New(trackfiles.Labels{ID: 1, ACMIName: "F-15C", Coalition: coalitions.Blue})
+	tf.Update(trackfiles.Frame{Time: now.Add(-11 * time.Second), Point: spatial.PointAtBearingAndDistance(origin, north, -200*unit.Meter), Altitude: 20000 * unit.Foot})
+	tf.Update(trackfiles.Frame{Time: now.Add(-10 * time.Second), Point: origin, Altitude: 20000 * unit.Foot})
+	expected := spatial.PointAtBearingAndDistance(origin, north, 2000*unit.Meter)
+
+	grp := group{contacts: []*trackfiles.Trackfile{tf}, time: now}
+	assert.InDelta(t, 0, spatial.Distance(expected, grp.point()).Meters(), 10)
+
+	r := newTestRadarWithContacts()
+	r.SetMissionTime(now)
+	assert.InDelta(t, 0, spatial.Distance(expected, r.Position(tf)).Meters(), 10)
+}
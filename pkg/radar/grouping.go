@@ -1,6 +1,7 @@
 package radar
 
 import (
+	"cmp"
 	"slices"
 
 	"github.com/dharmab/collections/sets"
@@ -12,10 +13,15 @@ import (
 	"github.com/martinlindhe/unit"
 )
 
+// enumerateGroups returns every group of the given coalition. Groups are seeded from contacts in ID order, so the same
+// contacts produce the same groups on every call.
 func (r *Radar) enumerateGroups(coalition coalitions.Coalition) []*group {
 	visited := sets.New[uint64]()
 	groups := make([]*group, 0)
-	for trackfile := range r.contacts.values() {
+	contacts := slices.SortedFunc(r.contacts.values(), func(a, b *trackfiles.Trackfile) int {
+		return cmp.Compare(a.Contact.ID, b.Contact.ID)
+	})
+	for _, trackfile := range contacts {
 		if sets.Contains(visited, trackfile.Contact.ID) {
 			continue
 		}
@@ -25,7 +31,7 @@ func (r *Radar) enumerateGroups(coalition coalitions.Coalition) []*group {
 			continue
 		}
 
-		if !isValidTrack(trackfile) {
+		if !isValidTrack(trackfile) || !r.isDetected(trackfile) {
 			continue
 		}
 
@@ -50,11 +56,16 @@ func (r *Radar) findGroupForAircraft(trackfile *trackfiles.Trackfile) *group {
 	grp := &group{
 		contacts:    make([]*trackfiles.Trackfile, 0),
 		declaration: brevity.Unable,
+		time:        r.now(),
 	}
 	grp.contacts = append(grp.contacts, trackfile)
 	if !trackfile.IsLastKnownPointZero() {
 		r.addNearbyAircraftToGroup(trackfile, grp)
 	}
+	// Only label groups on the opposing coalition, since those are the groups described in calls
+	if trackfile.Contact.Coalition != r.coalition {
+		grp.label = r.labels.lookup(grp.ObjectIDs())
+	}
 	// Compute bullseye after all contacts are added so g.point() is accurate
 	r.setBullseyeForGroup(grp)
 	return grp
@@ -87,7 +98,7 @@ func (r *Radar) addNearbyAircraftToGroup(this *trackfiles.Trackfile, group *grou
 		}
 
 		// Check coalition, categoty, and filters
-		if !isMatch(other, this.Contact.Coalition, group.category()) {
+		if !r.isMatch(other, this.Contact.Coalition, group.category()) {
 			continue
 		}
 
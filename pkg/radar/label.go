@@ -0,0 +1,253 @@
+package radar
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+
+	"github.com/dharmab/collections/sets"
+)
+
+// groupLabelAlphabet is the sequence of labels assigned to groups.
+var groupLabelAlphabet = []string{
+	"Alpha", "Bravo", "Charlie", "Delta", "Echo", "Foxtrot", "Golf", "Hotel", "India", "Juliet", "Kilo", "Lima", "Mike",
+	"November", "Oscar", "Papa", "Quebec", "Romeo", "Sierra", "Tango", "Uniform", "Victor", "Whiskey", "X-ray", "Yankee",
+	"Zulu",
+}
+
+// groupLabeler assigns persistent labels to groups, so that the same group is called by the same name across
+// sweeps, even though groups are rebuilt from their contacts on every call.
+//
+// Labels are only assigned by update, which is given every group at once. A group keeps the label held by most of its
+// contacts. When contacts leave a group, the part holding most of the label's contacts keeps the label, and the other
+// part is given a new label. When groups join, the joined group keeps the label held by most of its contacts. Between
+// updates, lookup finds a group's label without changing any state, so that queries do not affect labels.
+type groupLabeler struct {
+	// labels maps unit IDs to the label of the group they were last seen in.
+	labels map[uint64]string
+	// members maps labels to the unit IDs of the group's contacts.
+	members map[string]sets.Set[uint64]
+	// next is the index in groupLabelAlphabet at which to start searching for an unused label.
+	next int
+	// lock protects labels, members and next.
+	lock sync.RWMutex
+}
+
+func newGroupLabeler() *groupLabeler {
+	l := &groupLabeler{}
+	l.reset()
+	return l
+}
+
+// lookup returns the label held by most of the given unit IDs, or an empty string if none of them are labeled. Ties
+// are broken alphabetically.
+func (l *groupLabeler) lookup(ids []uint64) string {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	candidates := l.candidates(ids)
+	if len(candidates) == 0 {
+		return ""
+	}
+	return candidates[0]
+}
+
+// candidates returns the labels held by any of the given unit IDs, ordered by the number of IDs holding each label,
+// then alphabetically.
+func (l *groupLabeler) candidates(ids []uint64) []string {
+	counts := l.counts(ids)
+	candidates := make([]string, 0, len(counts))
+	for label := range counts {
+		candidates = append(candidates, label)
+	}
+	slices.SortFunc(candidates, func(a, b string) int {
+		if c := cmp.Compare(counts[b], counts[a]); c != 0 {
+			return c
+		}
+		return cmp.Compare(a, b)
+	})
+	return candidates
+}
+
+// counts returns the number of the given unit IDs which hold each label.
+func (l *groupLabeler) counts(ids []uint64) map[string]int {
+	counts := make(map[string]int)
+	for _, id := range ids {
+		if label, ok := l.labels[id]; ok {
+			counts[label]++
+		}
+	}
+	return counts
+}
+
+// update assigns labels to the given groups, each given as the unit IDs of its contacts, and compares them to the
+// groups given in the previous update. The groups must not overlap. Contacts which are not in any of the groups lose
+// their labels.
+//
+// The first return value maps the labels of groups which split to the labels of the groups which split off from
+// them. The second return value maps the labels of groups which other groups joined to the labels of the groups which
+// joined them.
+func (l *groupLabeler) update(groups [][]uint64) (splits, joins map[string]sets.Set[string]) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	// Each previous label is owned by the group holding most of the label's contacts. On a tie, the group containing
+	// the lowest of the tied contacts owns the label.
+	owners := make(map[string]int)
+	ownerCounts := make(map[string]int)
+	ownerLowest := make(map[string]uint64)
+	groupCounts := make([]map[string]int, len(groups))
+	for i, ids := range groups {
+		groupCounts[i] = l.counts(ids)
+		for label, count := range groupCounts[i] {
+			lowest := uint64(math.MaxUint64)
+			for _, id := range ids {
+				if l.labels[id] == label {
+					lowest = min(lowest, id)
+				}
+			}
+			if _, ok := owners[label]; !ok || count > ownerCounts[label] || (count == ownerCounts[label] && lowest < ownerLowest[label]) {
+				owners[label] = i
+				ownerCounts[label] = count
+				ownerLowest[label] = lowest
+			}
+		}
+	}
+
+	// Each group keeps the owned label held by most of its contacts.
+	chosen := make([]string, len(groups))
+	for i, ids := range groups {
+		for _, label := range l.candidates(ids) {
+			if owners[label] == i {
+				chosen[i] = label
+				break
+			}
+		}
+	}
+
+	// Compare to the previous update. A group holding a label kept by another group split off from that group. A group
+	// owning a label which it did not keep joined this group.
+	splits = make(map[string]sets.Set[string])
+	joins = make(map[string]sets.Set[string])
+	kept := sets.New[string]()
+	for _, label := range chosen {
+		if label != "" {
+			sets.Add(kept, label)
+		}
+	}
+	for i := range groups {
+		for previous := range groupCounts[i] {
+			if previous == chosen[i] {
+				continue
+			}
+			if sets.Contains(kept, previous) {
+				if chosen[i] != "" {
+					addEvent(splits, previous, chosen[i])
+				}
+			} else if owners[previous] == i && chosen[i] != "" {
+				addEvent(joins, chosen[i], previous)
+			}
+		}
+	}
+
+	l.labels = make(map[uint64]string)
+	l.members = make(map[string]sets.Set[uint64])
+	for i, ids := range groups {
+		if chosen[i] != "" {
+			l.label(chosen[i], ids)
+		}
+	}
+	for i, ids := range groups {
+		if chosen[i] != "" {
+			continue
+		}
+		chosen[i] = l.allocate()
+		l.label(chosen[i], ids)
+		// A new group which contains contacts of a kept label split off from that group.
+		for previous := range groupCounts[i] {
+			if sets.Contains(kept, previous) {
+				addEvent(splits, previous, chosen[i])
+			}
+		}
+	}
+	return splits, joins
+}
+
+// label records that the given unit IDs hold the given label.
+func (l *groupLabeler) label(label string, ids []uint64) {
+	l.members[label] = sets.Of(ids...)
+	for _, id := range ids {
+		l.labels[id] = label
+	}
+}
+
+func addEvent(events map[string]sets.Set[string], key, value string) {
+	if _, ok := events[key]; !ok {
+		events[key] = sets.New[string]()
+	}
+	sets.Add(events[key], value)
+}
+
+// allocate returns an unused label. Labels are allocated in alphabetical order, wrapping around, so that a label is
+// not reused soon after it is released. If every label is in use, a number is appended.
+func (l *groupLabeler) allocate() string {
+	for round := 1; ; round++ {
+		for i := range groupLabelAlphabet {
+			index := (l.next + i) % len(groupLabelAlphabet)
+			label := groupLabelAlphabet[index]
+			if round > 1 {
+				label = fmt.Sprintf("%s %d", label, round)
+			}
+			if _, ok := l.members[label]; !ok {
+				l.next = index + 1
+				return label
+			}
+		}
+	}
+}
+
+// release removes the given unit ID from the given label's members, freeing the label if it has no members left.
+func (l *groupLabeler) release(label string, id uint64) {
+	members, ok := l.members[label]
+	if !ok {
+		return
+	}
+	sets.Remove(members, id)
+	if sets.Len(members) == 0 {
+		delete(l.members, label)
+	}
+}
+
+// remove forgets the given unit ID.
+func (l *groupLabeler) remove(id uint64) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if label, ok := l.labels[id]; ok {
+		l.release(label, id)
+		delete(l.labels, id)
+	}
+}
+
+func (l *groupLabeler) reset() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.labels = make(map[uint64]string)
+	l.members = make(map[string]sets.Set[uint64])
+	l.next = 0
+}
+
+// updateLabels assigns labels to the given groups, which must be every group of the opposing coalition, and sets each
+// group's label. This is the only place labels are assigned; other queries only look labels up. The return values are
+// the same as groupLabeler.update.
+func (r *Radar) updateLabels(groups []*group) (splits, joins map[string]sets.Set[string]) {
+	ids := make([][]uint64, 0, len(groups))
+	for _, grp := range groups {
+		ids = append(ids, grp.ObjectIDs())
+	}
+	splits, joins = r.labels.update(ids)
+	for _, grp := range groups {
+		grp.label = r.labels.lookup(grp.ObjectIDs())
+	}
+	return splits, joins
+}
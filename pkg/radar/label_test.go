@@ -0,0 +1,119 @@
+package radar
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/dharmab/collections/sets"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupLabeler(t *testing.T) {
+	t.Parallel()
+	l := newGroupLabeler()
+	assert.Empty(t, l.lookup([]uint64{1, 2, 3}), "groups are not labeled until the first update")
+
+	l.update([][]uint64{{1, 2, 3}, {4}})
+	assert.Equal(t, "Alpha", l.lookup([]uint64{1, 2, 3}))
+	assert.Equal(t, "Bravo", l.lookup([]uint64{4}))
+	l.update([][]uint64{{4}, {3, 2, 1}})
+	assert.Equal(t, "Alpha", l.lookup([]uint64{1, 2, 3}), "the same contacts keep the same label")
+	assert.Equal(t, "Bravo", l.lookup([]uint64{4}))
+
+	// Contact 3 splits off. The larger part keeps the label.
+	l.update([][]uint64{{3}, {1, 2}, {4}})
+	assert.Equal(t, "Charlie", l.lookup([]uint64{3}))
+	assert.Equal(t, "Alpha", l.lookup([]uint64{1, 2}))
+
+	// Contact 4 joins contacts 1 and 2. The label held by most of the contacts wins.
+	l.update([][]uint64{{1, 2, 4}, {3}})
+	assert.Equal(t, "Alpha", l.lookup([]uint64{1, 2, 4}))
+	// Bravo was released, but labels are not reused until the alphabet wraps around.
+	l.update([][]uint64{{1, 2, 4}, {3}, {5}})
+	assert.Equal(t, "Delta", l.lookup([]uint64{5}))
+
+	l.remove(5)
+	assert.Empty(t, l.lookup([]uint64{5}))
+	l.reset()
+	l.update([][]uint64{{5}})
+	assert.Equal(t, "Alpha", l.lookup([]uint64{5}))
+}
+
+func TestGroupLabelerEvenSplit(t *testing.T) {
+	t.Parallel()
+	l := newGroupLabeler()
+	l.update([][]uint64{{1, 2, 3, 4}})
+
+	// The part containing the lowest ID keeps the label, regardless of the order of the groups.
+	l.update([][]uint64{{3, 4}, {1, 2}})
+	assert.Equal(t, "Bravo", l.lookup([]uint64{3, 4}))
+	assert.Equal(t, "Alpha", l.lookup([]uint64{1, 2}))
+}
+
+func TestGroupLabelerWrapsAround(t *testing.T) {
+	t.Parallel()
+	l := newGroupLabeler()
+	groups := make([][]uint64, 0, len(groupLabelAlphabet)+2)
+	for i := range groupLabelAlphabet {
+		groups = append(groups, []uint64{uint64(i + 1)})
+	}
+	l.update(groups)
+	groups = append(groups, []uint64{100})
+	l.update(groups)
+	assert.Equal(t, "Alpha 2", l.lookup([]uint64{100}))
+
+	groups = slices.DeleteFunc(groups, func(ids []uint64) bool { return ids[0] == 2 })
+	groups = append(groups, []uint64{101})
+	l.update(groups)
+	assert.Equal(t, "Alpha 2", l.lookup([]uint64{100}))
+	assert.Equal(t, "Bravo", l.lookup([]uint64{101}))
+}
+
+func TestFindGroupForAircraftLabel(t *testing.T) {
+	t.Parallel()
+	r := newTestRadarWithContacts()
+	insertTanker(t, r, 1, "Flanker 1", "Su-27", coalitions.Red, orb.Point{30.1, 40.1})
+	insertTanker(t, r, 2, "Flanker 2", "Su-27", coalitions.Red, orb.Point{30.11, 40.1})
+	insertTanker(t, r, 3, "Eagle 1 1", "F-15C", coalitions.Blue, orb.Point{30.5, 40.1})
+
+	lead, ok := r.contacts.getByID(1)
+	require.True(t, ok)
+	wingman, ok := r.contacts.getByID(2)
+	require.True(t, ok)
+	friendly, ok := r.contacts.getByID(3)
+	require.True(t, ok)
+
+	assert.Empty(t, r.findGroupForAircraft(lead).Label(), "lookups do not assign labels")
+	r.GroupChanges(coalitions.Red)
+
+	assert.Equal(t, "Alpha", r.findGroupForAircraft(lead).Label())
+	assert.Equal(t, "Alpha", r.findGroupForAircraft(wingman).Label(), "groups are labeled the same regardless of which contact is used to find them")
+	assert.Empty(t, r.findGroupForAircraft(friendly).Label(), "friendly groups are not labeled")
+}
+
+func TestGroupLabelerEvents(t *testing.T) {
+	t.Parallel()
+	l := newGroupLabeler()
+	splits, joins := l.update([][]uint64{{1, 2, 3}, {4}})
+	assert.Empty(t, splits, "new groups are not splits")
+	assert.Empty(t, joins, "new groups are not joins")
+
+	splits, joins = l.update([][]uint64{{1, 2}, {3}, {4}})
+	assert.Equal(t, map[string][]string{"Alpha": {"Charlie"}}, collectEvents(splits))
+	assert.Empty(t, joins)
+
+	splits, joins = l.update([][]uint64{{1, 2}, {3, 4}})
+	assert.Empty(t, splits)
+	assert.Equal(t, map[string][]string{"Bravo": {"Charlie"}}, collectEvents(joins))
+}
+
+func collectEvents(events map[string]sets.Set[string]) map[string][]string {
+	result := make(map[string][]string, len(events))
+	for key, values := range events {
+		result[key] = slices.Sorted(sets.All(values))
+	}
+	return result
+}
@@ -0,0 +1,108 @@
+package radar
+
+import (
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/encyclopedia"
+	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// maxLauncherCorrelationDistance is the maximum distance between a missile at launch and an aircraft for the
+	// aircraft to be considered the launcher, when the telemetry does not identify the launcher.
+	maxLauncherCorrelationDistance = 1 * unit.NauticalMile
+	// maxTargetCorrelationDistance is the maximum distance between a missile at launch and an aircraft for the
+	// aircraft to be considered the target, when the telemetry does not identify the target.
+	maxTargetCorrelationDistance = 60 * unit.NauticalMile
+	// maxTargetCorrelationAngle is the maximum angle off the missile's heading at which an aircraft may be considered
+	// the target, when the telemetry does not identify the target.
+	maxTargetCorrelationAngle = 30 * unit.Degree
+)
+
+// handleLaunched correlates a missile launch to its launching aircraft and its target, and calls the launchedCallback.
+// Missiles which were not launched by a tracked aircraft, such as surface-to-air missiles, and missiles which are not
+// aimed at an aircraft, such as air-to-ground missiles, are ignored.
+func (r *Radar) handleLaunched(launch sim.Launched) {
+	logger := log.With().Uint64("id", launch.Labels.ID).Str("missile", launch.Labels.ACMIName).Logger()
+
+	launcher := r.correlateLauncher(launch)
+	if launcher == nil {
+		logger.Debug().Msg("ignoring missile launch because it was not correlated to a launching aircraft")
+		return
+	}
+	logger = logger.With().Uint64("launcherID", launcher.Contact.ID).Str("launcher", launcher.Contact.ACMIName).Logger()
+
+	target := r.correlateTarget(launch, launcher)
+	if target == nil {
+		logger.Debug().Msg("ignoring missile launch because it was not correlated to a target aircraft")
+		return
+	}
+	logger.Info().Uint64("targetID", target.Contact.ID).Str("target", target.Contact.Name).Msg("correlated missile launch")
+
+	r.callbackLock.RLock()
+	defer r.callbackLock.RUnlock()
+	if r.launchedCallback != nil {
+		r.launchedCallback(launch.Labels, launcher, target)
+	}
+}
+
+// correlateLauncher returns the trackfile of the aircraft which launched the missile, or nil if no launcher was found.
+func (r *Radar) correlateLauncher(launch sim.Launched) *trackfiles.Trackfile {
+	if launch.LauncherID != 0 {
+		if trackfile, ok := r.contacts.getByID(launch.LauncherID); ok {
+			return trackfile
+		}
+	}
+
+	var launcher *trackfiles.Trackfile
+	nearest := maxLauncherCorrelationDistance
+	for trackfile := range r.contacts.values() {
+		if trackfile.Contact.Coalition != launch.Labels.Coalition || !isValidTrack(trackfile) {
+			continue
+		}
+		distance := spatial.Distance(launch.Frame.Point, trackfile.LastKnown().Point, r.withProjection())
+		if distance <= nearest {
+			launcher = trackfile
+			nearest = distance
+		}
+	}
+	return launcher
+}
+
+// correlateTarget returns the trackfile of the aircraft targeted by the missile, or nil if no target was found. If the
+// telemetry identifies the target, the target must be an aircraft. Otherwise, the target is guessed from the missile's
+// heading, but only for known air-to-air missiles, so that air-to-ground missiles are not mistaken for threats to
+// aircraft ahead of the shot.
+func (r *Radar) correlateTarget(launch sim.Launched, launcher *trackfiles.Trackfile) *trackfiles.Trackfile {
+	if launch.TargetID != 0 {
+		if trackfile, ok := r.contacts.getByID(launch.TargetID); ok {
+			return trackfile
+		}
+		return nil
+	}
+	if !encyclopedia.IsAirToAirMissile(launch.Labels.ACMIName) {
+		return nil
+	}
+
+	heading := bearings.NewTrueBearing(launch.Frame.Heading)
+	var target *trackfiles.Trackfile
+	nearest := maxTargetCorrelationDistance
+	for trackfile := range r.contacts.values() {
+		if trackfile.Contact.Coalition != launcher.Contact.Coalition.Opposite() || !isValidTrack(trackfile) {
+			continue
+		}
+		bearing := spatial.TrueBearing(launch.Frame.Point, trackfile.LastKnown().Point, r.withProjection())
+		if bearings.AngularDistance(heading, bearing) > maxTargetCorrelationAngle {
+			continue
+		}
+		distance := spatial.Distance(launch.Frame.Point, trackfile.LastKnown().Point, r.withProjection())
+		if distance <= nearest {
+			target = trackfile
+			nearest = distance
+		}
+	}
+	return target
+}
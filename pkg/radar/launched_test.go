@@ -0,0 +1,117 @@
+package radar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLaunch(point orb.Point, heading unit.Angle) sim.Launched {
+	return sim.Launched{
+		Labels: trackfiles.Labels{
+			ID:        100,
+			Name:      "R_77",
+			Coalition: coalitions.Red,
+			ACMIName:  "R_77",
+		},
+		Frame: trackfiles.Frame{
+			Time:     time.Now(),
+			Point:    point,
+			Altitude: 25000 * unit.Foot,
+			Heading:  heading,
+		},
+	}
+}
+
+func TestHandleLaunched_CorrelatesByProximityAndHeading(t *testing.T) {
+	t.Parallel()
+	r := newTestRadarWithContacts()
+	insertTanker(t, r, 1, "Flanker 1", "Su-27", coalitions.Red, orb.Point{30.0, 40.0})
+	insertTanker(t, r, 2, "Eagle 1 1", "F-15C", coalitions.Blue, orb.Point{30.3, 40.0})
+	insertTanker(t, r, 3, "Eagle 2 1", "F-15C", coalitions.Blue, orb.Point{30.0, 40.3})
+
+	var launcher, target *trackfiles.Trackfile
+	r.SetLaunchedCallback(func(_ trackfiles.Labels, l, t *trackfiles.Trackfile) {
+		launcher = l
+		target = t
+	})
+
+	// Fired eastward, towards Eagle 1 1.
+	r.handleLaunched(newTestLaunch(orb.Point{30.001, 40.0}, 90*unit.Degree))
+	require.NotNil(t, launcher)
+	require.NotNil(t, target)
+	assert.Equal(t, uint64(1), launcher.Contact.ID)
+	assert.Equal(t, uint64(2), target.Contact.ID)
+
+	// Fired northward, towards Eagle 2 1.
+	r.handleLaunched(newTestLaunch(orb.Point{30.001, 40.0}, 0*unit.Degree))
+	require.NotNil(t, target)
+	assert.Equal(t, uint64(3), target.Contact.ID)
+}
+
+func TestHandleLaunched_CorrelatesByID(t *testing.T) {
+	t.Parallel()
+	r := newTestRadarWithContacts()
+	insertTanker(t, r, 1, "Flanker 1", "Su-27", coalitions.Red, orb.Point{30.0, 40.0})
+	insertTanker(t, r, 2, "Eagle 1 1", "F-15C", coalitions.Blue, orb.Point{30.3, 40.0})
+	insertTanker(t, r, 3, "Eagle 2 1", "F-15C", coalitions.Blue, orb.Point{30.0, 40.3})
+
+	var target *trackfiles.Trackfile
+	r.SetLaunchedCallback(func(_ trackfiles.Labels, _, t *trackfiles.Trackfile) {
+		target = t
+	})
+
+	launch := newTestLaunch(orb.Point{30.001, 40.0}, 90*unit.Degree)
+	launch.LauncherID = 1
+	launch.TargetID = 3
+	r.handleLaunched(launch)
+	require.NotNil(t, target)
+	assert.Equal(t, uint64(3), target.Contact.ID)
+}
+
+func TestHandleLaunched_IgnoresUncorrelatedLaunch(t *testing.T) {
+	t.Parallel()
+	r := newTestRadarWithContacts()
+	insertTanker(t, r, 2, "Eagle 1 1", "F-15C", coalitions.Blue, orb.Point{30.3, 40.0})
+
+	called := false
+	r.SetLaunchedCallback(func(_ trackfiles.Labels, _, _ *trackfiles.Trackfile) {
+		called = true
+	})
+
+	// No aircraft near the launch point, e.g. a surface-to-air missile.
+	r.handleLaunched(newTestLaunch(orb.Point{30.0, 40.0}, 90*unit.Degree))
+	assert.False(t, called)
+}
+
+func TestHandleLaunched_IgnoresAirToGroundMissile(t *testing.T) {
+	t.Parallel()
+	r := newTestRadarWithContacts()
+	insertTanker(t, r, 1, "Flanker 1", "Su-27", coalitions.Red, orb.Point{30.0, 40.0})
+	insertTanker(t, r, 2, "Eagle 1 1", "F-15C", coalitions.Blue, orb.Point{30.3, 40.0})
+
+	called := false
+	r.SetLaunchedCallback(func(_ trackfiles.Labels, _, _ *trackfiles.Trackfile) {
+		called = true
+	})
+
+	// An anti-radiation missile fired eastward, with Eagle 1 1 ahead of the shot.
+	launch := newTestLaunch(orb.Point{30.001, 40.0}, 90*unit.Degree)
+	launch.Labels.Name = "X_58"
+	launch.Labels.ACMIName = "X_58"
+	r.handleLaunched(launch)
+	assert.False(t, called)
+
+	// A missile locked on a target which is not an aircraft.
+	launch = newTestLaunch(orb.Point{30.001, 40.0}, 90*unit.Degree)
+	launch.TargetID = 50
+	r.handleLaunched(launch)
+	assert.False(t, called)
+}
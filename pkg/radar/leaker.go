@@ -0,0 +1,40 @@
+package radar
+
+import (
+	"github.com/dharmab/collections/sets"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/paulmach/orb"
+)
+
+// Leakers returns the groups of the given coalition which contain a contact that crossed the given line inbound since
+// it was at the given previous position. The protected side of the line is on the right when facing along the line
+// from its first point to its last point; contacts crossing outbound are ignored. previous maps unit IDs to their previously observed positions; contacts without a
+// previous position are ignored. Each group's bullseye is set.
+func (r *Radar) Leakers(coalition coalitions.Coalition, line orb.LineString, previous map[uint64]orb.Point) []brevity.Group {
+	visited := sets.New[uint64]()
+	leakers := make([]brevity.Group, 0)
+	for contact := range r.contacts.values() {
+		if sets.Contains(visited, contact.Contact.ID) {
+			continue
+		}
+		if contact.Contact.Coalition != coalition || !isValidTrack(contact) || !r.isDetected(contact) {
+			continue
+		}
+		from, ok := previous[contact.Contact.ID]
+		if !ok || spatial.IsZero(from) {
+			continue
+		}
+		if !spatial.CrossesToRight(from, contact.LastKnown().Point, line) {
+			continue
+		}
+
+		grp := r.findGroupForAircraft(contact)
+		for _, id := range grp.ObjectIDs() {
+			sets.Add(visited, id)
+		}
+		leakers = append(leakers, grp)
+	}
+	return leakers
+}
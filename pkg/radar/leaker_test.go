@@ -0,0 +1,37 @@
+package radar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeakers(t *testing.T) {
+	t.Parallel()
+	r := newTestRadarWithContacts()
+	r.SetMissionTime(time.Now())
+	r.SetBullseye(orb.Point{30.0, 40.0}, coalitions.Blue)
+
+	insertTanker(t, r, 1, "Flanker 1", "Su-27", coalitions.Red, orb.Point{30.1, 40.5})
+	insertTanker(t, r, 2, "Flanker 2", "Su-27", coalitions.Red, orb.Point{29.5, 40.5})
+	insertTanker(t, r, 3, "Flanker 3", "Su-27", coalitions.Red, orb.Point{30.1, 41.5})
+	insertTanker(t, r, 4, "Eagle 1 1", "F-15C", coalitions.Blue, orb.Point{30.1, 40.5})
+	insertTanker(t, r, 5, "Flanker 5", "Su-27", coalitions.Red, orb.Point{29.9, 40.9})
+
+	line := orb.LineString{{30, 40}, {30, 41}}
+	previous := map[uint64]orb.Point{
+		1: {29.9, 40.5},
+		2: {29.4, 40.5},
+		4: {29.9, 40.5},
+		// Egressing back across the line from the protected side.
+		5: {30.1, 40.9},
+	}
+	leakers := r.Leakers(coalitions.Red, line, previous)
+	require.Len(t, leakers, 1)
+	assert.Equal(t, []uint64{1}, leakers[0].ObjectIDs())
+	assert.NotNil(t, leakers[0].Bullseye())
+}
@@ -25,7 +25,7 @@ func (r *Radar) Merges(coalition coalitions.Coalition) map[brevity.Group][]*trac
 			continue
 		}
 
-		if !isValidTrack(contact) {
+		if !isValidTrack(contact) || !r.isDetected(contact) {
 			continue
 		}
 
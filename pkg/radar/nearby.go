@@ -24,7 +24,7 @@ func (r *Radar) findNearbyGroups(pointOfInterest orb.Point, minAltitude, maxAlti
 		if sets.Contains(visited, trackfile.Contact.ID) {
 			continue
 		}
-		isMatch := isMatch(trackfile, coalition, filter)
+		isMatch := r.isMatch(trackfile, coalition, filter)
 		inCircle := circle.Contains(trackfile.LastKnown().Point)
 		inStack := minAltitude <= trackfile.LastKnown().Altitude && trackfile.LastKnown().Altitude <= maxAltitude
 		if isMatch && inCircle && inStack {
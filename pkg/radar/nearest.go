@@ -30,7 +30,7 @@ func (r *Radar) FindNearestTrackfile(
 	var nearestTrackfile *trackfiles.Trackfile
 	nearestDistance := radius
 	for trackfile := range r.contacts.values() {
-		isMatch := isMatch(trackfile, coalition, filter)
+		isMatch := r.isMatch(trackfile, coalition, filter)
 		altitude := trackfile.LastKnown().Altitude
 		isWithinAltitude := minAltitude <= altitude && altitude <= maxAltitude
 		if isMatch && isWithinAltitude {
@@ -145,7 +145,7 @@ func (r *Radar) FindNearestGroupInSector(origin orb.Point, minAltitude, maxAltit
 	var nearestContact *trackfiles.Trackfile
 	for trackfile := range r.contacts.values() {
 		logger := logger.With().Uint64("id", trackfile.Contact.ID).Logger()
-		isMatch := isMatch(trackfile, coalition, filter)
+		isMatch := r.isMatch(trackfile, coalition, filter)
 		isWithinAltitude := minAltitude <= trackfile.LastKnown().Altitude && trackfile.LastKnown().Altitude <= maxAltitude
 		if isMatch && isWithinAltitude {
 			contactLocation := trackfile.LastKnown().Point
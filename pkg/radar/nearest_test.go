@@ -21,7 +21,9 @@ func newTestRadarWithContacts() *Radar {
 	starts := make(chan sim.Started)
 	updates := make(chan sim.Updated)
 	fades := make(chan sim.Faded)
-	return New(coalitions.Blue, starts, updates, fades, 25*unit.NauticalMile, 5*unit.Degree, 1*unit.NauticalMile, false, nil)
+	destroys := make(chan sim.Destroyed)
+	launches := make(chan sim.Launched)
+	return New(coalitions.Blue, starts, updates, fades, destroys, launches, 25*unit.NauticalMile, 5*unit.Degree, 1*unit.NauticalMile, false, nil)
 }
 
 // insertTanker adds a tanker trackfile at the given point to the radar's
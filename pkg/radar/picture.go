@@ -14,8 +14,9 @@ import (
 // Picture returns a picture of the radar scope anchored at the center point, within the given radius,
 // filtered by the given coalition and contact category. The first return value is the total number of groups
 // and the second is a slice of up to 3 high priority groups. Each group has Bullseye set relative to the
-// point provided in SetBullseye.
-func (r *Radar) Picture(radius unit.Length, coalition coalitions.Coalition, filter brevity.ContactCategory) (int, []brevity.Group) {
+// point provided in SetBullseye. If the picture contains 2 or 3 groups which form a standard presentation, the third
+// return value is the formation, and the groups are named and ordered accordingly.
+func (r *Radar) Picture(radius unit.Length, coalition coalitions.Coalition, filter brevity.ContactCategory) (int, []brevity.Group, brevity.Formation) {
 	// Find groups near the center point
 	r.centerLock.RLock()
 	defer r.centerLock.RUnlock()
@@ -43,12 +44,20 @@ func (r *Radar) Picture(radius unit.Length, coalition coalitions.Coalition, filt
 
 	// Return the top 3 groups
 	capacity := min(len(groups), 3)
+	top := groups[:capacity]
+
+	// Only label the formation if every group in the picture is included
+	formation := brevity.NoFormation
+	if len(groups) == capacity {
+		formation, top = r.classifyFormation(origin, top)
+	}
+
 	result := make([]brevity.Group, capacity)
 	for i := range capacity {
-		result[i] = groups[i]
+		result[i] = top[i]
 	}
-	log.Info().Float64("centerLat", origin.Lat()).Float64("centerLon", origin.Lon()).Int("groups", len(groups)).Msg("generating PICTURE")
-	return len(groups), result
+	log.Info().Float64("centerLat", origin.Lat()).Float64("centerLon", origin.Lon()).Int("groups", len(groups)).Str("formation", string(formation)).Msg("generating PICTURE")
+	return len(groups), result, formation
 }
 
 func (r *Radar) compareThreat(a, b *group) int {
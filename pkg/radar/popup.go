@@ -0,0 +1,122 @@
+package radar
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/dharmab/collections/sets"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+)
+
+const (
+	// popUpWindow is how long after a contact is first seen that it is considered a pop-up.
+	popUpWindow = 30 * time.Second
+	// popUpGracePeriod is how long after the first contact is seen that new contacts are not considered pop-ups.
+	// This prevents every contact from being reported as a pop-up when the mission (re)starts.
+	popUpGracePeriod = 1 * time.Minute
+)
+
+// popUpDetector records when each contact was first seen as a valid track, so that groups which suddenly appear
+// close to friendly aircraft, such as low-level groups or groups which just took off, can be identified.
+type popUpDetector struct {
+	// firstSeen maps unit IDs to the mission time at which the unit was first seen as a valid track.
+	firstSeen map[uint64]time.Time
+	// epoch is the mission time at which the first valid track was seen since the mission (re)started.
+	epoch time.Time
+	// lock protects firstSeen and epoch.
+	lock sync.RWMutex
+}
+
+func newPopUpDetector() *popUpDetector {
+	d := &popUpDetector{}
+	d.reset()
+	return d
+}
+
+// observe records that the given unit was seen as a valid track at the given mission time, if it has not been seen
+// before.
+func (d *popUpDetector) observe(id uint64, t time.Time) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.epoch.IsZero() {
+		d.epoch = t
+	}
+	if _, ok := d.firstSeen[id]; !ok {
+		d.firstSeen[id] = t
+	}
+}
+
+// isPopUp checks if the given unit was first seen recently, relative to the given mission time.
+func (d *popUpDetector) isPopUp(id uint64, now time.Time) bool {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	firstSeen, ok := d.firstSeen[id]
+	if !ok {
+		return false
+	}
+	if firstSeen.Before(d.epoch.Add(popUpGracePeriod)) {
+		return false
+	}
+	return now.Sub(firstSeen) <= popUpWindow
+}
+
+func (d *popUpDetector) remove(id uint64) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	delete(d.firstSeen, id)
+}
+
+func (d *popUpDetector) reset() {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.firstSeen = make(map[uint64]time.Time)
+	d.epoch = time.Time{}
+}
+
+// PopUps returns a map of hostile groups of the given coalition which contain a recently appeared contact, to the
+// friendly trackfiles within the given radius of the group. If there is only one nearby friendly, the group's BRAA
+// is set relative to that friendly. Otherwise, the group's bullseye is set.
+func (r *Radar) PopUps(coalition coalitions.Coalition, radius unit.Length) map[brevity.Group][]*trackfiles.Trackfile {
+	now := r.now()
+
+	visited := sets.New[uint64]()
+	result := make(map[brevity.Group][]*trackfiles.Trackfile)
+	for contact := range r.contacts.values() {
+		if sets.Contains(visited, contact.Contact.ID) {
+			continue
+		}
+		if contact.Contact.Coalition != coalition || !isValidTrack(contact) || !r.isDetected(contact) {
+			continue
+		}
+		if !r.popUps.isPopUp(contact.Contact.ID, now) {
+			continue
+		}
+
+		grp := r.findGroupForAircraft(contact)
+		for _, id := range grp.ObjectIDs() {
+			sets.Add(visited, id)
+		}
+
+		friendlies := make([]*trackfiles.Trackfile, 0)
+		for _, friendlyGroup := range r.findNearbyGroups(grp.point(), 0, math.MaxFloat64, radius, coalition.Opposite(), brevity.Aircraft, []uint64{}) {
+			for _, friendly := range friendlyGroup.contacts {
+				if spatial.Distance(grp.point(), positionAt(friendly, now), r.withProjection()) <= radius {
+					friendlies = append(friendlies, friendly)
+				}
+			}
+		}
+		if len(friendlies) == 0 {
+			continue
+		}
+		if len(friendlies) == 1 {
+			r.setGroupBRAA(grp, positionAt(friendlies[0], now))
+		}
+		result[grp] = friendlies
+	}
+	return result
+}
@@ -0,0 +1,59 @@
+package radar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPopUpDetector(t *testing.T) {
+	t.Parallel()
+	d := newPopUpDetector()
+	epoch := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	d.observe(1, epoch)
+	d.observe(2, epoch.Add(30*time.Second))
+	d.observe(3, epoch.Add(5*time.Minute))
+	// Later observations do not change the first-seen time.
+	d.observe(3, epoch.Add(6*time.Minute))
+
+	now := epoch.Add(5*time.Minute + 10*time.Second)
+	assert.False(t, d.isPopUp(1, now), "contacts present at the epoch are not pop-ups")
+	assert.False(t, d.isPopUp(2, now), "contacts seen during the grace period are not pop-ups")
+	assert.True(t, d.isPopUp(3, now))
+	assert.False(t, d.isPopUp(3, now.Add(time.Minute)), "contacts are only pop-ups shortly after they appear")
+	assert.False(t, d.isPopUp(4, now), "unknown contacts are not pop-ups")
+
+	d.remove(3)
+	assert.False(t, d.isPopUp(3, now))
+}
+
+func TestPopUps(t *testing.T) {
+	t.Parallel()
+	r := newTestRadarWithContacts()
+	now := time.Now()
+	r.SetMissionTime(now)
+	r.SetBullseye(orb.Point{30.0, 40.0}, coalitions.Blue)
+
+	insertTanker(t, r, 1, "Eagle 1 1", "F-15C", coalitions.Blue, orb.Point{30.1, 40.1})
+	insertTanker(t, r, 2, "Flanker 1", "Su-27", coalitions.Red, orb.Point{30.4, 40.1})
+	insertTanker(t, r, 3, "Flanker 2", "Su-27", coalitions.Red, orb.Point{32.0, 42.0})
+	r.popUps.observe(1, now.Add(-10*time.Minute))
+	r.popUps.observe(2, now.Add(-10*time.Second))
+	r.popUps.observe(3, now.Add(-10*time.Second))
+
+	popUps := r.PopUps(coalitions.Red, 30*unit.NauticalMile)
+	require.Len(t, popUps, 1)
+	for grp, friendlies := range popUps {
+		assert.Equal(t, []uint64{2}, grp.ObjectIDs())
+		require.Len(t, friendlies, 1)
+		assert.Equal(t, uint64(1), friendlies[0].Contact.ID)
+		require.NotNil(t, grp.BRAA())
+		assert.InDelta(t, 14, grp.BRAA().Range().NauticalMiles(), 2)
+	}
+}
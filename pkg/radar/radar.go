@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/dharmab/collections/sets"
 	"github.com/dharmab/skyeye/pkg/bearings"
 	"github.com/dharmab/skyeye/pkg/brevity"
 	"github.com/dharmab/skyeye/pkg/coalitions"
@@ -29,6 +30,10 @@ type Radar struct {
 	updates <-chan sim.Updated
 	// fades receives events when aircraft are marked as removed.
 	fades <-chan sim.Faded
+	// destroys receives events when aircraft are destroyed.
+	destroys <-chan sim.Destroyed
+	// launches receives events when missiles are launched.
+	launches <-chan sim.Launched
 	// missionTime should be continually updated to the current mission time.
 	missionTime time.Time
 	// missionTimeLock protects missionTime.
@@ -39,13 +44,23 @@ type Radar struct {
 	bullseyes sync.Map
 	// contacts contains trackfiles for each aircraft.
 	contacts *contactDatabase
+	// surface contains trackfiles for each sea and ground object.
+	surface *contactDatabase
+	// popUps records when each contact was first seen, to detect pop-up groups.
+	popUps *popUpDetector
+	// labels assigns persistent labels to hostile groups.
+	labels *groupLabeler
 	// startedCallback is called when a start event is received.
 	startedCallback StartedCallback
 	// fadedCallback is called when a fade event is received.
 	fadedCallback FadedCallback
+	// destroyedCallback is called when a destroyed group is removed.
+	destroyedCallback DestroyedCallback
+	// launchedCallback is called when an aircraft launches a missile at another aircraft.
+	launchedCallback LaunchedCallback
 	// removalCallback is called when a trackfile is removed for a reason other than a fade event.
 	removalCallback RemovedCallback
-	// callbackLock protects startedCallback, fadedCallback, and removalCallback.
+	// callbackLock protects startedCallback, fadedCallback, destroyedCallback, launchedCallback, and removalCallback.
 	callbackLock sync.RWMutex
 	// center is a point used to center PICTURE calls.
 	center orb.Point
@@ -67,7 +82,9 @@ type Radar struct {
 	completedFadesLock sync.RWMutex
 	// pendingFades collects faded contacts for grouping.
 	pendingFades []sim.Faded
-	// pendingFadesLock protects pendingFades.
+	// pendingDestroys contains the IDs of pending faded contacts which were destroyed.
+	pendingDestroys sets.Set[uint64]
+	// pendingFadesLock protects pendingFades and pendingDestroys.
 	pendingFadesLock sync.RWMutex
 	// enableTerrainDetection controls whether terrain detection and Transverse Mercator projection are used.
 	// When false, spatial functions use spherical Earth calculations.
@@ -79,6 +96,11 @@ type Radar struct {
 	projection projections.Projection
 	// projectionLock protects projection.
 	projectionLock sync.RWMutex
+	// sensors limits the radar picture to contacts detected by friendly emitters. If nil, all contacts are visible.
+	sensors *SensorModel
+	// awacs is the unit or group name of the friendly aircraft the GCI is bound to. If empty, the GCI is not bound to
+	// an aircraft.
+	awacs string
 }
 
 // New creates a radar scope that consumes updates from the provided channels.
@@ -89,13 +111,18 @@ type Radar struct {
 // srsClient is used by threat detection to filter receiver to friendlies that
 // are on the controller's SRS frequencies; pass nil to disable this filtering and
 // treat every friendly as a receiver.
-func New(coalition coalitions.Coalition, starts <-chan sim.Started, updates <-chan sim.Updated, fades <-chan sim.Faded, mandatoryThreatRadius unit.Length, maxBRAABearingSpread unit.Angle, maxBRAARangeSpread unit.Length, enableTerrainDetection bool, srsClient *simpleradio.Client) *Radar {
+func New(coalition coalitions.Coalition, starts <-chan sim.Started, updates <-chan sim.Updated, fades <-chan sim.Faded, destroys <-chan sim.Destroyed, launches <-chan sim.Launched, mandatoryThreatRadius unit.Length, maxBRAABearingSpread unit.Angle, maxBRAARangeSpread unit.Length, enableTerrainDetection bool, srsClient *simpleradio.Client) *Radar {
 	return &Radar{
 		coalition:                  coalition,
 		starts:                     starts,
 		updates:                    updates,
 		fades:                      fades,
+		destroys:                   destroys,
+		launches:                   launches,
 		contacts:                   newContactDatabase(),
+		surface:                    newContactDatabase(),
+		popUps:                     newPopUpDetector(),
+		labels:                     newGroupLabeler(),
 		mandatoryThreatRadius:      mandatoryThreatRadius,
 		maxSharedBRAABearingSpread: maxBRAABearingSpread,
 		maxSharedBRAARangeSpread:   maxBRAARangeSpread,
@@ -103,16 +130,31 @@ func New(coalition coalitions.Coalition, starts <-chan sim.Started, updates <-ch
 		srsClient:                  srsClient,
 		completedFades:             map[uint64]time.Time{},
 		pendingFades:               []sim.Faded{},
+		pendingDestroys:            sets.New[uint64](),
 	}
 }
 
-// SetMissionTime updates the mission time. The mission time is used for computing magnetic declination.
+// SetMissionTime updates the mission time. The mission time is used for computing magnetic declination, and is the
+// time to which contacts' positions are predicted.
 func (r *Radar) SetMissionTime(t time.Time) {
 	r.missionTimeLock.Lock()
 	defer r.missionTimeLock.Unlock()
 	r.missionTime = t
 }
 
+// now returns the time provided in SetMissionTime.
+func (r *Radar) now() time.Time {
+	r.missionTimeLock.RLock()
+	defer r.missionTimeLock.RUnlock()
+	return r.missionTime
+}
+
+// Position returns the given trackfile's position at the time provided in SetMissionTime. If the trackfile was last
+// updated before then, the position is predicted from the trackfile's filtered velocity.
+func (r *Radar) Position(trackfile *trackfiles.Trackfile) orb.Point {
+	return positionAt(trackfile, r.now())
+}
+
 // SetBullseye updates the bullseye point for the given coalition.
 // The bullseye point is the reference point for polar coordinates provided in [Group.Bullseye].
 // When terrain detection is enabled, this also detects the closest DCS terrain and updates
@@ -218,6 +260,17 @@ func (r *Radar) Run(ctx context.Context, wg *sync.WaitGroup) {
 			}
 		}
 	})
+
+	wg.Go(func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case launch := <-r.launches:
+				r.handleLaunched(launch)
+			}
+		}
+	})
 	wg.Go(func() {
 		r.collectFadedTrackfiles(ctx)
 	})
@@ -247,13 +300,23 @@ func (r *Radar) handleUpdate(update sim.Updated) {
 		Stringer("coalition", update.Labels.Coalition).
 		Logger()
 
-	trackfile, ok := r.contacts.getByID(update.Labels.ID)
+	db := r.contacts
+	if update.Labels.Class.IsSurface() {
+		db = r.surface
+	}
+	trackfile, ok := db.getByID(update.Labels.ID)
 	if ok {
 		trackfile.Update(update.Frame)
 	} else {
 		trackfile = trackfiles.New(update.Labels)
-		r.contacts.set(trackfile)
-		logger.Info().Msg("created new trackfile")
+		db.set(trackfile)
+		logger.Info().Stringer("class", update.Labels.Class).Msg("created new trackfile")
+	}
+	if update.Labels.Class.IsSurface() {
+		return
+	}
+	if isValidTrack(trackfile) {
+		r.popUps.observe(trackfile.Contact.ID, update.Frame.Time)
 	}
 }
 
@@ -279,6 +342,9 @@ func (r *Radar) handleGarbageCollection() {
 		isOld := lastSeen.Before(r.missionTime.Add(-1 * time.Minute))
 		if !lastSeen.IsZero() && isOld {
 			ok := r.contacts.delete(trackfile.Contact.ID)
+			r.popUps.remove(trackfile.Contact.ID)
+			r.labels.remove(trackfile.Contact.ID)
+			r.forgetDetection(trackfile.Contact.ID)
 			if ok {
 				logger.Info().
 					Stringer("age", r.missionTime.Sub(lastSeen)).
@@ -293,6 +359,19 @@ func (r *Radar) handleGarbageCollection() {
 			}
 		}
 	}
+
+	for trackfile := range r.surface.values() {
+		lastSeen := trackfile.LastKnown().Time
+		isOld := lastSeen.Before(r.missionTime.Add(-1 * time.Minute))
+		if !lastSeen.IsZero() && isOld && r.surface.delete(trackfile.Contact.ID) {
+			log.Info().
+				Uint64("id", trackfile.Contact.ID).
+				Str("name", trackfile.Contact.ACMIName).
+				Stringer("class", trackfile.Contact.Class).
+				Stringer("age", r.missionTime.Sub(lastSeen)).
+				Msg("expired surface trackfile")
+		}
+	}
 }
 
 // isValidTrack checks if the trackfile is valid. This means the following conditions are met:
@@ -316,13 +395,17 @@ func isValidTrack(trackfile *trackfiles.Trackfile) bool {
 //   - if the trackfile is of the given coalition
 //   - if the trackfile is of the given contact category (or if the aircraft is not in the encyclopedia)
 //   - if the trackfile is valid
-func isMatch(trackfile *trackfiles.Trackfile, coalition coalitions.Coalition, filter brevity.ContactCategory) bool {
+//   - if the trackfile is detected by the sensor model
+func (r *Radar) isMatch(trackfile *trackfiles.Trackfile, coalition coalitions.Coalition, filter brevity.ContactCategory) bool {
 	if trackfile.Contact.Coalition != coalition {
 		return false
 	}
 	if !isValidTrack(trackfile) {
 		return false
 	}
+	if !r.isDetected(trackfile) {
+		return false
+	}
 	data, ok := encyclopedia.GetAircraftData(trackfile.Contact.ACMIName)
 	// If the aircraft is not in the encyclopedia, assume it matches
 	matchesFilter := !ok || data.Category() == filter || filter == brevity.Aircraft
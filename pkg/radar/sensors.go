@@ -0,0 +1,202 @@
+package radar
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/encyclopedia"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+)
+
+// effectiveEarthRadius is the radius of the Earth scaled by the standard 4/3 factor, which accounts for atmospheric
+// refraction bending radar waves beyond the geometric horizon.
+const effectiveEarthRadius = 4.0 / 3.0 * 6371 * unit.Kilometer
+
+const (
+	// awacsRange is the instrumented range of an airborne early warning aircraft's radar.
+	awacsRange = 250 * unit.NauticalMile
+	// ewrRange is the instrumented range of a ground-based early warning radar.
+	ewrRange = 150 * unit.NauticalMile
+	// ewrAntennaHeight is the height of a ground-based early warning radar's antenna above the ground.
+	ewrAntennaHeight = 20 * unit.Meter
+	// terrainSampleInterval is the distance between terrain samples along the line of sight.
+	terrainSampleInterval = 0.5 * unit.NauticalMile
+)
+
+// ewrNames contains the ACMI names of ground-based early warning radars.
+var ewrNames = []string{"1L13 EWR", "55G6 EWR", "FPS-117", "FPS-117 Dome", "FPS-117 ECS"}
+
+// Emitter is a friendly surveillance radar.
+type Emitter struct {
+	// Point is the position of the radar.
+	Point orb.Point
+	// Altitude is the height of the radar antenna above mean sea level.
+	Altitude unit.Length
+	// Range is the instrumented range of the radar.
+	Range unit.Length
+}
+
+// Terrain provides terrain elevation data for terrain masking.
+type Terrain interface {
+	// Elevation returns the terrain elevation above mean sea level at the given point. The second return value is
+	// false if no elevation data is available for the point.
+	Elevation(orb.Point) (unit.Length, bool)
+}
+
+// SensorModel limits the radar picture to hostile contacts which a friendly surveillance radar can detect. A contact
+// is detected if it is within the instrumented range and radar horizon of at least one friendly emitter, and, if
+// terrain data is available, the line of sight is not masked by terrain.
+type SensorModel struct {
+	// sites are fixed emitters which are not present in the telemetry.
+	sites []Emitter
+	// terrain is used for terrain masking. If nil, terrain masking is disabled.
+	terrain Terrain
+	// detections caches the detection status of each contact.
+	detections map[uint64]detection
+	// lock protects detections.
+	lock sync.Mutex
+}
+
+// detection is the detection status of a contact at a point in time.
+type detection struct {
+	time     time.Time
+	detected bool
+}
+
+// NewSensorModel creates a sensor model using the given fixed emitters in addition to the bound AWACS aircraft and
+// friendly early warning aircraft and radars in the telemetry. If terrain is nil, terrain masking is disabled.
+func NewSensorModel(sites []Emitter, terrain Terrain) *SensorModel {
+	return &SensorModel{
+		sites:      sites,
+		terrain:    terrain,
+		detections: make(map[uint64]detection),
+	}
+}
+
+// SetSensorModel enables the given sensor model. When enabled, hostile contacts which are not detected by any
+// friendly emitter are excluded from PICTURE, BOGEY DOPE, THREAT and other group queries. This must be called before
+// Run.
+func (r *Radar) SetSensorModel(model *SensorModel) {
+	r.sensors = model
+}
+
+// isDetected returns true if the given trackfile is visible on the radar. Friendly contacts are always visible.
+func (r *Radar) isDetected(trackfile *trackfiles.Trackfile) bool {
+	if r.sensors == nil || trackfile.Contact.Coalition == r.coalition {
+		return true
+	}
+	frame := trackfile.LastKnown()
+
+	r.sensors.lock.Lock()
+	defer r.sensors.lock.Unlock()
+	if cached, ok := r.sensors.detections[trackfile.Contact.ID]; ok && cached.time.Equal(frame.Time) {
+		return cached.detected
+	}
+	detected := slices.ContainsFunc(r.emitters(), func(emitter Emitter) bool {
+		return r.sensors.canDetect(emitter, frame.Point, frame.Altitude, r.withProjection())
+	})
+	r.sensors.detections[trackfile.Contact.ID] = detection{time: frame.Time, detected: detected}
+	return detected
+}
+
+// resetSensors clears the sensor model's detection cache.
+func (r *Radar) resetSensors() {
+	if r.sensors == nil {
+		return
+	}
+	r.sensors.lock.Lock()
+	defer r.sensors.lock.Unlock()
+	r.sensors.detections = make(map[uint64]detection)
+}
+
+// forgetDetection removes the given contact from the sensor model's detection cache.
+func (r *Radar) forgetDetection(id uint64) {
+	if r.sensors == nil {
+		return
+	}
+	r.sensors.lock.Lock()
+	defer r.sensors.lock.Unlock()
+	delete(r.sensors.detections, id)
+}
+
+// emitters returns the fixed emitters, the bound AWACS aircraft, and the friendly early warning aircraft and radars in
+// the telemetry.
+func (r *Radar) emitters() []Emitter {
+	emitters := slices.Clone(r.sensors.sites)
+	for trackfile := range r.contacts.values() {
+		if trackfile.Contact.Coalition != r.coalition || !isValidTrack(trackfile) {
+			continue
+		}
+		data, ok := encyclopedia.GetAircraftData(trackfile.Contact.ACMIName)
+		isEarlyWarning := ok && data.HasTag(encyclopedia.AirborneEarlyWarning)
+		if !isEarlyWarning && !r.isAWACS(trackfile) {
+			continue
+		}
+		emitters = append(emitters, Emitter{
+			Point:    trackfile.LastKnown().Point,
+			Altitude: trackfile.LastKnown().Altitude,
+			Range:    awacsRange,
+		})
+	}
+	for trackfile := range r.surface.values() {
+		if trackfile.Contact.Coalition != r.coalition || trackfile.IsLastKnownPointZero() {
+			continue
+		}
+		if !slices.Contains(ewrNames, trackfile.Contact.ACMIName) {
+			continue
+		}
+		emitters = append(emitters, Emitter{
+			Point:    trackfile.LastKnown().Point,
+			Altitude: trackfile.LastKnown().Altitude + ewrAntennaHeight,
+			Range:    ewrRange,
+		})
+	}
+	return emitters
+}
+
+// canDetect returns true if the given emitter can detect a target at the given point and altitude.
+func (m *SensorModel) canDetect(emitter Emitter, target orb.Point, altitude unit.Length, opts ...spatial.Option) bool {
+	distance := spatial.Distance(emitter.Point, target, opts...)
+	if distance > emitter.Range {
+		return false
+	}
+	if distance > radarHorizon(emitter.Altitude)+radarHorizon(altitude) {
+		return false
+	}
+	return m.terrain == nil || !m.isMasked(emitter, target, altitude, distance)
+}
+
+// radarHorizon returns the distance to the radar horizon from the given height above mean sea level.
+func radarHorizon(height unit.Length) unit.Length {
+	return unit.Length(math.Sqrt(2 * effectiveEarthRadius.Meters() * max(height.Meters(), 0)))
+}
+
+// isMasked returns true if terrain blocks the line of sight between the emitter and the target. The terrain is
+// raised by the curvature of the Earth at each sample point, relative to a straight line between the endpoints.
+func (m *SensorModel) isMasked(emitter Emitter, target orb.Point, altitude, distance unit.Length) bool {
+	samples := int(distance / terrainSampleInterval)
+	for i := 1; i < samples; i++ {
+		fraction := float64(i) / float64(samples)
+		point := orb.Point{
+			emitter.Point.Lon() + (target.Lon()-emitter.Point.Lon())*fraction,
+			emitter.Point.Lat() + (target.Lat()-emitter.Point.Lat())*fraction,
+		}
+		elevation, ok := m.terrain.Elevation(point)
+		if !ok {
+			continue
+		}
+		fromEmitter := distance.Meters() * fraction
+		fromTarget := distance.Meters() - fromEmitter
+		bulge := fromEmitter * fromTarget / (2 * effectiveEarthRadius.Meters())
+		lineOfSight := emitter.Altitude.Meters() + (altitude.Meters()-emitter.Altitude.Meters())*fraction
+		if elevation.Meters()+bulge > lineOfSight {
+			return true
+		}
+	}
+	return false
+}
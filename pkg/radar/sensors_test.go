@@ -0,0 +1,157 @@
+package radar
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ridge is terrain with a 3000 meter ridge along the given longitude and flat sea level terrain elsewhere.
+type ridge float64
+
+func (r ridge) Elevation(p orb.Point) (unit.Length, bool) {
+	if math.Abs(p.Lon()-float64(r)) < 0.05 {
+		return 3000 * unit.Meter, true
+	}
+	return 0, true
+}
+
+func insertAircraft(r *Radar, id uint64, acmiName string, coalition coalitions.Coalition, point orb.Point, altitude unit.Length) {
+	tf := trackfiles.New(trackfiles.Labels{
+		ID:        id,
+		Name:      acmiName,
+		Coalition: coalition,
+		ACMIName:  acmiName,
+	})
+	agl := altitude
+	tf.Update(trackfiles.Frame{
+		Time:     time.Now(),
+		Point:    point,
+		Altitude: altitude,
+		AGL:      &agl,
+		Heading:  90 * unit.Degree,
+	})
+	r.contacts.set(tf)
+}
+
+func TestSensorModelCanDetect(t *testing.T) {
+	t.Parallel()
+	origin := orb.Point{30.0, 40.0}
+	awacs := Emitter{Point: origin, Altitude: 30000 * unit.Foot, Range: awacsRange}
+	ewr := Emitter{Point: origin, Altitude: ewrAntennaHeight, Range: ewrRange}
+	testCases := []struct {
+		name     string
+		emitter  Emitter
+		bearing  float64
+		distance unit.Length
+		altitude unit.Length
+		terrain  Terrain
+		expected bool
+	}{
+		{name: "AWACS detects low target within horizon", emitter: awacs, distance: 150 * unit.NauticalMile, altitude: 100 * unit.Foot, expected: true},
+		{name: "AWACS misses low target beyond horizon", emitter: awacs, distance: 240 * unit.NauticalMile, altitude: 100 * unit.Foot, expected: false},
+		{name: "AWACS misses high target beyond range", emitter: awacs, distance: 260 * unit.NauticalMile, altitude: 40000 * unit.Foot, expected: false},
+		{name: "EWR detects high target", emitter: ewr, distance: 100 * unit.NauticalMile, altitude: 30000 * unit.Foot, expected: true},
+		{name: "EWR misses low target beyond horizon", emitter: ewr, distance: 100 * unit.NauticalMile, altitude: 500 * unit.Foot, expected: false},
+		{name: "EWR detects target clear of terrain", emitter: ewr, bearing: 90, distance: 40 * unit.NauticalMile, altitude: 20000 * unit.Foot, terrain: ridge(29.0), expected: true},
+		{name: "EWR misses target masked by terrain", emitter: ewr, bearing: 90, distance: 40 * unit.NauticalMile, altitude: 5000 * unit.Foot, terrain: ridge(30.4), expected: false},
+		{name: "EWR detects target above terrain", emitter: ewr, bearing: 90, distance: 40 * unit.NauticalMile, altitude: 30000 * unit.Foot, terrain: ridge(30.4), expected: true},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			model := NewSensorModel(nil, test.terrain)
+			target := pointAt(origin, test.bearing, test.distance)
+			assert.Equal(t, test.expected, model.canDetect(test.emitter, target, test.altitude))
+		})
+	}
+}
+
+func pointAt(origin orb.Point, bearing float64, distance unit.Length) orb.Point {
+	lat := origin.Lat() + distance.NauticalMiles()/60*math.Cos(bearing*math.Pi/180)
+	lon := origin.Lon() + distance.NauticalMiles()/60*math.Sin(bearing*math.Pi/180)/math.Cos(origin.Lat()*math.Pi/180)
+	return orb.Point{lon, lat}
+}
+
+func TestSensorModelHidesUndetectedContacts(t *testing.T) {
+	t.Parallel()
+	r := newTestRadarWithContacts()
+	r.SetSensorModel(NewSensorModel(nil, nil))
+	origin := orb.Point{30.0, 40.0}
+	insertAircraft(r, 1, "F-15C", coalitions.Blue, origin, 20000*unit.Foot)
+	insertAircraft(r, 2, "MiG-29S", coalitions.Red, pointAt(origin, 90, 30*unit.NauticalMile), 200*unit.Foot)
+
+	// Without a friendly emitter, no hostile contacts are visible.
+	assert.Empty(t, r.FindNearbyGroupsWithBullseye(origin, 0, math.MaxFloat64, 100*unit.NauticalMile, coalitions.Red, brevity.Aircraft, nil))
+	assert.Nil(t, r.FindNearestGroupWithBRAA(origin, 0, math.MaxFloat64, 100*unit.NauticalMile, coalitions.Red, brevity.Aircraft))
+	assert.Empty(t, r.enumerateGroups(coalitions.Red))
+	// Friendly contacts are always visible.
+	assert.Len(t, r.FindNearbyGroupsWithBullseye(origin, 0, math.MaxFloat64, 100*unit.NauticalMile, coalitions.Blue, brevity.Aircraft, nil), 1)
+
+	// An AWACS within range detects the hostile contact.
+	insertAircraft(r, 3, "E-3A", coalitions.Blue, pointAt(origin, 270, 50*unit.NauticalMile), 30000*unit.Foot)
+	r.forgetDetection(2)
+	assert.Len(t, r.FindNearbyGroupsWithBullseye(origin, 0, math.MaxFloat64, 100*unit.NauticalMile, coalitions.Red, brevity.Aircraft, nil), 1)
+	assert.Len(t, r.enumerateGroups(coalitions.Red), 1)
+	assert.NotNil(t, r.FindNearestGroupWithBRAA(origin, 0, math.MaxFloat64, 100*unit.NauticalMile, coalitions.Red, brevity.Aircraft))
+}
+
+func TestSensorModelUsesGroundRadars(t *testing.T) {
+	t.Parallel()
+	r := newTestRadarWithContacts()
+	origin := orb.Point{30.0, 40.0}
+	site := Emitter{Point: origin, Altitude: 100 * unit.Meter, Range: ewrRange}
+	r.SetSensorModel(NewSensorModel([]Emitter{site}, nil))
+	insertAircraft(r, 1, "MiG-29S", coalitions.Red, pointAt(origin, 90, 60*unit.NauticalMile), 25000*unit.Foot)
+	insertAircraft(r, 2, "MiG-29S", coalitions.Red, pointAt(origin, 0, 120*unit.NauticalMile), 25000*unit.Foot)
+	insertAircraft(r, 3, "MiG-29S", coalitions.Red, pointAt(origin, 180, 80*unit.NauticalMile), 200*unit.Foot)
+
+	for id, expected := range map[uint64]bool{1: true, 2: true, 3: false} {
+		tf, ok := r.contacts.getByID(id)
+		require.True(t, ok)
+		assert.Equal(t, expected, r.isDetected(tf), id)
+	}
+
+	// A friendly EWR in the telemetry detects the low contact.
+	insertSurface(r, 4, "55G6 EWR", trackfiles.AirDefense, coalitions.Blue, pointAt(origin, 180, 70*unit.NauticalMile))
+	r.resetSensors()
+	tf, ok := r.contacts.getByID(3)
+	require.True(t, ok)
+	assert.True(t, r.isDetected(tf))
+}
+
+func TestSensorModelHidesUndetectedPopUpsAndLeakers(t *testing.T) {
+	t.Parallel()
+	r := newTestRadarWithContacts()
+	r.SetSensorModel(NewSensorModel(nil, nil))
+	now := time.Now()
+	r.SetMissionTime(now)
+	r.SetBullseye(orb.Point{30.0, 40.0}, coalitions.Blue)
+	origin := orb.Point{30.0, 40.0}
+	insertAircraft(r, 1, "F-15C", coalitions.Blue, origin, 20000*unit.Foot)
+	insertAircraft(r, 2, "MiG-29S", coalitions.Red, pointAt(origin, 90, 10*unit.NauticalMile), 200*unit.Foot)
+	r.popUps.observe(1, now.Add(-10*time.Minute))
+	r.popUps.observe(2, now.Add(-10*time.Second))
+	line := orb.LineString{{30.2, 39.5}, {30.2, 40.5}}
+	previous := map[uint64]orb.Point{2: {30.1, 40.0}}
+
+	// Without a friendly emitter, the hostile contact is neither a pop-up nor a leaker.
+	assert.Empty(t, r.PopUps(coalitions.Red, 30*unit.NauticalMile))
+	assert.Empty(t, r.Leakers(coalitions.Red, line, previous))
+	assert.Nil(t, r.FindGroupWithBRAA(origin, []uint64{2}))
+
+	// An AWACS within range detects the hostile contact.
+	insertAircraft(r, 3, "E-3A", coalitions.Blue, pointAt(origin, 270, 50*unit.NauticalMile), 30000*unit.Foot)
+	r.forgetDetection(2)
+	assert.Len(t, r.PopUps(coalitions.Red, 30*unit.NauticalMile), 1)
+	assert.Len(t, r.Leakers(coalitions.Red, line, previous), 1)
+	assert.NotNil(t, r.FindGroupWithBRAA(origin, []uint64{2}))
+}
@@ -0,0 +1,49 @@
+package radar
+
+import (
+	"slices"
+
+	"github.com/dharmab/collections/sets"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+)
+
+// GroupChanges updates the labels of the given coalition's groups, and returns the groups which have split or joined
+// since the previous call. It should be called once per controller tick; other queries do not change labels.
+//
+// The first return value maps the label of each group which split to the groups it split into, including the
+// remainder of the original group. The second return value maps each group which other groups joined to the labels of
+// the groups which joined it. Each group has Bullseye set relative to the point provided in SetBullseye.
+func (r *Radar) GroupChanges(coalition coalitions.Coalition) (map[string][]brevity.Group, map[brevity.Group][]string) {
+	groups := r.enumerateGroups(coalition)
+	splitEvents, joinEvents := r.updateLabels(groups)
+	byLabel := make(map[string]*group)
+	for _, grp := range groups {
+		if grp.label != "" {
+			byLabel[grp.label] = grp
+		}
+	}
+
+	splits := make(map[string][]brevity.Group)
+	for original, labels := range splitEvents {
+		groups := make([]brevity.Group, 0)
+		for _, label := range append([]string{original}, slices.Sorted(sets.All(labels))...) {
+			if grp, ok := byLabel[label]; ok {
+				groups = append(groups, grp)
+			}
+		}
+		if len(groups) > 1 {
+			splits[original] = groups
+		}
+	}
+
+	joins := make(map[brevity.Group][]string)
+	for survivor, labels := range joinEvents {
+		grp, ok := byLabel[survivor]
+		if !ok {
+			continue
+		}
+		joins[grp] = slices.Sorted(sets.All(labels))
+	}
+	return splits, joins
+}
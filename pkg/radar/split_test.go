@@ -0,0 +1,92 @@
+package radar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func moveContact(t *testing.T, r *Radar, id uint64, point orb.Point) {
+	t.Helper()
+	tf, ok := r.contacts.getByID(id)
+	require.True(t, ok)
+	frame := tf.LastKnown()
+	frame.Time = frame.Time.Add(time.Second)
+	frame.Point = point
+	tf.Update(frame)
+}
+
+func TestGroupChanges(t *testing.T) {
+	t.Parallel()
+	r := newTestRadarWithContacts()
+	r.SetMissionTime(time.Now())
+	r.SetBullseye(orb.Point{30.0, 40.0}, coalitions.Blue)
+	insertTanker(t, r, 1, "Flanker 1", "Su-27", coalitions.Red, orb.Point{30.1, 40.1})
+	insertTanker(t, r, 2, "Flanker 2", "Su-27", coalitions.Red, orb.Point{30.11, 40.1})
+
+	splits, joins := r.GroupChanges(coalitions.Red)
+	assert.Empty(t, splits)
+	assert.Empty(t, joins)
+
+	moveContact(t, r, 2, orb.Point{30.6, 40.1})
+	splits, joins = r.GroupChanges(coalitions.Red)
+	assert.Empty(t, joins)
+	require.Len(t, splits, 1)
+	groups, ok := splits["Alpha"]
+	require.True(t, ok)
+	require.Len(t, groups, 2)
+	assert.Equal(t, "Alpha", groups[0].Label())
+	assert.Equal(t, []uint64{1}, groups[0].ObjectIDs())
+	assert.Equal(t, "Bravo", groups[1].Label())
+	assert.Equal(t, []uint64{2}, groups[1].ObjectIDs())
+	assert.NotNil(t, groups[1].Bullseye())
+
+	moveContact(t, r, 2, orb.Point{30.11, 40.1})
+	splits, joins = r.GroupChanges(coalitions.Red)
+	assert.Empty(t, splits)
+	require.Len(t, joins, 1)
+	for grp, labels := range joins {
+		assert.Equal(t, "Alpha", grp.Label())
+		assert.Equal(t, []string{"Bravo"}, labels)
+		assert.Equal(t, 2, grp.Contacts())
+	}
+}
+
+func TestGroupChangesIgnoresQueries(t *testing.T) {
+	t.Parallel()
+	r := newTestRadarWithContacts()
+	r.SetMissionTime(time.Now())
+	r.SetBullseye(orb.Point{30.0, 40.0}, coalitions.Blue)
+	insertTanker(t, r, 1, "Flanker 1", "Su-27", coalitions.Red, orb.Point{30.1, 40.1})
+	insertTanker(t, r, 2, "Flanker 2", "Su-27", coalitions.Red, orb.Point{30.11, 40.1})
+	insertTanker(t, r, 3, "Flanker 3", "Su-27", coalitions.Red, orb.Point{30.12, 40.1})
+	r.GroupChanges(coalitions.Red)
+
+	// Contact 3 splits off. Queries before the next update see the previous labels and do not record any changes.
+	moveContact(t, r, 3, orb.Point{30.6, 40.1})
+	for _, id := range []uint64{3, 2, 1, 3} {
+		tf, ok := r.contacts.getByID(id)
+		require.True(t, ok)
+		assert.Equal(t, "Alpha", r.findGroupForAircraft(tf).Label())
+	}
+
+	splits, joins := r.GroupChanges(coalitions.Red)
+	assert.Empty(t, joins)
+	require.Len(t, splits, 1)
+	require.Len(t, splits["Alpha"], 2)
+	assert.Equal(t, []uint64{1, 2}, splits["Alpha"][0].ObjectIDs())
+	assert.Equal(t, "Bravo", splits["Alpha"][1].Label())
+
+	for _, id := range []uint64{1, 2, 3} {
+		tf, ok := r.contacts.getByID(id)
+		require.True(t, ok)
+		r.findGroupForAircraft(tf)
+	}
+	splits, joins = r.GroupChanges(coalitions.Red)
+	assert.Empty(t, splits, "a split is reported once")
+	assert.Empty(t, joins)
+}
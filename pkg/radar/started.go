@@ -3,6 +3,7 @@ package radar
 import (
 	"time"
 
+	"github.com/dharmab/collections/sets"
 	"github.com/dharmab/skyeye/pkg/sim"
 	"github.com/rs/zerolog/log"
 )
@@ -10,11 +11,16 @@ import (
 func (r *Radar) handleStarted() {
 	log.Info().Msg("clearing all trackfiles due to mission (re)start")
 	r.contacts.reset()
+	r.surface.reset()
+	r.popUps.reset()
+	r.labels.reset()
+	r.resetSensors()
 
 	log.Info().Msg("clearing pending FADED trackfiles due to mission (re)start")
 	r.pendingFadesLock.Lock()
 	defer r.pendingFadesLock.Unlock()
 	r.pendingFades = make([]sim.Faded, 0)
+	r.pendingDestroys = sets.New[uint64]()
 
 	log.Info().Msg("clearing FADED trackfile history due to mission (re)start")
 	r.completedFadesLock.Lock()
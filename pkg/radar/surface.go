@@ -0,0 +1,111 @@
+package radar
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/dharmab/collections/sets"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+)
+
+// surfaceGroupSpread is the maximum distance between surface contacts of the same category for them to be grouped
+// together. This is large enough to group the launchers and radars of a SAM site, or a ship with its escorts in
+// close formation.
+const surfaceGroupSpread = 1 * unit.NauticalMile
+
+// FindNearbySurfaceGroups returns groups of sea and ground contacts of the given coalition within the given radius of
+// the given origin. Each group has its vector set relative to the origin. The groups are ordered by increasing range.
+func (r *Radar) FindNearbySurfaceGroups(origin orb.Point, radius unit.Length, coalition coalitions.Coalition) []brevity.SurfaceGroup {
+	candidates := make([]*trackfiles.Trackfile, 0)
+	for trackfile := range r.surface.values() {
+		if trackfile.Contact.Coalition != coalition || trackfile.IsLastKnownPointZero() {
+			continue
+		}
+		if spatial.Distance(origin, trackfile.LastKnown().Point, r.withProjection()) > radius {
+			continue
+		}
+		candidates = append(candidates, trackfile)
+	}
+
+	type surfaceGroup struct {
+		group    brevity.SurfaceGroup
+		distance unit.Length
+	}
+	groups := make([]surfaceGroup, 0)
+	visited := sets.New[uint64]()
+	for _, trackfile := range candidates {
+		if sets.Contains(visited, trackfile.Contact.ID) {
+			continue
+		}
+		members := r.collectSurfaceGroup(trackfile, candidates, visited)
+		center := surfaceGroupCenter(members)
+		declination := r.Declination(origin)
+		bearing := spatial.TrueBearing(origin, center, r.withProjection()).Magnetic(declination)
+		distance := spatial.Distance(origin, center, r.withProjection())
+		groups = append(groups, surfaceGroup{
+			group: brevity.SurfaceGroup{
+				Category: surfaceCategory(trackfile.Contact.Class),
+				Contacts: len(members),
+				Vector:   brevity.NewVector(bearing, distance),
+			},
+			distance: distance,
+		})
+	}
+
+	slices.SortFunc(groups, func(a, b surfaceGroup) int {
+		return cmp.Compare(a.distance, b.distance)
+	})
+	result := make([]brevity.SurfaceGroup, 0, len(groups))
+	for _, grp := range groups {
+		result = append(result, grp.group)
+	}
+	return result
+}
+
+// collectSurfaceGroup returns the given trackfile and all candidates of the same class which are transitively within
+// surfaceGroupSpread of it. The IDs of the returned trackfiles are added to visited.
+func (r *Radar) collectSurfaceGroup(trackfile *trackfiles.Trackfile, candidates []*trackfiles.Trackfile, visited sets.Set[uint64]) []*trackfiles.Trackfile {
+	sets.Add(visited, trackfile.Contact.ID)
+	members := []*trackfiles.Trackfile{trackfile}
+	for i := 0; i < len(members); i++ {
+		this := members[i]
+		for _, other := range candidates {
+			if sets.Contains(visited, other.Contact.ID) || other.Contact.Class != this.Contact.Class {
+				continue
+			}
+			if spatial.Distance(this.LastKnown().Point, other.LastKnown().Point, r.withProjection()) < surfaceGroupSpread {
+				sets.Add(visited, other.Contact.ID)
+				members = append(members, other)
+			}
+		}
+	}
+	return members
+}
+
+// surfaceGroupCenter returns the mean position of the given trackfiles.
+func surfaceGroupCenter(members []*trackfiles.Trackfile) orb.Point {
+	var lon, lat float64
+	for _, trackfile := range members {
+		point := trackfile.LastKnown().Point
+		lon += point.Lon()
+		lat += point.Lat()
+	}
+	n := float64(len(members))
+	return orb.Point{lon / n, lat / n}
+}
+
+func surfaceCategory(class trackfiles.Class) brevity.SurfaceCategory {
+	switch class {
+	case trackfiles.Watercraft:
+		return brevity.Ships
+	case trackfiles.AirDefense:
+		return brevity.AirDefenses
+	default:
+		return brevity.Vehicles
+	}
+}
@@ -0,0 +1,82 @@
+package radar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func insertSurface(r *Radar, id uint64, acmiName string, class trackfiles.Class, coalition coalitions.Coalition, point orb.Point) {
+	tf := trackfiles.New(trackfiles.Labels{
+		ID:        id,
+		Name:      acmiName,
+		Coalition: coalition,
+		ACMIName:  acmiName,
+		Class:     class,
+	})
+	tf.Update(trackfiles.Frame{
+		Time:  time.Now(),
+		Point: point,
+	})
+	r.surface.set(tf)
+}
+
+func TestFindNearbySurfaceGroups(t *testing.T) {
+	t.Parallel()
+	r := newTestRadarWithContacts()
+	origin := orb.Point{30.0, 40.0}
+	// A SAM site with three units, about 25 NM east.
+	insertSurface(r, 1, "SA-11 Buk LN 9A310M1", trackfiles.AirDefense, coalitions.Red, orb.Point{30.55, 40.0})
+	insertSurface(r, 2, "SA-11 Buk LN 9A310M1", trackfiles.AirDefense, coalitions.Red, orb.Point{30.555, 40.0})
+	insertSurface(r, 3, "SA-11 Buk SR 9S18M1", trackfiles.AirDefense, coalitions.Red, orb.Point{30.55, 40.005})
+	// Armor co-located with the SAM site is reported separately.
+	insertSurface(r, 4, "T-72B", trackfiles.GroundVehicle, coalitions.Red, orb.Point{30.552, 40.002})
+	// A ship about 10 NM north.
+	insertSurface(r, 5, "MOSCOW", trackfiles.Watercraft, coalitions.Red, orb.Point{30.0, 40.17})
+	// Out of range.
+	insertSurface(r, 6, "MOSCOW", trackfiles.Watercraft, coalitions.Red, orb.Point{35.0, 40.0})
+	// Friendly.
+	insertSurface(r, 7, "CVN_71", trackfiles.Watercraft, coalitions.Blue, orb.Point{30.0, 40.1})
+
+	groups := r.FindNearbySurfaceGroups(origin, 100*unit.NauticalMile, coalitions.Red)
+	require.Len(t, groups, 3)
+
+	assert.Equal(t, brevity.Ships, groups[0].Category)
+	assert.Equal(t, 1, groups[0].Contacts)
+	assert.InDelta(t, 10, groups[0].Vector.Range().NauticalMiles(), 1)
+	assert.True(t, groups[0].Vector.Bearing().IsMagnetic())
+
+	categories := []brevity.SurfaceCategory{groups[1].Category, groups[2].Category}
+	assert.ElementsMatch(t, []brevity.SurfaceCategory{brevity.AirDefenses, brevity.Vehicles}, categories)
+	for _, grp := range groups[1:] {
+		if grp.Category == brevity.AirDefenses {
+			assert.Equal(t, 3, grp.Contacts)
+		} else {
+			assert.Equal(t, 1, grp.Contacts)
+		}
+		assert.InDelta(t, 25, grp.Vector.Range().NauticalMiles(), 1)
+	}
+}
+
+func TestHandleUpdateSeparatesSurfaceContacts(t *testing.T) {
+	t.Parallel()
+	r := newTestRadarWithContacts()
+	labels := trackfiles.Labels{ID: 1, Name: "CVN_71", ACMIName: "CVN_71", Coalition: coalitions.Blue, Class: trackfiles.Watercraft}
+	r.handleUpdate(sim.Updated{Labels: labels, Frame: trackfiles.Frame{Time: time.Now(), Point: orb.Point{30.0, 40.0}}})
+
+	assert.Nil(t, r.FindUnit(1))
+	_, ok := r.surface.getByID(1)
+	assert.True(t, ok)
+
+	r.handleStarted()
+	_, ok = r.surface.getByID(1)
+	assert.False(t, ok)
+}
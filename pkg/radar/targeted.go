@@ -0,0 +1,42 @@
+package radar
+
+import (
+	"github.com/dharmab/collections/sets"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/paulmach/orb"
+)
+
+// FindGroupWithBRAA returns the group which contains the most of the given unit IDs, so that a group can be followed
+// as contacts join or leave it. The group has BRAA set relative to the given origin. Returns nil if none of the units
+// are on the scope.
+func (r *Radar) FindGroupWithBRAA(origin orb.Point, ids []uint64) brevity.Group {
+	wanted := sets.Of(ids...)
+	visited := sets.New[uint64]()
+	var best *group
+	bestCount := 0
+	for _, id := range ids {
+		if sets.Contains(visited, id) {
+			continue
+		}
+		trackfile, ok := r.contacts.getByID(id)
+		if !ok || !isValidTrack(trackfile) || !r.isDetected(trackfile) {
+			continue
+		}
+		grp := r.findGroupForAircraft(trackfile)
+		count := 0
+		for _, member := range grp.ObjectIDs() {
+			sets.Add(visited, member)
+			if sets.Contains(wanted, member) {
+				count++
+			}
+		}
+		if count > bestCount {
+			best, bestCount = grp, count
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	r.setGroupBRAA(best, origin)
+	return best
+}
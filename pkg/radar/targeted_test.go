@@ -0,0 +1,43 @@
+package radar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindGroupWithBRAA(t *testing.T) {
+	t.Parallel()
+	r := newTestRadarWithContacts()
+	r.SetMissionTime(time.Now())
+	r.SetBullseye(orb.Point{30.0, 40.0}, coalitions.Blue)
+	insertTanker(t, r, 1, "Flanker 1", "Su-27", coalitions.Red, orb.Point{30.5, 40.0})
+	insertTanker(t, r, 2, "Flanker 2", "Su-27", coalitions.Red, orb.Point{30.51, 40.0})
+	insertTanker(t, r, 3, "Flanker 3", "Su-27", coalitions.Red, orb.Point{31.5, 40.0})
+	origin := orb.Point{30.0, 40.0}
+
+	grp := r.FindGroupWithBRAA(origin, []uint64{1, 2})
+	require.NotNil(t, grp)
+	assert.ElementsMatch(t, []uint64{1, 2}, grp.ObjectIDs())
+	require.NotNil(t, grp.BRAA())
+	assert.Nil(t, grp.Bullseye())
+	assert.InDelta(t, 23, grp.BRAA().Range().NauticalMiles(), 1)
+
+	// A contact which joins the group is followed with the group.
+	moveContact(t, r, 3, orb.Point{30.52, 40.0})
+	grp = r.FindGroupWithBRAA(origin, []uint64{1, 2})
+	require.NotNil(t, grp)
+	assert.ElementsMatch(t, []uint64{1, 2, 3}, grp.ObjectIDs())
+
+	// When the group splits, the part containing most of the given contacts is returned.
+	moveContact(t, r, 1, orb.Point{31.5, 40.0})
+	grp = r.FindGroupWithBRAA(origin, []uint64{1, 2, 3})
+	require.NotNil(t, grp)
+	assert.ElementsMatch(t, []uint64{2, 3}, grp.ObjectIDs())
+
+	assert.Nil(t, r.FindGroupWithBRAA(origin, []uint64{4}))
+}
@@ -74,7 +74,7 @@ func (r *Radar) Threats(coalition coalitions.Coalition) map[brevity.Group][]uint
 		//   - tightly-grouped receivers → BRAA from the geographic midpoint, usable by all of them.
 		//   - otherwise                 → bullseye (default from enumerateGroups).
 		if len(receivers) == 1 {
-			r.setGroupBRAA(grp, receivers[0].LastKnown().Point)
+			r.setGroupBRAA(grp, r.Position(receivers[0]))
 			continue
 		}
 		if origin, ok := r.getGroupBRAAOrigin(grp, receivers); ok {
@@ -22,3 +22,21 @@ type Faded struct {
 	// ID of the aircraft that disappeared.
 	ID uint64
 }
+
+// Destroyed is a message sent when an aircraft is destroyed.
+type Destroyed struct {
+	// ID of the aircraft that was destroyed.
+	ID uint64
+}
+
+// Launched is a message sent when a missile is launched.
+type Launched struct {
+	// Labels contains the missile's identity.
+	Labels trackfiles.Labels
+	// Frame contains the missile's observed position data at launch.
+	Frame trackfiles.Frame
+	// LauncherID is the ID of the platform which launched the missile, or 0 if unknown.
+	LauncherID uint64
+	// TargetID is the ID of the missile's target, or 0 if unknown.
+	TargetID uint64
+}
@@ -26,6 +26,13 @@ type Transmission struct {
 	ClientName string
 	// Audio sample for the transmission.
 	Audio Audio
+	// Overlapped is true if another client transmitted on the same frequency while this transmission was in progress.
+	// Players on the frequency heard both transmissions at once, so they may not have heard this one clearly.
+	Overlapped bool
+	// Frequencies of the transmission. For a received transmission, this is the frequency it was received on. For an
+	// outgoing transmission, this selects which of the client's radios transmit the audio. If empty, or if none of the
+	// client's radios are on any of the frequencies, the audio is transmitted on all of the client's radios.
+	Frequencies []RadioFrequency
 }
 
 // Client is a SimpleRadio-Standalone Client.
@@ -43,6 +50,8 @@ type Client struct {
 	// clientInfo is the client information for this client. It is what players will see in the SRS client list, and in
 	/// the in-game overlay when this client transmits.
 	clientInfo types.ClientInfo
+	// positionLock protects clientInfo.Position, which is updated while the client is running.
+	positionLock sync.RWMutex
 	// clients is a map of GUIDs to client info, which the bot will use to filter out other clients that are not in the
 	// same coalition and frequency.
 	clients map[types.GUID]types.ClientInfo
@@ -67,6 +76,8 @@ type Client struct {
 	txLock sync.Mutex
 	// mute suppresses audio transmission.
 	mute bool
+	// stats counts received voice packets and lost frames.
+	stats receiveStats
 
 	// lastPing tracks the last time a ping was received. If no pings are received for a period of time, the client will
 	// attempt to reconnect.
@@ -80,7 +91,7 @@ func NewClient(config types.ClientConfiguration) (*Client, error) {
 
 	receivers := make(map[types.Radio]*receiver, len(config.Radios))
 	for _, radio := range config.Radios {
-		receivers[radio] = &receiver{}
+		receivers[radio] = newReceiver(radio)
 	}
 
 	client := &Client{
@@ -194,7 +205,7 @@ func (c *Client) Run(ctx context.Context, wg *sync.WaitGroup) error {
 	wg.Go(func() { c.receivePings(ctx, udpPingRxChan) })
 
 	udpVoiceRxChan := make(chan []byte, maxRxPackets)
-	voiceBytesRxChan := make(chan []voice.Packet, 128)
+	voiceBytesRxChan := make(chan *stream, 128)
 	wg.Go(func() { c.receiveVoice(ctx, udpVoiceRxChan, voiceBytesRxChan) })
 	wg.Go(func() { c.decodeVoice(ctx, voiceBytesRxChan) })
 
@@ -0,0 +1,296 @@
+package simpleradio
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/pcm/rate"
+	"github.com/dharmab/skyeye/pkg/simpleradio/simpleradiotest"
+	"github.com/dharmab/skyeye/pkg/simpleradio/types"
+	"github.com/dharmab/skyeye/pkg/simpleradio/voice"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/hraban/opus.v2"
+)
+
+// newTestServer starts an SRS test server which is closed when the test ends.
+func newTestServer(t *testing.T, opts ...simpleradiotest.Option) *simpleradiotest.Server {
+	t.Helper()
+	server, err := simpleradiotest.NewServer(opts...)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, server.Close())
+	})
+	return server
+}
+
+// runTestClient runs a client connected to the given server until the test ends. The client is stopped before the
+// server is closed.
+// If no radios are given, the client listens on testRadio.
+func runTestClient(t *testing.T, server *simpleradiotest.Server, name string, coalition coalitions.Coalition, password string, radios ...types.Radio) *Client {
+	t.Helper()
+	if len(radios) == 0 {
+		radios = []types.Radio{testRadio}
+	}
+	client, err := NewClient(types.ClientConfiguration{
+		Address:                   server.Address(),
+		ClientName:                name,
+		ExternalAWACSModePassword: password,
+		Coalition:                 coalition,
+		Radios:                    radios,
+	})
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(t.Context())
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		assert.NoError(t, client.Run(ctx, &wg))
+	})
+	t.Cleanup(func() {
+		cancel()
+		wg.Wait()
+	})
+	return client
+}
+
+func TestClientSyncsWithServer(t *testing.T) {
+	t.Parallel()
+	human := testPeer("peer000000000000000001", "Eagle 1", coalitions.Blue, testRadio)
+	server := newTestServer(
+		t,
+		simpleradiotest.WithExternalAWACSModePasswords("blue", "red"),
+		simpleradiotest.WithClients(
+			human,
+			testPeer("peer000000000000000002", "Eagle 2", coalitions.Blue, testOtherRadio),
+			testPeer("peer000000000000000003", "Bandit 1", coalitions.Red, testRadio),
+			testPeer("peer000000000000000004", "Tanker [BOT]", coalitions.Blue, testRadio),
+		),
+	)
+	client := runTestClient(t, server, "GCI Test [BOT]", coalitions.Blue, "blue")
+
+	require.Eventually(t, func() bool { return server.IsAuthenticated("GCI Test [BOT]") }, 5*time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool { return client.HumansOnFrequency() == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 1, client.BotsOnFrequency())
+	assert.True(t, client.IsOnFrequency("Eagle 1"))
+	assert.False(t, client.IsOnFrequency("Eagle 2"))
+
+	// A human which leaves the server is no longer counted.
+	server.SetClients(testPeer("peer000000000000000004", "Tanker [BOT]", coalitions.Blue, testRadio))
+	require.Eventually(t, func() bool { return client.HumansOnFrequency() == 0 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 1, client.BotsOnFrequency())
+
+	// A connected client is counted like any other peer.
+	runTestClient(t, server, "Viper 1", coalitions.Blue, "blue")
+	require.Eventually(t, func() bool { return client.HumansOnFrequency() == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.True(t, client.IsOnFrequency("Viper 1"))
+}
+
+func TestClientExternalAWACSModePassword(t *testing.T) {
+	t.Parallel()
+	server := newTestServer(t, simpleradiotest.WithExternalAWACSModePasswords("blue", "red"))
+	runTestClient(t, server, "GCI Red [BOT]", coalitions.Red, "red")
+	runTestClient(t, server, "GCI Intruder [BOT]", coalitions.Blue, "wrong")
+
+	require.Eventually(t, func() bool { return server.IsAuthenticated("GCI Red [BOT]") }, 5*time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool { return server.Rejected() == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.False(t, server.IsAuthenticated("GCI Intruder [BOT]"))
+}
+
+func TestClientServerSettings(t *testing.T) {
+	t.Parallel()
+	server := newTestServer(
+		t,
+		simpleradiotest.WithExternalAWACSModePasswords("blue", "red"),
+		simpleradiotest.WithServerSetting(types.CoalitionAudioSecurity, "true"),
+	)
+	client := runTestClient(t, server, "GCI Test [BOT]", coalitions.Blue, "blue")
+	require.Eventually(t, client.secureCoalitionRadios.Load, 5*time.Second, 10*time.Millisecond)
+
+	server.SetServerSetting(types.CoalitionAudioSecurity, "false")
+	require.Eventually(t, func() bool { return !client.secureCoalitionRadios.Load() }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestClientTransmitAndReceive(t *testing.T) {
+	t.Parallel()
+	server := newTestServer(t, simpleradiotest.WithExternalAWACSModePasswords("blue", "red"))
+	transmitter := runTestClient(t, server, "GCI Transmitter [BOT]", coalitions.Blue, "blue")
+	receiver := runTestClient(t, server, "GCI Receiver [BOT]", coalitions.Blue, "blue")
+	require.Eventually(t, func() bool {
+		return transmitter.IsOnFrequency("GCI Receiver [BOT]") && receiver.IsOnFrequency("GCI Transmitter [BOT]")
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool {
+		return server.IsAuthenticated("GCI Transmitter [BOT]") && server.IsAuthenticated("GCI Receiver [BOT]")
+	}, 5*time.Second, 10*time.Millisecond)
+	// Wait for the UDP pings which register each client's voice address with the server.
+	require.Eventually(t, func() bool { return server.VoiceClients() == 2 }, 5*time.Second, 10*time.Millisecond)
+
+	audio := testTone(int(frameSize) * 40)
+	transmitter.Transmit(Transmission{TraceID: "test", Audio: audio})
+
+	select {
+	case transmission := <-receiver.Receive():
+		assert.Equal(t, "GCI Transmitter [BOT]", transmission.ClientName)
+		assert.NotEmpty(t, transmission.Audio)
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "timed out waiting for transmission")
+	}
+	assert.Len(t, server.Packets(), 40)
+	assert.Equal(t, uint64(40), receiver.ReceiveStats().Received)
+}
+
+func TestClientReceivesFromUnauthenticatedClient(t *testing.T) {
+	t.Parallel()
+	server := newTestServer(t, simpleradiotest.WithExternalAWACSModePasswords("blue", "red"))
+	gci := runTestClient(t, server, "GCI Test [BOT]", coalitions.Blue, "blue")
+	// A player's client joins a coalition through the game, not through External AWACS Mode.
+	player := runTestClient(t, server, "Eagle 1", coalitions.Blue, "")
+	require.Eventually(t, func() bool { return server.IsAuthenticated("GCI Test [BOT]") }, 5*time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool { return server.VoiceClients() == 2 }, 5*time.Second, 10*time.Millisecond)
+	assert.False(t, server.IsAuthenticated("Eagle 1"))
+
+	player.Transmit(Transmission{TraceID: "test", Audio: testTone(int(frameSize) * 40)})
+	select {
+	case transmission := <-gci.Receive():
+		assert.Equal(t, "Eagle 1", transmission.ClientName)
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "timed out waiting for transmission")
+	}
+	assert.Len(t, server.Packets(), 40)
+}
+
+func TestClientReceivesOutOfOrderPackets(t *testing.T) {
+	t.Parallel()
+	peer := testPeer("peer000000000000000001", "Eagle 1", coalitions.Blue, testRadio)
+	server := newTestServer(t, simpleradiotest.WithExternalAWACSModePasswords("blue", "red"), simpleradiotest.WithClients(peer))
+	client := runTestClient(t, server, "GCI Test [BOT]", coalitions.Blue, "blue")
+	require.Eventually(t, func() bool { return server.IsAuthenticated("GCI Test [BOT]") }, 5*time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool { return server.VoiceClients() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	encoder, err := opus.NewEncoder(int(rate.Wideband.Hertz()), channels, opusApplicationVoIP)
+	require.NoError(t, err)
+	frequencies := []voice.Frequency{{Frequency: testRadio.Frequency, Modulation: byte(testRadio.Modulation)}}
+	packets := make([]voice.Packet, 0)
+	for id := uint64(1); id <= 40; id++ {
+		// Packets 20 and 30 are lost.
+		if id == 20 || id == 30 {
+			continue
+		}
+		audio, err := encodeFrame(encoder, testTone(int(frameSize)))
+		require.NoError(t, err)
+		packets = append(packets, voice.NewPacket(audio, frequencies, 0, id, 0, []byte(peer.GUID), []byte(peer.GUID)))
+	}
+	// Packets 11 and 12 are swapped, and packet 25 is delivered twice.
+	packets[10], packets[11] = packets[11], packets[10]
+	packets = append(packets, packets[23])
+	server.Transmit(peer, packets...)
+
+	select {
+	case transmission := <-client.Receive():
+		assert.Equal(t, "Eagle 1", transmission.ClientName)
+		assert.Len(t, transmission.Audio, 40*int(frameSize))
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "timed out waiting for transmission")
+	}
+	stats := client.ReceiveStats()
+	assert.Equal(t, uint64(38), stats.Received)
+	assert.Equal(t, uint64(1), stats.Reordered)
+	assert.Equal(t, uint64(1), stats.Discarded)
+	assert.Equal(t, uint64(2), stats.Lost)
+	assert.Equal(t, uint64(2), stats.Recovered)
+}
+
+func TestClientReceivesOverlappingTransmissions(t *testing.T) {
+	t.Parallel()
+	first := testPeer("peer000000000000000001", "Eagle 1", coalitions.Blue, testRadio)
+	second := testPeer("peer000000000000000002", "Viper 1", coalitions.Blue, testRadio)
+	server := newTestServer(t, simpleradiotest.WithExternalAWACSModePasswords("blue", "red"), simpleradiotest.WithClients(first, second))
+	client := runTestClient(t, server, "GCI Test [BOT]", coalitions.Blue, "blue")
+	require.Eventually(t, func() bool { return server.IsAuthenticated("GCI Test [BOT]") }, 5*time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool { return server.VoiceClients() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	encoder, err := opus.NewEncoder(int(rate.Wideband.Hertz()), channels, opusApplicationVoIP)
+	require.NoError(t, err)
+	frequencies := []voice.Frequency{{Frequency: testRadio.Frequency, Modulation: byte(testRadio.Modulation)}}
+	newPackets := func(origin types.GUID, count int) []voice.Packet {
+		packets := make([]voice.Packet, 0, count)
+		for id := range uint64(count) {
+			audio, err := encodeFrame(encoder, testTone(int(frameSize)))
+			require.NoError(t, err)
+			packets = append(packets, voice.NewPacket(audio, frequencies, 0, id+1, 0, []byte(origin), []byte(origin)))
+		}
+		return packets
+	}
+	// Both clients key up at the same time.
+	server.Transmit(first, newPackets(first.GUID, 40)...)
+	server.Transmit(second, newPackets(second.GUID, 30)...)
+
+	received := make(map[string]Transmission)
+	for range 2 {
+		select {
+		case transmission := <-client.Receive():
+			received[transmission.ClientName] = transmission
+		case <-time.After(10 * time.Second):
+			require.Fail(t, "timed out waiting for transmission")
+		}
+	}
+	require.Contains(t, received, "Eagle 1")
+	assert.Len(t, received["Eagle 1"].Audio, 40*int(frameSize))
+	assert.True(t, received["Eagle 1"].Overlapped)
+	require.Contains(t, received, "Viper 1")
+	assert.Len(t, received["Viper 1"].Audio, 30*int(frameSize))
+	assert.True(t, received["Viper 1"].Overlapped)
+}
+
+func TestClientRepliesOnReceivedFrequency(t *testing.T) {
+	t.Parallel()
+	server := newTestServer(t, simpleradiotest.WithExternalAWACSModePasswords("blue", "red"))
+	gci := runTestClient(t, server, "GCI Test [BOT]", coalitions.Blue, "blue", testRadio, testOtherRadio)
+	uhf := runTestClient(t, server, "Eagle 1", coalitions.Blue, "blue", testRadio)
+	vhf := runTestClient(t, server, "Viper 1", coalitions.Blue, "blue", testOtherRadio)
+	require.Eventually(t, func() bool {
+		return server.IsAuthenticated("GCI Test [BOT]") && server.IsAuthenticated("Eagle 1") && server.IsAuthenticated("Viper 1")
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool {
+		return gci.IsOnFrequency("Eagle 1") && gci.IsOnFrequency("Viper 1")
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool { return server.VoiceClients() == 3 }, 5*time.Second, 10*time.Millisecond)
+
+	// A request on the UHF radio records the frequency it was received on.
+	uhf.Transmit(Transmission{TraceID: "request", Audio: testTone(int(frameSize) * 60)})
+	var request Transmission
+	select {
+	case request = <-gci.Receive():
+		assert.Equal(t, "Eagle 1", request.ClientName)
+		require.Len(t, request.Frequencies, 1)
+		assert.True(t, request.Frequencies[0].IsSameFrequency(NewRadioFrequency(testRadio)))
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "timed out waiting for request")
+	}
+
+	// The reply is only heard on the UHF radio.
+	gci.Transmit(Transmission{TraceID: "reply", Audio: testTone(int(frameSize) * 60), Frequencies: request.Frequencies})
+	select {
+	case reply := <-uhf.Receive():
+		assert.Equal(t, "GCI Test [BOT]", reply.ClientName)
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "timed out waiting for reply")
+	}
+	select {
+	case transmission := <-vhf.Receive():
+		assert.Fail(t, "reply heard on another frequency", "client: %s", transmission.ClientName)
+	case <-time.After(time.Second):
+	}
+
+	// A broadcast is heard on every radio.
+	gci.Transmit(Transmission{TraceID: "broadcast", Audio: testTone(int(frameSize) * 60)})
+	for _, client := range []*Client{uhf, vhf} {
+		select {
+		case broadcast := <-client.Receive():
+			assert.Equal(t, "GCI Test [BOT]", broadcast.ClientName)
+		case <-time.After(10 * time.Second):
+			require.Fail(t, "timed out waiting for broadcast")
+		}
+	}
+}
@@ -32,6 +32,25 @@ func decodeFrame(decoder *opus.Decoder, b []byte) ([]float32, error) {
 	return f32le, nil
 }
 
+// recoverFrame rebuilds a lost frame from the forward error correction data carried in the frame which followed it. If
+// the following frame carries no FEC data, Opus falls back to packet loss concealment.
+func recoverFrame(decoder *opus.Decoder, next []byte) ([]float32, error) {
+	f32le := make([]float32, frameSize)
+	if err := decoder.DecodeFECFloat32(next, f32le); err != nil {
+		return nil, fmt.Errorf("failed to recover Opus audio: %w", err)
+	}
+	return f32le, nil
+}
+
+// concealFrame synthesizes a replacement for a lost frame using Opus packet loss concealment.
+func concealFrame(decoder *opus.Decoder) ([]float32, error) {
+	f32le := make([]float32, frameSize)
+	if err := decoder.DecodePLCFloat32(f32le); err != nil {
+		return nil, fmt.Errorf("failed to conceal lost Opus audio: %w", err)
+	}
+	return f32le, nil
+}
+
 // encodeFrame encodes the given F32LE PCM audio data into an Opus frame.
 func encodeFrame(encoder *opus.Encoder, f32le []float32) ([]byte, error) {
 	b := make([]byte, encodingBufferSize)
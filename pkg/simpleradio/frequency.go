@@ -12,10 +12,48 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+// Role is the purpose of a radio frequency.
+type Role int
+
+const (
+	// RoleTactical frequencies are used to receive requests and transmit replies, as well as all other calls.
+	RoleTactical Role = iota
+	// RoleBroadcast frequencies are used only to transmit broadcasts such as automatic PICTURE and THREAT calls.
+	// Requests received on these frequencies are ignored.
+	RoleBroadcast
+	// RoleGuard frequencies are used only to transmit emergency calls such as MISSILE and POP-UP calls. Requests
+	// received on these frequencies are ignored.
+	RoleGuard
+)
+
+// String representation of the Role.
+func (r Role) String() string {
+	switch r {
+	case RoleTactical:
+		return "tactical"
+	case RoleBroadcast:
+		return "broadcast"
+	case RoleGuard:
+		return "guard"
+	default:
+		return "unknown"
+	}
+}
+
 // RadioFrequency selects a frequency and either AM or FM modulation.
 type RadioFrequency struct {
 	Frequency  unit.Frequency
 	Modulation types.Modulation
+	// Role is the purpose of the frequency. It does not affect which frequency is selected.
+	Role Role
+}
+
+// NewRadioFrequency returns the frequency and modulation of the given radio.
+func NewRadioFrequency(radio types.Radio) RadioFrequency {
+	return RadioFrequency{
+		Frequency:  unit.Frequency(radio.Frequency) * unit.Hertz,
+		Modulation: radio.Modulation,
+	}
 }
 
 // ParseRadioFrequency parses a string into a RadioFrequency.
@@ -82,11 +120,7 @@ func (f RadioFrequency) String() string {
 func (c *Client) Frequencies() []RadioFrequency {
 	frequencies := make([]RadioFrequency, 0, len(c.clientInfo.RadioInfo.Radios))
 	for _, radio := range c.clientInfo.RadioInfo.Radios {
-		frequency := RadioFrequency{
-			Frequency:  unit.Frequency(radio.Frequency) * unit.Hertz,
-			Modulation: radio.Modulation,
-		}
-		frequencies = append(frequencies, frequency)
+		frequencies = append(frequencies, NewRadioFrequency(radio))
 	}
 	return frequencies
 }
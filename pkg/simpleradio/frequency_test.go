@@ -20,12 +20,12 @@ func TestParseFrequency(t *testing.T) {
 		{"", RadioFrequency{}, false},
 		{"0", RadioFrequency{}, false},
 		{"-1", RadioFrequency{}, false},
-		{"30FM", RadioFrequency{30 * unit.Megahertz, types.ModulationFM}, true},
-		{"30.0FM", RadioFrequency{30 * unit.Megahertz, types.ModulationFM}, true},
-		{"251.0", RadioFrequency{251 * unit.Megahertz, types.ModulationAM}, true},
-		{"251.0AM", RadioFrequency{251 * unit.Megahertz, types.ModulationAM}, true},
-		{"251.1AM", RadioFrequency{251.1 * unit.Megahertz, types.ModulationAM}, true},
-		{"251.1 AM", RadioFrequency{251.1 * unit.Megahertz, types.ModulationAM}, true},
+		{"30FM", RadioFrequency{Frequency: 30 * unit.Megahertz, Modulation: types.ModulationFM}, true},
+		{"30.0FM", RadioFrequency{Frequency: 30 * unit.Megahertz, Modulation: types.ModulationFM}, true},
+		{"251.0", RadioFrequency{Frequency: 251 * unit.Megahertz, Modulation: types.ModulationAM}, true},
+		{"251.0AM", RadioFrequency{Frequency: 251 * unit.Megahertz, Modulation: types.ModulationAM}, true},
+		{"251.1AM", RadioFrequency{Frequency: 251.1 * unit.Megahertz, Modulation: types.ModulationAM}, true},
+		{"251.1 AM", RadioFrequency{Frequency: 251.1 * unit.Megahertz, Modulation: types.ModulationAM}, true},
 		{"eekum bokum", RadioFrequency{}, false},
 		{"AM", RadioFrequency{}, false},
 		{"FM", RadioFrequency{}, false},
@@ -60,16 +60,16 @@ func TestRadioFrequencyString(t *testing.T) {
 		frequency RadioFrequency
 		expected  string
 	}{
-		{RadioFrequency{30 * unit.Megahertz, types.ModulationFM}, "30.000FM"},
-		{RadioFrequency{44.5 * unit.Megahertz, types.ModulationFM}, "44.500FM"},
-		{RadioFrequency{87.975 * unit.Megahertz, types.ModulationFM}, "87.975FM"},
-		{RadioFrequency{116 * unit.Megahertz, types.ModulationAM}, "116.000AM"},
-		{RadioFrequency{133.5 * unit.Megahertz, types.ModulationAM}, "133.500AM"},
-		{RadioFrequency{151.975 * unit.Megahertz, types.ModulationAM}, "151.975AM"},
-		{RadioFrequency{225 * unit.Megahertz, types.ModulationAM}, "225.000AM"},
-		{RadioFrequency{251 * unit.Megahertz, types.ModulationAM}, "251.000AM"},
-		{RadioFrequency{251.075 * unit.Megahertz, types.ModulationAM}, "251.075AM"},
-		{RadioFrequency{399.975 * unit.Megahertz, types.ModulationAM}, "399.975AM"},
+		{RadioFrequency{Frequency: 30 * unit.Megahertz, Modulation: types.ModulationFM}, "30.000FM"},
+		{RadioFrequency{Frequency: 44.5 * unit.Megahertz, Modulation: types.ModulationFM}, "44.500FM"},
+		{RadioFrequency{Frequency: 87.975 * unit.Megahertz, Modulation: types.ModulationFM}, "87.975FM"},
+		{RadioFrequency{Frequency: 116 * unit.Megahertz, Modulation: types.ModulationAM}, "116.000AM"},
+		{RadioFrequency{Frequency: 133.5 * unit.Megahertz, Modulation: types.ModulationAM}, "133.500AM"},
+		{RadioFrequency{Frequency: 151.975 * unit.Megahertz, Modulation: types.ModulationAM}, "151.975AM"},
+		{RadioFrequency{Frequency: 225 * unit.Megahertz, Modulation: types.ModulationAM}, "225.000AM"},
+		{RadioFrequency{Frequency: 251 * unit.Megahertz, Modulation: types.ModulationAM}, "251.000AM"},
+		{RadioFrequency{Frequency: 251.075 * unit.Megahertz, Modulation: types.ModulationAM}, "251.075AM"},
+		{RadioFrequency{Frequency: 399.975 * unit.Megahertz, Modulation: types.ModulationAM}, "399.975AM"},
 	}
 
 	for _, test := range tests {
@@ -82,11 +82,12 @@ func TestRadioFrequencyString(t *testing.T) {
 
 func TestRadioFrequencyIsSameFrequency(t *testing.T) {
 	t.Parallel()
-	uhf := RadioFrequency{251 * unit.Megahertz, types.ModulationAM}
+	uhf := RadioFrequency{Frequency: 251 * unit.Megahertz, Modulation: types.ModulationAM}
 
-	assert.True(t, uhf.IsSameFrequency(RadioFrequency{251 * unit.Megahertz, types.ModulationAM}))
-	assert.False(t, uhf.IsSameFrequency(RadioFrequency{251 * unit.Megahertz, types.ModulationFM}))
-	assert.False(t, uhf.IsSameFrequency(RadioFrequency{133 * unit.Megahertz, types.ModulationAM}))
+	assert.True(t, uhf.IsSameFrequency(RadioFrequency{Frequency: 251 * unit.Megahertz, Modulation: types.ModulationAM}))
+	assert.False(t, uhf.IsSameFrequency(RadioFrequency{Frequency: 251 * unit.Megahertz, Modulation: types.ModulationFM}))
+	assert.False(t, uhf.IsSameFrequency(RadioFrequency{Frequency: 133 * unit.Megahertz, Modulation: types.ModulationAM}))
+	assert.True(t, uhf.IsSameFrequency(RadioFrequency{Frequency: 251 * unit.Megahertz, Modulation: types.ModulationAM, Role: RoleGuard}), "role is ignored")
 }
 
 func TestClientFrequencies(t *testing.T) {
@@ -105,6 +106,55 @@ func TestClientFrequencies(t *testing.T) {
 	assert.InDelta(t, 133.0, frequencies[1].Frequency.Megahertz(), 0.001)
 }
 
+func TestClientFrequencyList(t *testing.T) {
+	t.Parallel()
+	c := &Client{
+		clientInfo: types.ClientInfo{
+			RadioInfo: types.RadioInfo{Radios: []types.Radio{testRadio, testOtherRadio}},
+		},
+	}
+	tests := []struct {
+		name        string
+		frequencies []RadioFrequency
+		expected    []float64
+	}{
+		{
+			name:     "no frequencies",
+			expected: []float64{testRadio.Frequency, testOtherRadio.Frequency},
+		},
+		{
+			name:        "one frequency",
+			frequencies: []RadioFrequency{NewRadioFrequency(testOtherRadio)},
+			expected:    []float64{testOtherRadio.Frequency},
+		},
+		{
+			name:        "both frequencies",
+			frequencies: []RadioFrequency{NewRadioFrequency(testRadio), NewRadioFrequency(testOtherRadio)},
+			expected:    []float64{testRadio.Frequency, testOtherRadio.Frequency},
+		},
+		{
+			name:        "different modulation",
+			frequencies: []RadioFrequency{{Frequency: 251 * unit.Megahertz, Modulation: types.ModulationFM}},
+			expected:    []float64{testRadio.Frequency, testOtherRadio.Frequency},
+		},
+		{
+			name:        "unknown frequency",
+			frequencies: []RadioFrequency{{Frequency: 30 * unit.Megahertz, Modulation: types.ModulationFM}},
+			expected:    []float64{testRadio.Frequency, testOtherRadio.Frequency},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			actual := make([]float64, 0)
+			for _, frequency := range c.frequencyList(test.frequencies) {
+				actual = append(actual, frequency.Frequency)
+			}
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
 // The on-frequency counts gate whether the bot broadcasts at all, and the human/bot split keeps it
 // from talking to an audience of other bots.
 func TestClientsOnFrequency(t *testing.T) {
@@ -34,7 +34,9 @@ func (c *Client) newMessage(t types.MessageType) types.Message {
 		Version: "2.1.0.2", // stubbing fake SRS version, TODO add flag
 		Type:    t,
 	}
+	c.positionLock.RLock()
 	client := c.clientInfo
+	c.positionLock.RUnlock()
 	message.Client = &client
 	return message
 }
@@ -0,0 +1,31 @@
+package simpleradio
+
+import (
+	"fmt"
+
+	"github.com/dharmab/skyeye/pkg/simpleradio/types"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+)
+
+// SetPosition moves the client to the given position and sends an update message to the SRS server. SRS clients use
+// this position for line of sight and radio range checks on the client's transmissions.
+func (c *Client) SetPosition(point orb.Point, altitude unit.Length) error {
+	c.setPosition(point, altitude)
+	message := c.newMessage(types.MessageUpdate)
+	if err := c.Send(message); err != nil {
+		return fmt.Errorf("position update failed: %w", err)
+	}
+	return nil
+}
+
+// setPosition updates the position in the client's info.
+func (c *Client) setPosition(point orb.Point, altitude unit.Length) {
+	c.positionLock.Lock()
+	defer c.positionLock.Unlock()
+	c.clientInfo.Position = &types.Position{
+		Latitude:  point.Lat(),
+		Longitude: point.Lon(),
+		Altitude:  altitude.Meters(),
+	}
+}
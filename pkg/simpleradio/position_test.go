@@ -0,0 +1,24 @@
+package simpleradio
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/simpleradio/types"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetPosition(t *testing.T) {
+	t.Parallel()
+	c := newSyncTestClient()
+	c.setPosition(orb.Point{41.5, 42.25}, 30000*unit.Foot)
+
+	message := c.newMessage(types.MessageUpdate)
+	require.NotNil(t, message.Client)
+	require.NotNil(t, message.Client.Position)
+	assert.InDelta(t, 42.25, message.Client.Position.Latitude, 0.0001)
+	assert.InDelta(t, 41.5, message.Client.Position.Longitude, 0.0001)
+	assert.InDelta(t, 9144, message.Client.Position.Altitude, 0.1)
+}
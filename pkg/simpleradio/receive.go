@@ -1,7 +1,9 @@
 package simpleradio
 
 import (
+	"cmp"
 	"context"
+	"slices"
 	"sync"
 	"time"
 
@@ -10,19 +12,41 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-// receiver buffers incoming transmissions on a single radio frequency.
+// receiver buffers incoming transmissions on a single radio frequency. Each client's transmission is buffered
+// separately, so that when several clients transmit at the same time, each transmission is received in full.
 type receiver struct {
+	// radio is the radio the receiver is listening on.
+	radio types.Radio
 	// lock protects the receiver's state.
 	lock sync.RWMutex
-	// buffer of received voice packets.
+	// streams contains the buffered transmissions, keyed by the GUID of the transmitting client.
+	streams map[types.GUID]*stream
+}
+
+// stream buffers a single client's transmission.
+type stream struct {
+	// radio is the radio the transmission was received on.
+	radio types.Radio
+	// buffer of received voice packets, ordered by packet ID.
 	buffer []voice.Packet
-	// origin is the GUID of a client we are currently listening to. We can only listen to one client at a time, and whoever started broadcasting first wins.
-	origin types.GUID
+	// start is the time the first packet of the transmission was received.
+	start time.Time
 	// deadline is extended every time another voice packet is received. When we pass the deadline, the transmission is considered over.
 	deadline time.Time
-	// packetNumber is the number of the last received voice packet. We only record a packet if its packet number is larger than the last received packet's, and skip any that were dropped or delivered out of order.
-	// If we were more ambitious we would reassemble the packets and use Opus's forward error correction to recover from lost packets... too bad!
+	// packetNumber is the highest packet ID received in the transmission. Packets which arrive out of order are moved
+	// back into order, as long as they are no more than maxReorderPackets behind this packet.
 	packetNumber uint64
+	// reordered is the number of packets in the transmission which arrived out of order.
+	reordered int
+	// discarded is the number of packets in the transmission which were duplicates or arrived too late to be reordered.
+	discarded int
+	// overlapped is true if another client transmitted on the same frequency while this transmission was in progress.
+	overlapped bool
+}
+
+// newReceiver creates a receiver for the given radio with no buffered transmissions.
+func newReceiver(radio types.Radio) *receiver {
+	return &receiver{radio: radio, streams: make(map[types.GUID]*stream)}
 }
 
 // Receive returns a channel that receives transmissions over the radio. Each transmission is F32LE PCM audio data.
@@ -30,60 +54,100 @@ func (c *Client) Receive() <-chan Transmission {
 	return c.rxChan
 }
 
-// receive checks if the given packet is part of a new transmission or matches a transmission in progress.
-// If either case is true, the packet is buffered into the receiver.
+// receive buffers the given packet into the transmission from the packet's origin. If no transmission from the origin
+// is buffered, a new transmission is started. If another client is transmitting at the same time, both transmissions
+// are marked as overlapped.
 func (r *receiver) receive(packet *voice.Packet) {
-	// Accept the packet if it is either:
-	// - the first packet of a new transmission
-	isNewTransmission := r.origin == "" && r.packetNumber == 0
-	// - a newer packet from the same origin
-	isNewerPacket := packet.PacketID > r.packetNumber
-	isSameOrigin := r.origin == types.GUID(packet.OriginGUID)
-	shouldAcceptPacket := isNewTransmission || (isNewerPacket && isSameOrigin)
-	if !shouldAcceptPacket {
-		return
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := time.Now()
+	origin := types.GUID(packet.OriginGUID)
+	s, ok := r.streams[origin]
+	if !ok {
+		log.Info().Str("origin", string(origin)).Msg("receiving transmission")
+		s = &stream{radio: r.radio, start: now}
+		for other, o := range r.streams {
+			if o.deadline.After(now) {
+				log.Info().Str("origin", string(origin)).Str("other", string(other)).Msg("transmission overlaps another transmission")
+				o.overlapped = true
+				s.overlapped = true
+			}
+		}
+		r.streams[origin] = s
 	}
+	s.receive(packet, now)
+}
 
-	if isNewTransmission {
-		log.Info().Str("origin", string(packet.OriginGUID)).Msg("receiving transmission")
+// receive inserts the given packet into the stream's buffer in packet ID order. Duplicate packets, packets which
+// arrive too late to be reordered, and packets beyond the maximum transmission length are discarded.
+func (s *stream) receive(packet *voice.Packet, now time.Time) {
+	isTooLate := packet.PacketID+uint64(maxReorderPackets) < s.packetNumber
+	i, isDuplicate := slices.BinarySearchFunc(s.buffer, packet.PacketID, comparePacketID)
+	if isTooLate || isDuplicate || len(s.buffer) >= maxRxPackets {
+		s.discarded++
+		return
+	}
+	if i < len(s.buffer) {
+		s.reordered++
 	}
+	s.buffer = slices.Insert(s.buffer, i, *packet)
+	s.deadline = now.Add(maxRxGap)
+	s.packetNumber = max(s.packetNumber, packet.PacketID)
+}
+
+// comparePacketID orders voice packets by packet ID.
+func comparePacketID(packet voice.Packet, id uint64) int {
+	return cmp.Compare(packet.PacketID, id)
+}
 
+// completed removes and returns the complete transmissions buffered in the receiver, in the order they started.
+func (r *receiver) completed() []*stream {
 	r.lock.Lock()
 	defer r.lock.Unlock()
-	if len(r.buffer) < maxRxPackets {
-		r.buffer = append(r.buffer, *packet)
+	now := time.Now()
+	completed := make([]*stream, 0)
+	for origin, s := range r.streams {
+		if now.After(s.deadline) {
+			delete(r.streams, origin)
+			completed = append(completed, s)
+		}
 	}
-	r.origin = types.GUID(packet.OriginGUID)
-	r.deadline = time.Now().Add(maxRxGap)
-	r.packetNumber = packet.PacketID
-}
-
-// hasTransmission checks if the receiver has a complete transmission buffered.
-func (r *receiver) hasTransmission() bool {
-	r.lock.RLock()
-	defer r.lock.RUnlock()
-	hasPackets := len(r.buffer) > 0
-	isComplete := time.Now().After(r.deadline)
-	return hasPackets && isComplete
+	slices.SortFunc(completed, func(a, b *stream) int {
+		return a.start.Compare(b.start)
+	})
+	return completed
 }
 
-// isReceivingTransmission checks if the receiver is currently buffering an in-progress transmission.
-func (r *receiver) isReceivingTransmission() bool {
+// isReceivingTransmission checks if the receiver is currently buffering an in-progress transmission. If so, it also
+// returns the latest deadline of the in-progress transmissions.
+func (r *receiver) isReceivingTransmission() (time.Time, bool) {
 	r.lock.RLock()
 	defer r.lock.RUnlock()
-	return r.deadline.After(time.Now())
+	now := time.Now()
+	var deadline time.Time
+	for _, s := range r.streams {
+		if s.deadline.After(now) && s.deadline.After(deadline) {
+			deadline = s.deadline
+		}
+	}
+	return deadline, !deadline.IsZero()
 }
 
-// reset clears the receiver's buffer.
+// reset clears the receiver's buffers.
 func (r *receiver) reset() {
 	r.lock.Lock()
 	defer r.lock.Unlock()
-	r.buffer = make([]voice.Packet, 0)
-	r.origin = ""
-	r.deadline = time.Time{}
-	r.packetNumber = 0
+	r.streams = make(map[types.GUID]*stream)
 }
 
+// reorderWindow is how far behind the newest packet of a transmission a packet may arrive and still be moved back into
+// order.
+const reorderWindow = 1 * time.Second
+
+// maxReorderPackets is the reorder window in packets.
+const maxReorderPackets = int(reorderWindow / frameLength)
+
 // maxRxGap is a duration after which the receiver will assume the end of a transmission if no packets are received.
 const maxRxGap = 300 * time.Millisecond
 
@@ -99,7 +163,7 @@ const maxRxPackets = int(MaxTransmissionDuration / frameLength)
 const minRxDuration = 1 * time.Second // 1s is whisper.cpp's minimum duration, it errors for any samples shorter than this.
 
 // receiveVoice listens for incoming UDP voice packets, decodes them into VoicePacket structs, and routes them to the out channel for audio decoding.
-func (c *Client) receiveVoice(ctx context.Context, in <-chan []byte, out chan<- []voice.Packet) {
+func (c *Client) receiveVoice(ctx context.Context, in <-chan []byte, out chan<- *stream) {
 	// t is a ticker which triggers the check for the end of a transmission.
 	t := time.NewTicker(frameLength)
 	for {
@@ -134,28 +198,34 @@ func (c *Client) receiveVoice(ctx context.Context, in <-chan []byte, out chan<-
 					}
 					if testRadio.IsSameFrequency(radio) {
 						receiver.receive(packet)
+						break
 					}
 				}
 			}
 		case <-t.C:
-			// Check if everyone has stopped talking.
+			// Check if anyone has stopped talking.
 			if len(in) == 0 {
 				for _, receiver := range c.receivers {
-					if receiver.hasTransmission() {
-						duration := time.Duration(len(receiver.buffer)) * frameLength
-						logger := log.With().Stringer("duration", duration).Logger()
+					for _, s := range receiver.completed() {
+						duration := time.Duration(len(s.buffer)) * frameLength
+						logger := log.With().
+							Stringer("duration", duration).
+							Int("reordered", s.reordered).
+							Int("discarded", s.discarded).
+							Bool("overlapped", s.overlapped).
+							Logger()
+						c.stats.received.Add(uint64(len(s.buffer)))
+						c.stats.reordered.Add(uint64(s.reordered))
+						c.stats.discarded.Add(uint64(s.discarded))
 						if duration > minRxDuration {
-							if len(receiver.buffer) >= maxRxPackets {
+							if len(s.buffer) >= maxRxPackets {
 								logger.Warn().Stringer("max", MaxTransmissionDuration).Msg("transmission truncated to maximum duration")
 							}
 							logger.Info().Msg("received transmission")
-							audio := make([]voice.Packet, len(receiver.buffer))
-							copy(audio, receiver.buffer)
-							out <- audio
+							out <- s
 						} else {
 							logger.Info().Msg("discarding transmission below minimum size")
 						}
-						receiver.reset()
 					}
 				}
 			}
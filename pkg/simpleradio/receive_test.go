@@ -0,0 +1,129 @@
+package simpleradio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/pcm/rate"
+	"github.com/dharmab/skyeye/pkg/simpleradio/voice"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/hraban/opus.v2"
+)
+
+const (
+	testOriginGUID = "Origin0000000000000000"
+	testOtherGUID  = "Other00000000000000000"
+)
+
+func testPacket(origin string, id uint64, audio []byte) *voice.Packet {
+	packet := voice.NewPacket(audio, nil, 100000002, id, 0, []byte(origin), []byte(origin))
+	return &packet
+}
+
+func packetIDs(packets []voice.Packet) []uint64 {
+	ids := make([]uint64, 0, len(packets))
+	for _, packet := range packets {
+		ids = append(ids, packet.PacketID)
+	}
+	return ids
+}
+
+func TestReceiverReordersPackets(t *testing.T) {
+	t.Parallel()
+	r := newReceiver(testRadio)
+	for _, id := range []uint64{10, 12, 11, 15, 13, 14, 16} {
+		r.receive(testPacket(testOriginGUID, id, []byte{1}))
+	}
+	s := r.streams[testOriginGUID]
+	require.NotNil(t, s)
+	assert.Equal(t, []uint64{10, 11, 12, 13, 14, 15, 16}, packetIDs(s.buffer))
+	assert.Equal(t, 3, s.reordered)
+	assert.Equal(t, 0, s.discarded)
+	assert.Equal(t, uint64(16), s.packetNumber)
+	assert.False(t, s.overlapped)
+}
+
+func TestReceiverDiscardsPackets(t *testing.T) {
+	t.Parallel()
+	r := newReceiver(testRadio)
+	r.receive(testPacket(testOriginGUID, 100, []byte{1}))
+	r.receive(testPacket(testOriginGUID, 101, []byte{1}))
+
+	// Duplicates are discarded.
+	r.receive(testPacket(testOriginGUID, 101, []byte{1}))
+	s := r.streams[testOriginGUID]
+	require.NotNil(t, s)
+	assert.Equal(t, []uint64{100, 101}, packetIDs(s.buffer))
+	assert.Equal(t, 1, s.discarded)
+
+	// Packets which fall outside the reorder window are discarded.
+	r.receive(testPacket(testOriginGUID, 100+uint64(maxReorderPackets)+10, []byte{1}))
+	r.receive(testPacket(testOriginGUID, 102, []byte{1}))
+	r.receive(testPacket(testOriginGUID, 111, []byte{1}))
+	assert.Equal(t, []uint64{100, 101, 111, 100 + uint64(maxReorderPackets) + 10}, packetIDs(s.buffer))
+	assert.Equal(t, 2, s.discarded)
+	assert.Equal(t, 1, s.reordered)
+
+	r.reset()
+	assert.Empty(t, r.streams)
+}
+
+func TestReceiverBuffersOverlappingTransmissions(t *testing.T) {
+	t.Parallel()
+	r := newReceiver(testRadio)
+	r.receive(testPacket(testOriginGUID, 1, []byte{1}))
+	r.receive(testPacket(testOtherGUID, 50, []byte{1}))
+	r.receive(testPacket(testOriginGUID, 2, []byte{1}))
+	r.receive(testPacket(testOtherGUID, 51, []byte{1}))
+
+	deadline, ok := r.isReceivingTransmission()
+	require.True(t, ok)
+	assert.True(t, deadline.After(time.Now()))
+	assert.Empty(t, r.completed())
+
+	require.Eventually(t, func() bool {
+		_, ok := r.isReceivingTransmission()
+		return !ok
+	}, time.Second, 10*time.Millisecond)
+	completed := r.completed()
+	require.Len(t, completed, 2)
+	assert.Equal(t, []uint64{1, 2}, packetIDs(completed[0].buffer))
+	assert.True(t, completed[0].overlapped)
+	assert.Equal(t, []uint64{50, 51}, packetIDs(completed[1].buffer))
+	assert.True(t, completed[1].overlapped)
+	assert.Empty(t, r.streams)
+
+	// A transmission which starts after the others ended does not overlap them.
+	r.receive(testPacket(testOriginGUID, 3, []byte{1}))
+	assert.False(t, r.streams[testOriginGUID].overlapped)
+}
+
+func TestDecodeTransmissionRecoversLostFrames(t *testing.T) {
+	t.Parallel()
+	encoder, err := opus.NewEncoder(int(rate.Wideband.Hertz()), channels, opusApplicationVoIP)
+	require.NoError(t, err)
+	require.NoError(t, encoder.SetInBandFEC(true))
+	require.NoError(t, encoder.SetPacketLossPerc(20))
+	decoder, err := opus.NewDecoder(int(rate.Wideband.Hertz()), channels)
+	require.NoError(t, err)
+
+	tone := testTone(int(frameSize))
+	packets := make([]voice.Packet, 0)
+	// Packets 3, 5, 6, 8 through 16 are lost.
+	for _, id := range []uint64{1, 2, 4, 7, 17} {
+		audio, err := encodeFrame(encoder, tone)
+		require.NoError(t, err)
+		packets = append(packets, *testPacket(testOriginGUID, id, audio))
+	}
+
+	c := &Client{}
+	audio := c.decodeTransmission(decoder, packets)
+	stats := c.ReceiveStats()
+	assert.Equal(t, uint64(12), stats.Lost)
+	// One frame is recovered with FEC before each packet which follows a gap.
+	assert.Equal(t, uint64(3), stats.Recovered)
+	// The remaining frames are concealed, up to the limit for each gap.
+	assert.Equal(t, uint64(1+maxConcealedFrames), stats.Concealed)
+	assert.Len(t, audio, (len(packets)+3+1+maxConcealedFrames)*int(frameSize))
+}
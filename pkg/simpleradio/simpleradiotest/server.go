@@ -0,0 +1,530 @@
+// Package simpleradiotest provides a stand-in for a SimpleRadio-Standalone server, for use in tests.
+package simpleradiotest
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/simpleradio/types"
+	"github.com/dharmab/skyeye/pkg/simpleradio/voice"
+	"github.com/rs/zerolog/log"
+)
+
+// version is the SRS version sent in server messages.
+const version = "2.1.0.2"
+
+// Server is an in-process SRS server. It accepts clients on a TCP and UDP port pair, exchanges sync, update and
+// External AWACS Mode messages over TCP, echoes UDP pings, and relays UDP voice packets between clients tuned to the
+// same frequency.
+//
+// In addition to the connected clients, the server can present a scripted list of clients which exist only in the
+// messages sent to connected clients. Scripted clients can transmit audio using [Server.Transmit].
+type Server struct {
+	listener   net.Listener
+	packetConn *net.UDPConn
+	// bluePassword and redPassword are the External AWACS Mode passwords for each coalition.
+	bluePassword string
+	redPassword  string
+	// settings are the server settings sent to clients.
+	settings map[string]string
+
+	// peers contains the connected clients, in the order they connected.
+	peers []*peer
+	// scripted contains the scripted clients.
+	scripted []types.ClientInfo
+	// addresses maps client GUIDs to the UDP addresses they sent pings from. A client's ping may arrive before its
+	// sync message.
+	addresses map[types.GUID]*net.UDPAddr
+	// packets contains the voice packets received from connected clients, in order.
+	packets []voice.Packet
+	// rejected is the number of External AWACS Mode authentication attempts with an incorrect password.
+	rejected int
+	// lock protects peers, scripted, addresses, packets, rejected, settings and the state of each peer.
+	lock sync.Mutex
+	wg   sync.WaitGroup
+}
+
+// peer is a client connected to the server.
+type peer struct {
+	conn net.Conn
+	// info is the client's most recent information. It is nil until the client sends a sync message.
+	info *types.ClientInfo
+	// authenticated is true if the client provided a correct External AWACS Mode password.
+	authenticated bool
+	// writeLock serializes messages written to the connection.
+	writeLock sync.Mutex
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithExternalAWACSModePasswords sets the External AWACS Mode password for each coalition and enables External AWACS
+// Mode.
+func WithExternalAWACSModePasswords(blue, red string) Option {
+	return func(s *Server) {
+		s.bluePassword = blue
+		s.redPassword = red
+		s.settings[string(types.ExternalAWACSMode)] = "true"
+	}
+}
+
+// WithServerSetting sets a server setting sent to clients.
+func WithServerSetting(setting types.ServerSetting, value string) Option {
+	return func(s *Server) {
+		s.settings[string(setting)] = value
+	}
+}
+
+// WithClients sets the initial scripted clients.
+func WithClients(clients ...types.ClientInfo) Option {
+	return func(s *Server) {
+		s.scripted = slices.Clone(clients)
+	}
+}
+
+// NewServer starts a server listening on a random local port.
+func NewServer(opts ...Option) (*Server, error) {
+	s := &Server{
+		settings: map[string]string{
+			string(types.CoalitionAudioSecurity): "false",
+			string(types.ExternalAWACSMode):      "false",
+		},
+		addresses: make(map[types.GUID]*net.UDPAddr),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if err := s.listen(); err != nil {
+		return nil, err
+	}
+	s.wg.Go(s.serveTCP)
+	s.wg.Go(s.serveUDP)
+	return s, nil
+}
+
+// listen opens the TCP and UDP sockets. SRS uses the same port number for both, so this retries until it finds a port
+// which is free for both protocols.
+func (s *Server) listen() error {
+	var err error
+	for range 10 {
+		var listener net.Listener
+		listener, err = net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			return fmt.Errorf("failed to listen on TCP: %w", err)
+		}
+		port := listener.Addr().(*net.TCPAddr).Port
+		var packetConn *net.UDPConn
+		packetConn, err = net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: port})
+		if err != nil {
+			_ = listener.Close()
+			continue
+		}
+		s.listener = listener
+		s.packetConn = packetConn
+		return nil
+	}
+	return fmt.Errorf("failed to listen on UDP: %w", err)
+}
+
+// Address returns the address the server is listening on, including port.
+func (s *Server) Address() string {
+	return s.listener.Addr().String()
+}
+
+// Clients returns the information of the connected clients which have sent a sync message, in the order they
+// connected.
+func (s *Server) Clients() []types.ClientInfo {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	clients := make([]types.ClientInfo, 0, len(s.peers))
+	for _, p := range s.peers {
+		if p.info != nil {
+			clients = append(clients, *p.info)
+		}
+	}
+	return clients
+}
+
+// IsAuthenticated returns true if a connected client with the given name provided a correct External AWACS Mode
+// password.
+func (s *Server) IsAuthenticated(name string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return slices.ContainsFunc(s.peers, func(p *peer) bool {
+		return p.info != nil && p.info.Name == name && p.authenticated
+	})
+}
+
+// VoiceClients returns the number of connected clients which have sent both a sync message and a UDP ping. These
+// clients can send and receive voice packets.
+func (s *Server) VoiceClients() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	count := 0
+	for _, p := range s.peers {
+		if p.info == nil {
+			continue
+		}
+		if _, ok := s.addresses[p.info.GUID]; ok {
+			count++
+		}
+	}
+	return count
+}
+
+// Rejected returns the number of External AWACS Mode authentication attempts with an incorrect password.
+func (s *Server) Rejected() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.rejected
+}
+
+// Packets returns the voice packets received from connected clients, in the order they were received.
+func (s *Server) Packets() []voice.Packet {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return slices.Clone(s.packets)
+}
+
+// SetClients replaces the scripted clients. Connected clients are sent a disconnect message for each scripted client
+// which was removed, followed by a sync message containing the new client list.
+func (s *Server) SetClients(clients ...types.ClientInfo) {
+	s.lock.Lock()
+	removed := slices.DeleteFunc(slices.Clone(s.scripted), func(old types.ClientInfo) bool {
+		return slices.ContainsFunc(clients, func(c types.ClientInfo) bool { return c.GUID == old.GUID })
+	})
+	s.scripted = slices.Clone(clients)
+	peers := slices.Clone(s.peers)
+	s.lock.Unlock()
+
+	for _, info := range removed {
+		s.broadcast(nil, types.Message{Version: version, Type: types.MessageClientDisconnect, Client: &info})
+	}
+	for _, p := range peers {
+		s.send(p, types.Message{Version: version, Type: types.MessageSync, Clients: s.otherClients(p)})
+	}
+}
+
+// SetServerSetting changes a server setting and sends the new settings to all connected clients.
+func (s *Server) SetServerSetting(setting types.ServerSetting, value string) {
+	s.lock.Lock()
+	s.settings[string(setting)] = value
+	s.lock.Unlock()
+	s.broadcast(nil, s.settingsMessage())
+}
+
+// Transmit sends the given voice packets to every connected client with a radio tuned to one of the packets'
+// frequencies, as if they were transmitted by the client with the given information. This is intended for scripted
+// clients, which have no connection of their own.
+func (s *Server) Transmit(origin types.ClientInfo, packets ...voice.Packet) {
+	for _, packet := range packets {
+		s.relay(origin, &packet, packet.Encode())
+	}
+}
+
+// Close stops the server and closes all open client connections.
+func (s *Server) Close() error {
+	var err error
+	if tcpErr := s.listener.Close(); tcpErr != nil {
+		err = errors.Join(err, fmt.Errorf("failed to close TCP listener: %w", tcpErr))
+	}
+	if udpErr := s.packetConn.Close(); udpErr != nil {
+		err = errors.Join(err, fmt.Errorf("failed to close UDP socket: %w", udpErr))
+	}
+	s.lock.Lock()
+	for _, p := range s.peers {
+		_ = p.conn.Close()
+	}
+	s.lock.Unlock()
+	s.wg.Wait()
+	return err
+}
+
+func (s *Server) serveTCP() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				log.Error().Err(err).Msg("error accepting connection")
+			}
+			return
+		}
+		p := &peer{conn: conn}
+		s.lock.Lock()
+		s.peers = append(s.peers, p)
+		s.lock.Unlock()
+		s.wg.Go(func() {
+			defer s.disconnect(p)
+			if err := s.handle(p); err != nil {
+				log.Debug().Err(err).Msg("SRS test server connection ended")
+			}
+		})
+	}
+}
+
+// handle reads messages from a connected client until the connection is closed.
+func (s *Server) handle(p *peer) error {
+	reader := bufio.NewReader(p.conn)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			return fmt.Errorf("error reading message: %w", err)
+		}
+		var message types.Message
+		if err := json.Unmarshal(line, &message); err != nil {
+			return fmt.Errorf("error decoding message: %w", err)
+		}
+		s.handleMessage(p, message)
+	}
+}
+
+// handleMessage responds to a message from a connected client.
+func (s *Server) handleMessage(p *peer, message types.Message) {
+	switch message.Type {
+	case types.MessageSync:
+		if message.Client == nil {
+			return
+		}
+		info := s.updatePeer(p, *message.Client)
+		settings := s.settingsMessage()
+		s.send(p, types.Message{
+			Version:        version,
+			Type:           types.MessageSync,
+			Clients:        s.otherClients(p),
+			ServerSettings: settings.ServerSettings,
+		})
+		s.send(p, settings)
+		s.broadcast(p, types.Message{Version: version, Type: types.MessageUpdate, Client: &info})
+	case types.MessageUpdate, types.MessageRadioUpdate:
+		if message.Client == nil {
+			return
+		}
+		info := s.updatePeer(p, *message.Client)
+		s.broadcast(p, types.Message{Version: version, Type: message.Type, Client: &info})
+	case types.MessageExternalAWACSModePassword:
+		if message.Client == nil {
+			return
+		}
+		s.authenticate(p, *message.Client, message.ExternalAWACSModePassword)
+	case types.MessagePing:
+	default:
+		log.Debug().Any("message", message).Msg("SRS test server ignoring message")
+	}
+}
+
+// updatePeer stores the given client information. The coalition of an authenticated client is preserved.
+func (s *Server) updatePeer(p *peer, info types.ClientInfo) types.ClientInfo {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if p.info != nil {
+		info.Coalition = p.info.Coalition
+	}
+	p.info = &info
+	return info
+}
+
+// authenticate checks an External AWACS Mode password. If the password matches a coalition's password, the client
+// joins that coalition. The client is sent a reply containing its coalition, which is a spectator coalition if the
+// password was incorrect.
+func (s *Server) authenticate(p *peer, info types.ClientInfo, password string) {
+	s.lock.Lock()
+	var coalition coalitions.Coalition
+	switch {
+	case s.settings[string(types.ExternalAWACSMode)] != "true" || password == "":
+		coalition = coalitions.Neutrals
+	case password == s.bluePassword:
+		coalition = coalitions.Blue
+	case password == s.redPassword:
+		coalition = coalitions.Red
+	default:
+		coalition = coalitions.Neutrals
+	}
+	authenticated := !types.IsSpectator(coalition)
+	if authenticated {
+		info.Coalition = coalition
+		p.info = &info
+		p.authenticated = true
+	} else {
+		s.rejected++
+	}
+	s.lock.Unlock()
+
+	reply := info
+	reply.Coalition = coalition
+	s.send(p, types.Message{Version: version, Type: types.MessageExternalAWACSModePassword, Client: &reply})
+	if authenticated {
+		s.broadcast(p, types.Message{Version: version, Type: types.MessageUpdate, Client: &info})
+	}
+}
+
+// disconnect removes a client and notifies the other connected clients.
+func (s *Server) disconnect(p *peer) {
+	_ = p.conn.Close()
+	s.lock.Lock()
+	s.peers = slices.DeleteFunc(s.peers, func(other *peer) bool { return other == p })
+	info := p.info
+	if info != nil {
+		delete(s.addresses, info.GUID)
+	}
+	s.lock.Unlock()
+	if info != nil {
+		s.broadcast(nil, types.Message{Version: version, Type: types.MessageClientDisconnect, Client: info})
+	}
+}
+
+// otherClients returns the scripted clients and every connected client other than the given one.
+func (s *Server) otherClients(p *peer) []types.ClientInfo {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	clients := slices.Clone(s.scripted)
+	for _, other := range s.peers {
+		if other != p && other.info != nil {
+			clients = append(clients, *other.info)
+		}
+	}
+	return clients
+}
+
+// settingsMessage returns a message containing the server settings.
+func (s *Server) settingsMessage() types.Message {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return types.Message{Version: version, Type: types.MessageServerSettings, ServerSettings: maps.Clone(s.settings)}
+}
+
+// send writes a message to a connected client.
+func (s *Server) send(p *peer, message types.Message) {
+	b, err := json.Marshal(message)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to marshal message")
+		return
+	}
+	p.writeLock.Lock()
+	defer p.writeLock.Unlock()
+	if _, err := p.conn.Write(append(b, '\n')); err != nil {
+		log.Debug().Err(err).Msg("failed to write message")
+	}
+}
+
+// broadcast writes a message to every connected client other than the given one.
+func (s *Server) broadcast(except *peer, message types.Message) {
+	s.lock.Lock()
+	peers := slices.Clone(s.peers)
+	s.lock.Unlock()
+	for _, p := range peers {
+		if p != except {
+			s.send(p, message)
+		}
+	}
+}
+
+func (s *Server) serveUDP() {
+	buf := make([]byte, 1500)
+	for {
+		n, address, err := s.packetConn.ReadFromUDP(buf)
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				log.Error().Err(err).Msg("error reading UDP packet")
+			}
+			return
+		}
+		b := slices.Clone(buf[:n])
+		switch {
+		case n == types.GUIDLength:
+			s.handlePing(types.GUID(b), address)
+		case n > types.GUIDLength:
+			s.handleVoice(b)
+		}
+	}
+}
+
+// handlePing records the UDP address of the client with the given GUID and echoes the ping back to it.
+func (s *Server) handlePing(guid types.GUID, address *net.UDPAddr) {
+	s.lock.Lock()
+	s.addresses[guid] = address
+	s.lock.Unlock()
+	if _, err := s.packetConn.WriteToUDP([]byte(guid), address); err != nil {
+		log.Debug().Err(err).Msg("failed to echo UDP ping")
+	}
+}
+
+// handleVoice records a voice packet from a connected client and relays it to other clients. Packets from clients
+// which have not sent a sync message are dropped. Like a real SRS server, clients do not need to authenticate in
+// External AWACS Mode to transmit.
+func (s *Server) handleVoice(b []byte) {
+	packet, err := voice.Decode(b)
+	if err != nil {
+		log.Debug().Err(err).Msg("failed to decode voice packet")
+		return
+	}
+	s.lock.Lock()
+	var origin *types.ClientInfo
+	for _, p := range s.peers {
+		if p.info != nil && p.info.GUID == types.GUID(packet.OriginGUID) {
+			origin = p.info
+		}
+	}
+	if origin != nil {
+		s.packets = append(s.packets, *packet)
+	}
+	s.lock.Unlock()
+	if origin == nil {
+		log.Debug().Str("GUID", string(packet.OriginGUID)).Msg("dropping voice packet from unknown client")
+		return
+	}
+	s.relay(*origin, packet, b)
+}
+
+// relay writes the encoded voice packet to every connected client other than the origin with a radio tuned to one of
+// the packet's frequencies. If coalition audio security is enabled, only clients in the origin's coalition receive the
+// packet.
+func (s *Server) relay(origin types.ClientInfo, packet *voice.Packet, b []byte) {
+	s.lock.Lock()
+	isSecure := strings.EqualFold(s.settings[string(types.CoalitionAudioSecurity)], "true")
+	addresses := make([]*net.UDPAddr, 0)
+	for _, p := range s.peers {
+		if p.info == nil || p.info.GUID == origin.GUID {
+			continue
+		}
+		address, ok := s.addresses[p.info.GUID]
+		if !ok {
+			continue
+		}
+		if isSecure && p.info.Coalition != origin.Coalition {
+			continue
+		}
+		if isTunedTo(p.info.RadioInfo, packet.Frequencies) {
+			addresses = append(addresses, address)
+		}
+	}
+	s.lock.Unlock()
+	for _, address := range addresses {
+		if _, err := s.packetConn.WriteToUDP(b, address); err != nil {
+			log.Debug().Err(err).Msg("failed to relay voice packet")
+		}
+	}
+}
+
+// isTunedTo returns true if any of the client's radios is tuned to any of the given frequencies.
+func isTunedTo(info types.RadioInfo, frequencies []voice.Frequency) bool {
+	for _, frequency := range frequencies {
+		radio := types.Radio{
+			Frequency:   frequency.Frequency,
+			Modulation:  types.Modulation(frequency.Modulation),
+			IsEncrypted: frequency.Encryption != 0,
+		}
+		if slices.ContainsFunc(info.Radios, radio.IsSameFrequency) {
+			return true
+		}
+	}
+	return false
+}
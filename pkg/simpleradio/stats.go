@@ -0,0 +1,41 @@
+package simpleradio
+
+import "sync/atomic"
+
+// ReceiveStats counts received voice packets and the frames lost and recovered from received transmissions.
+type ReceiveStats struct {
+	// Received is the number of voice packets buffered into received transmissions.
+	Received uint64
+	// Reordered is the number of voice packets which arrived out of order and were moved back into order.
+	Reordered uint64
+	// Discarded is the number of voice packets which were duplicates or arrived too late to be reordered.
+	Discarded uint64
+	// Lost is the number of frames missing from received transmissions.
+	Lost uint64
+	// Recovered is the number of lost frames rebuilt from Opus forward error correction data.
+	Recovered uint64
+	// Concealed is the number of lost frames filled in with Opus packet loss concealment.
+	Concealed uint64
+}
+
+// receiveStats is the atomic counterpart of ReceiveStats.
+type receiveStats struct {
+	received  atomic.Uint64
+	reordered atomic.Uint64
+	discarded atomic.Uint64
+	lost      atomic.Uint64
+	recovered atomic.Uint64
+	concealed atomic.Uint64
+}
+
+// ReceiveStats returns the client's received packet counters since it was created.
+func (c *Client) ReceiveStats() ReceiveStats {
+	return ReceiveStats{
+		Received:  c.stats.received.Load(),
+		Reordered: c.stats.reordered.Load(),
+		Discarded: c.stats.discarded.Load(),
+		Lost:      c.stats.lost.Load(),
+		Recovered: c.stats.recovered.Load(),
+		Concealed: c.stats.concealed.Load(),
+	}
+}
@@ -45,10 +45,10 @@ func (c *Client) waitForClearChannel() {
 		isReceiving := false
 		deadline := time.Now()
 		for _, receiver := range c.receivers {
-			if receiver.isReceivingTransmission() {
+			if receiverDeadline, ok := receiver.isReceivingTransmission(); ok {
 				isReceiving = true
-				if receiver.deadline.After(deadline) {
-					deadline = receiver.deadline
+				if receiverDeadline.After(deadline) {
+					deadline = receiverDeadline
 				}
 			}
 		}
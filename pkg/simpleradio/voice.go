@@ -2,6 +2,7 @@ package simpleradio
 
 import (
 	"context"
+	"slices"
 
 	"github.com/dharmab/skyeye/pkg/pcm/rate"
 	"github.com/dharmab/skyeye/pkg/simpleradio/types"
@@ -14,34 +15,33 @@ import (
 // Mirror of OPUS_APPLICATION_VOIP from the Opus API.
 const opusApplicationVoIP = 2048
 
-// deocdeVoice decodes incoming voice packets from voicePacketsChan into F32LE PCM audio data published to the client's rxChan.
-func (c *Client) decodeVoice(ctx context.Context, voicePacketsChan <-chan []voice.Packet) {
+// maxConcealedFrames is the longest run of lost frames which is filled in with packet loss concealment. Longer gaps
+// are left out of the decoded audio.
+const maxConcealedFrames = 5
+
+// deocdeVoice decodes incoming transmissions from streamChan into F32LE PCM audio data published to the client's rxChan.
+func (c *Client) decodeVoice(ctx context.Context, streamChan <-chan *stream) {
 	for {
 		select {
-		case voicePackets := <-voicePacketsChan:
+		case s := <-streamChan:
+			voicePackets := s.buffer
 			decoder, err := opus.NewDecoder(int(rate.Wideband.Hertz()), channels)
 			if err != nil {
 				log.Error().Err(err).Msg("failed to create Opus decoder")
 				continue
 			}
-			transmissionPCM := make([]float32, 0)
-			for _, packet := range voicePackets {
-				packetPCM, err := decodeFrame(decoder, packet.AudioBytes)
-				if err != nil {
-					log.Error().Err(err).Msg("failed to decode audio")
-				} else {
-					transmissionPCM = append(transmissionPCM, packetPCM...)
-				}
-			}
+			transmissionPCM := c.decodeTransmission(decoder, voicePackets)
 
 			if len(transmissionPCM) > 0 {
 				origin := types.GUID(voicePackets[0].OriginGUID)
 				name, _ := c.getPeerName(origin)
-				log.Info().Str("clientName", name).Int("len", len(transmissionPCM)).Msg("publishing received audio to receiving channel")
+				log.Info().Str("clientName", name).Int("len", len(transmissionPCM)).Bool("overlapped", s.overlapped).Msg("publishing received audio to receiving channel")
 				c.rxChan <- Transmission{
-					TraceID:    shortuuid.New(),
-					ClientName: name,
-					Audio:      transmissionPCM,
+					TraceID:     shortuuid.New(),
+					ClientName:  name,
+					Audio:       transmissionPCM,
+					Overlapped:  s.overlapped,
+					Frequencies: []RadioFrequency{NewRadioFrequency(s.radio)},
 				}
 			} else {
 				log.Debug().Msg("decoded transmission PCM is empty")
@@ -53,19 +53,83 @@ func (c *Client) decodeVoice(ctx context.Context, voicePacketsChan <-chan []voic
 	}
 }
 
-// encodeVoice encodes audio from the client's txChan and publishes an entire transmission's worth of voice packets to packetCh.
-func (c *Client) encodeVoice(ctx context.Context, packetChan chan<- []voice.Packet) {
-	frequencyList := make([]voice.Frequency, 0, len(c.clientInfo.RadioInfo.Radios))
-	for _, radio := range c.clientInfo.RadioInfo.Radios {
+// decodeTransmission decodes the given voice packets, which must be ordered by packet ID, into F32LE PCM audio data.
+// Gaps in the packet IDs are filled in: the frame immediately before each received packet is rebuilt from that
+// packet's forward error correction data, and up to maxConcealedFrames earlier frames are synthesized with packet loss
+// concealment.
+func (c *Client) decodeTransmission(decoder *opus.Decoder, packets []voice.Packet) []float32 {
+	transmissionPCM := make([]float32, 0, len(packets)*int(frameSize))
+	for i, packet := range packets {
+		if i > 0 {
+			lost := packet.PacketID - packets[i-1].PacketID - 1
+			transmissionPCM = append(transmissionPCM, c.recoverLostFrames(decoder, lost, packet)...)
+		}
+		packetPCM, err := decodeFrame(decoder, packet.AudioBytes)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to decode audio")
+		} else {
+			transmissionPCM = append(transmissionPCM, packetPCM...)
+		}
+	}
+	return transmissionPCM
+}
+
+// recoverLostFrames rebuilds the given number of frames lost immediately before the given packet.
+func (c *Client) recoverLostFrames(decoder *opus.Decoder, lost uint64, next voice.Packet) []float32 {
+	if lost == 0 {
+		return nil
+	}
+	c.stats.lost.Add(lost)
+	logger := log.With().Uint64("lost", lost).Uint64("packetID", next.PacketID).Logger()
+	recoveredPCM := make([]float32, 0)
+
+	concealed := min(lost-1, maxConcealedFrames)
+	for range concealed {
+		framePCM, err := concealFrame(decoder)
+		if err != nil {
+			logger.Error().Err(err).Msg("failed to conceal lost audio")
+			break
+		}
+		recoveredPCM = append(recoveredPCM, framePCM...)
+		c.stats.concealed.Add(1)
+	}
+
+	framePCM, err := recoverFrame(decoder, next.AudioBytes)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to recover lost audio")
+		return recoveredPCM
+	}
+	c.stats.recovered.Add(1)
+	logger.Debug().Msg("recovered lost audio")
+	return append(recoveredPCM, framePCM...)
+}
+
+// frequencyList returns the voice packet frequencies of the client's radios which are on any of the given frequencies.
+// If no radios match, all of the client's radios are returned.
+func (c *Client) frequencyList(frequencies []RadioFrequency) []voice.Frequency {
+	radios := slices.DeleteFunc(slices.Clone(c.clientInfo.RadioInfo.Radios), func(radio types.Radio) bool {
+		return !slices.ContainsFunc(frequencies, NewRadioFrequency(radio).IsSameFrequency)
+	})
+	if len(radios) == 0 {
+		radios = c.clientInfo.RadioInfo.Radios
+	}
+	frequencyList := make([]voice.Frequency, 0, len(radios))
+	for _, radio := range radios {
 		frequencyList = append(frequencyList, voice.Frequency{
 			Frequency:  radio.Frequency,
 			Modulation: byte(radio.Modulation),
 			Encryption: 0,
 		})
 	}
+	return frequencyList
+}
+
+// encodeVoice encodes audio from the client's txChan and publishes an entire transmission's worth of voice packets to packetCh.
+func (c *Client) encodeVoice(ctx context.Context, packetChan chan<- []voice.Packet) {
 	for {
 		select {
 		case transmission := <-c.txChan:
+			frequencyList := c.frequencyList(transmission.Frequencies)
 			encoder, err := opus.NewEncoder(int(rate.Wideband.Hertz()), channels, opusApplicationVoIP)
 			if err != nil {
 				log.Error().Err(err).Msg("failed to create Opus encoder")
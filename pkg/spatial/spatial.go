@@ -6,6 +6,7 @@ package spatial
 
 import (
 	"math"
+	"time"
 
 	"github.com/dharmab/skyeye/pkg/bearings"
 	"github.com/dharmab/skyeye/pkg/spatial/projections"
@@ -115,6 +116,101 @@ func IsZero(point orb.Point) bool {
 	return point.Equal(orb.Point{})
 }
 
+// CrossesToRight returns true if the path from point a to point b crosses any segment of the given line from the
+// segment's left side to its right side, when facing along the line from its first point to its last point. A path
+// which ends on the line counts as a crossing, but a path which starts on the line does not, so a single crossing is
+// never counted twice. Intersection is computed on a plane in longitude and latitude, which is accurate enough for the
+// short distances an aircraft travels between updates.
+func CrossesToRight(a, b orb.Point, line orb.LineString) bool {
+	for i := 1; i < len(line); i++ {
+		isFromLeft := orientation(line[i-1], line[i], a) > 0
+		isToRight := orientation(line[i-1], line[i], b) <= 0
+		if isFromLeft && isToRight && segmentsIntersect(a, b, line[i-1], line[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// segmentsIntersect returns true if segment p1-p2 intersects segment q1-q2.
+func segmentsIntersect(p1, p2, q1, q2 orb.Point) bool {
+	d1 := orientation(q1, q2, p1)
+	d2 := orientation(q1, q2, p2)
+	d3 := orientation(p1, p2, q1)
+	d4 := orientation(p1, p2, q2)
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+	return (d1 == 0 && isOnSegment(q1, q2, p1)) ||
+		(d2 == 0 && isOnSegment(q1, q2, p2)) ||
+		(d3 == 0 && isOnSegment(p1, p2, q1)) ||
+		(d4 == 0 && isOnSegment(p1, p2, q2))
+}
+
+// orientation returns the cross product of a->b and a->c. The sign indicates which side of a->b point c lies on.
+func orientation(a, b, c orb.Point) float64 {
+	return (b.X()-a.X())*(c.Y()-a.Y()) - (b.Y()-a.Y())*(c.X()-a.X())
+}
+
+// isOnSegment returns true if point c, which is collinear with a and b, lies within the bounding box of segment a-b.
+func isOnSegment(a, b, c orb.Point) bool {
+	return min(a.X(), b.X()) <= c.X() && c.X() <= max(a.X(), b.X()) &&
+		min(a.Y(), b.Y()) <= c.Y() && c.Y() <= max(a.Y(), b.Y())
+}
+
+// LeadIntercept returns the true bearing an interceptor at the given origin, moving at the given speed, should fly to
+// intercept a target at the given point which is moving on the given true course at the given speed, and the time
+// until intercept. The target is assumed to hold its course and speed. If the interceptor is too slow to catch the
+// target, ok is false, and the caller should fall back to pure pursuit.
+func LeadIntercept(
+	origin orb.Point,
+	speed unit.Speed,
+	target orb.Point,
+	targetCourse bearings.Bearing,
+	targetSpeed unit.Speed,
+	opts ...Option,
+) (bearing bearings.Bearing, timeToIntercept time.Duration, ok bool) {
+	// Work on a local plane in meters, with x east and y north.
+	distance := Distance(origin, target, opts...).Meters()
+	theta := TrueBearing(origin, target, opts...).Value().Radians()
+	dx, dy := distance*math.Sin(theta), distance*math.Cos(theta)
+	course := targetCourse.Value().Radians()
+	vx, vy := targetSpeed.MetersPerSecond()*math.Sin(course), targetSpeed.MetersPerSecond()*math.Cos(course)
+	s := speed.MetersPerSecond()
+
+	// Solve |d + v*t| = s*t for the earliest positive t.
+	a := vx*vx + vy*vy - s*s
+	b := 2 * (dx*vx + dy*vy)
+	c := dx*dx + dy*dy
+	var t float64
+	if math.Abs(a) < 1e-9 {
+		if b >= 0 {
+			return nil, 0, false
+		}
+		t = -c / b
+	} else {
+		discriminant := b*b - 4*a*c
+		if discriminant < 0 {
+			return nil, 0, false
+		}
+		root := math.Sqrt(discriminant)
+		t1, t2 := (-b-root)/(2*a), (-b+root)/(2*a)
+		t = math.Inf(1)
+		for _, candidate := range []float64{t1, t2} {
+			if candidate > 0 && candidate < t {
+				t = candidate
+			}
+		}
+		if math.IsInf(t, 1) {
+			return nil, 0, false
+		}
+	}
+
+	aimX, aimY := dx+vx*t, dy+vy*t
+	direction := unit.Angle(math.Atan2(aimX, aimY)) * unit.Radian
+	return bearings.NewTrueBearing(direction), time.Duration(t * float64(time.Second)), true
+}
+
 // NormalizeAltitude returns the absolute length rounded to the nearest 1000 feet, or nearest 100 feet if less than 1000 feet.
 func NormalizeAltitude(altitude unit.Length) unit.Length {
 	if altitude < 0 {
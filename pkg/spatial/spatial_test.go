@@ -9,6 +9,7 @@ import (
 	"github.com/martinlindhe/unit"
 	"github.com/paulmach/orb"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDistance(t *testing.T) {
@@ -155,6 +156,74 @@ func TestIsZero(t *testing.T) {
 	}
 }
 
+func TestCrossesToRight(t *testing.T) {
+	t.Parallel()
+	line := orb.LineString{{30, 40}, {30, 41}, {31, 42}}
+	testCases := []struct {
+		name     string
+		a        orb.Point
+		b        orb.Point
+		expected bool
+	}{
+		{name: "crosses first segment", a: orb.Point{29.9, 40.5}, b: orb.Point{30.1, 40.5}, expected: true},
+		{name: "crosses second segment", a: orb.Point{30.2, 41.6}, b: orb.Point{30.8, 41.2}, expected: true},
+		{name: "crosses first segment from right", a: orb.Point{30.1, 40.5}, b: orb.Point{29.9, 40.5}, expected: false},
+		{name: "crosses second segment from right", a: orb.Point{30.8, 41.2}, b: orb.Point{30.2, 41.6}, expected: false},
+		{name: "parallel", a: orb.Point{29.9, 40.1}, b: orb.Point{29.9, 40.9}, expected: false},
+		{name: "beyond end of line", a: orb.Point{29.9, 39.5}, b: orb.Point{30.1, 39.5}, expected: false},
+		{name: "same side", a: orb.Point{29.5, 40.5}, b: orb.Point{29.9, 40.5}, expected: false},
+		{name: "touches line", a: orb.Point{29.9, 40.5}, b: orb.Point{30, 40.5}, expected: true},
+		{name: "leaves line", a: orb.Point{30, 40.5}, b: orb.Point{30.1, 40.5}, expected: false},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, CrossesToRight(test.a, test.b, line))
+		})
+	}
+}
+
+func TestLeadIntercept(t *testing.T) {
+	t.Parallel()
+	origin := orb.Point{30, 40}
+	testCases := []struct {
+		name            string
+		targetBearing   float64
+		targetCourse    float64
+		targetSpeed     float64
+		speed           float64
+		expectedOK      bool
+		expectedBearing float64
+		expectedSeconds float64
+	}{
+		{name: "stationary target", targetBearing: 90, targetCourse: 0, targetSpeed: 0, speed: 300, expectedOK: true, expectedBearing: 90, expectedSeconds: 120},
+		{name: "crossing target", targetBearing: 0, targetCourse: 90, targetSpeed: 300, speed: 600, expectedOK: true, expectedBearing: 30, expectedSeconds: 69.3},
+		{name: "head-on target", targetBearing: 90, targetCourse: 270, targetSpeed: 300, speed: 300, expectedOK: true, expectedBearing: 90, expectedSeconds: 60},
+		{name: "same speed tail chase", targetBearing: 90, targetCourse: 90, targetSpeed: 300, speed: 300, expectedOK: false},
+		{name: "faster target", targetBearing: 90, targetCourse: 90, targetSpeed: 500, speed: 300, expectedOK: false},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			target := PointAtBearingAndDistance(origin, bearings.NewTrueBearing(unit.Angle(test.targetBearing)*unit.Degree), 10*unit.NauticalMile)
+			bearing, timeToIntercept, ok := LeadIntercept(
+				origin,
+				unit.Speed(test.speed)*unit.Knot,
+				target,
+				bearings.NewTrueBearing(unit.Angle(test.targetCourse)*unit.Degree),
+				unit.Speed(test.targetSpeed)*unit.Knot,
+			)
+			require.Equal(t, test.expectedOK, ok)
+			if !ok {
+				return
+			}
+			assert.True(t, bearing.IsTrue())
+			assert.InDelta(t, test.expectedBearing, bearing.Degrees(), 1)
+			assert.InDelta(t, test.expectedSeconds, timeToIntercept.Seconds(), 1)
+		})
+	}
+}
+
 func TestPointAtBearingAndDistance(t *testing.T) {
 	t.Parallel()
 	testCases := []struct {
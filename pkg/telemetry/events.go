@@ -0,0 +1,83 @@
+package telemetry
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/rs/zerolog/log"
+)
+
+// ACMI events are published as an Event property on the global object. The value takes the form
+// "EventType|FirstObjectID|SecondObjectID|...|EventText". Object IDs are hexadecimal.
+// Reference: https://www.tacview.net/documentation/acmi/en/ (Events section)
+const (
+	eventProperty  = "Event"
+	destroyedEvent = "Destroyed"
+)
+
+// parseEvent parses the value of an ACMI Event property into the event type and the IDs of the objects it refers to.
+func parseEvent(value string) (string, []uint64, error) {
+	fields := strings.Split(value, "|")
+	eventType := fields[0]
+	if eventType == "" {
+		return "", nil, errors.New("event is missing type")
+	}
+
+	// The final field is free text. Some recorders omit it, so the final field is only treated as text if it is not an
+	// object ID.
+	idFields := fields[1:]
+	if last := fields[len(fields)-1]; len(fields) > 1 {
+		if _, err := parseObjectID(last); err != nil {
+			idFields = fields[1 : len(fields)-1]
+		}
+	}
+
+	ids := make([]uint64, 0, len(idFields))
+	for _, field := range idFields {
+		if field == "" {
+			continue
+		}
+		id, err := parseObjectID(field)
+		if err != nil {
+			return "", nil, fmt.Errorf("error parsing object ID %q: %w", field, err)
+		}
+		ids = append(ids, id)
+	}
+	return eventType, ids, nil
+}
+
+// handleEvent publishes simulation messages for relevant ACMI events.
+func (c *streamingClient) handleEvent(value string) {
+	eventType, ids, err := parseEvent(value)
+	if err != nil {
+		log.Warn().Err(err).Str("event", value).Msg("ignoring malformed event")
+		return
+	}
+	if eventType != destroyedEvent {
+		return
+	}
+	for _, id := range ids {
+		if !c.isAircraftObject(id) {
+			continue
+		}
+		log.Info().Uint64("id", id).Msg("recording object destruction")
+		c.destroys <- sim.Destroyed{ID: id}
+	}
+}
+
+// isAircraftObject checks if the given ID belongs to a known aircraft.
+func (c *streamingClient) isAircraftObject(id uint64) bool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	object, ok := c.state[id]
+	if !ok {
+		return false
+	}
+	taglist, err := object.GetTypes()
+	if err != nil {
+		return false
+	}
+	return isAircraft(taglist)
+}
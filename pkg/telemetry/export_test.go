@@ -0,0 +1,9 @@
+package telemetry
+
+import "time"
+
+// SetRetryInterval changes the minimum time between connection attempts, so that tests need not wait for the
+// default interval.
+func SetRetryInterval(c *RealTimeClient, interval time.Duration) {
+	c.retryInterval = interval
+}
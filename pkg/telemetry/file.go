@@ -21,14 +21,44 @@ type FileReader struct {
 }
 
 // NewFileClient creates a new telemetry client for reading data from a file.
+// By default, the file is read as fast as possible; use ReplayOptions to
+// replay the file at a controlled speed.
 func NewFileClient(
 	filePath string,
 	updateInterval time.Duration,
+	opts ...ReplayOption,
 ) *FileReader {
-	return &FileReader{
+	client := &FileReader{
 		streamingClient: *newStreamingClient(updateInterval),
 		filePath:        filePath,
 	}
+	client.replay = newReplayer(opts...)
+	return client
+}
+
+// Pause pauses the replay. The radar picture is frozen until the replay is
+// resumed.
+func (r *FileReader) Pause() {
+	log.Info().Msg("pausing replay")
+	r.replay.pause()
+}
+
+// Resume resumes a paused replay.
+func (r *FileReader) Resume() {
+	log.Info().Msg("resuming replay")
+	r.replay.resume()
+}
+
+// IsPaused returns true if the replay is paused.
+func (r *FileReader) IsPaused() bool {
+	return r.replay.isPaused()
+}
+
+// SetTimeScale changes the replay speed. 1 replays in real time, 10 replays
+// ten times faster than real time, and 0 replays as fast as possible.
+func (r *FileReader) SetTimeScale(scale float64) {
+	log.Info().Float64("scale", scale).Msg("changing replay speed")
+	r.replay.setTimeScale(scale)
 }
 
 // Run reads telemetry data from the file.
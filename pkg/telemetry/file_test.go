@@ -0,0 +1,50 @@
+package telemetry
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileReaderPauseAndResume(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "replay.txt.acmi")
+	data := "FileType=text/acmi/tacview\nFileVersion=2.2\n0,ReferenceTime=2024-06-01T12:00:00Z\n#0\n#0.5\n#1\n#2\n#3600\n"
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+
+	reader := NewFileClient(path, time.Second, WithReplayEnd(time.Second), WithReplayTimeScale(10))
+	reader.starts = make(chan sim.Started, 1)
+	assert.False(t, reader.IsPaused())
+	done := make(chan error)
+	go func() {
+		done <- reader.Run(t.Context())
+	}()
+
+	// The replay pauses at the end offset.
+	require.Eventually(t, reader.IsPaused, time.Second, 5*time.Millisecond)
+	select {
+	case <-done:
+		t.Fatal("replay did not pause at the end")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// The replay can be resumed past the end, and the rest of the file replayed as fast as possible.
+	reader.SetTimeScale(0)
+	reader.Resume()
+	assert.False(t, reader.IsPaused())
+	select {
+	case err := <-done:
+		require.ErrorIs(t, err, io.EOF)
+	case <-time.After(time.Second):
+		t.Fatal("resumed replay did not reach the end of the file")
+	}
+
+	reader.Pause()
+	assert.True(t, reader.IsPaused())
+}
@@ -0,0 +1,385 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	grpccoalition "github.com/DCS-gRPC/go-bindings/dcs/v0/coalition"
+	"github.com/DCS-gRPC/go-bindings/dcs/v0/common"
+	"github.com/DCS-gRPC/go-bindings/dcs/v0/mission"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/rs/zerolog/log"
+)
+
+// grpcBullseyeInterval is how often bullseye positions are refreshed from DCS-gRPC.
+const grpcBullseyeInterval = 1 * time.Minute
+
+// GRPCClient reads telemetry data from DCS-gRPC. This is an alternative to real-time telemetry for servers which do
+// not run a TacView exporter.
+type GRPCClient struct {
+	missionClient   mission.MissionServiceClient
+	coalitionClient grpccoalition.CoalitionServiceClient
+
+	starts chan sim.Started
+	fades  chan sim.Faded
+
+	// updateInterval is how often to send updates to the channels passed to Stream(). It is also used as the DCS-gRPC
+	// unit polling rate.
+	updateInterval time.Duration
+	// retryInterval is the minimum time between connection attempts.
+	retryInterval time.Duration
+
+	// startTime is the time at which the mission scenario started.
+	startTime time.Time
+	// missionTime is the current mission time.
+	missionTime time.Time
+	// units maps unit IDs to the latest data for each aircraft, ship and ground unit.
+	units map[uint64]*common.Unit
+	// bullseyes maps coalitions to bullseye positions.
+	bullseyes map[coalitions.Coalition]orb.Point
+	// lock protects startTime, missionTime, units, and bullseyes.
+	lock sync.RWMutex
+}
+
+var _ Client = &GRPCClient{}
+
+// NewGRPCClient creates a new telemetry client for reading data from DCS-gRPC.
+func NewGRPCClient(
+	missionClient mission.MissionServiceClient,
+	coalitionClient grpccoalition.CoalitionServiceClient,
+	updateInterval time.Duration,
+) *GRPCClient {
+	c := &GRPCClient{
+		missionClient:   missionClient,
+		coalitionClient: coalitionClient,
+		starts:          make(chan sim.Started),
+		fades:           make(chan sim.Faded),
+		updateInterval:  updateInterval,
+		retryInterval:   10 * time.Second,
+	}
+	c.reset()
+	return c
+}
+
+// Run reads telemetry data until the context is canceled, automatically reconnecting if the unit stream is
+// interrupted, such as when the mission restarts.
+func (c *GRPCClient) Run(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+			nextAttempt := time.Now().Add(c.retryInterval)
+			if err := c.read(ctx); err != nil {
+				if errors.Is(err, context.Canceled) {
+					return nil
+				}
+				log.Error().Err(err).Msg("error reading telemetry from DCS-gRPC, retrying")
+				time.Sleep(time.Until(nextAttempt))
+			}
+		}
+	}
+}
+
+func (c *GRPCClient) read(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	startTimeResponse, err := c.missionClient.GetScenarioStartTime(ctx, &mission.GetScenarioStartTimeRequest{})
+	if err != nil {
+		return fmt.Errorf("error getting scenario start time: %w", err)
+	}
+	startTime, err := parseScenarioTime(startTimeResponse.GetDatetime())
+	if err != nil {
+		return fmt.Errorf("error parsing scenario start time: %w", err)
+	}
+
+	pollRate := uint32(max(1, math.Round(c.updateInterval.Seconds())))
+	stream, err := c.missionClient.StreamUnits(ctx, &mission.StreamUnitsRequest{
+		PollRate: &pollRate,
+		Category: common.GroupCategory_GROUP_CATEGORY_UNSPECIFIED,
+	})
+	if err != nil {
+		return fmt.Errorf("error streaming units: %w", err)
+	}
+
+	log.Info().Msg("resetting DCS-gRPC client state")
+	c.reset()
+	c.lock.Lock()
+	c.startTime = startTime
+	c.missionTime = startTime
+	c.lock.Unlock()
+	c.updateBullseyes(ctx)
+	log.Info().Msg("sending mission start message")
+	select {
+	case c.starts <- sim.Started{}:
+	case <-ctx.Done():
+		return nil
+	}
+
+	go func() {
+		ticker := time.NewTicker(grpcBullseyeInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				c.updateBullseyes(ctx)
+			}
+		}
+	}()
+
+	for {
+		response, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("unit stream error: %w", err)
+		}
+		if fade := c.handleUnitsResponse(response); fade != nil {
+			select {
+			case c.fades <- *fade:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	}
+}
+
+// parseScenarioTime parses a date-time returned by DCS-gRPC.
+func parseScenarioTime(datetime string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, datetime); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02T15:04:05", datetime, time.UTC)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t, nil
+}
+
+// handleUnitsResponse updates the client state from a unit stream response. If the response indicates that an
+// aircraft is gone, a fade message is returned.
+func (c *GRPCClient) handleUnitsResponse(response *mission.StreamUnitsResponse) *sim.Faded {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.missionTime = c.startTime.Add(time.Duration(response.GetTime() * float64(time.Second)))
+
+	if gone := response.GetGone(); gone != nil {
+		id := uint64(gone.GetId())
+		if _, ok := c.units[id]; !ok {
+			return nil
+		}
+		log.Info().Uint64("id", id).Str("name", gone.GetName()).Msg("recording object removal")
+		delete(c.units, id)
+		return &sim.Faded{ID: id}
+	}
+
+	u := response.GetUnit()
+	if u == nil {
+		return nil
+	}
+	if _, ok := grpcClass(u); !ok {
+		return nil
+	}
+	id := uint64(u.GetId())
+	if _, ok := c.units[id]; !ok {
+		log.Info().Uint64("id", id).Str("name", u.GetName()).Str("aircraft", u.GetType()).Msg("recording new object")
+	}
+	c.units[id] = u
+	return nil
+}
+
+// grpcClass returns the trackfile class of a unit, or false if the unit is not tracked. DCS-gRPC does not distinguish
+// air defenses from other ground units, so all ground units are classed as ground vehicles.
+func grpcClass(u *common.Unit) (trackfiles.Class, bool) {
+	switch u.GetGroup().GetCategory() {
+	case common.GroupCategory_GROUP_CATEGORY_AIRPLANE, common.GroupCategory_GROUP_CATEGORY_HELICOPTER:
+		return trackfiles.Aircraft, true
+	case common.GroupCategory_GROUP_CATEGORY_SHIP:
+		return trackfiles.Watercraft, true
+	case common.GroupCategory_GROUP_CATEGORY_GROUND:
+		return trackfiles.GroundVehicle, true
+	default:
+		return trackfiles.Aircraft, false
+	}
+}
+
+// updateBullseyes refreshes the bullseye position of each coalition.
+func (c *GRPCClient) updateBullseyes(ctx context.Context) {
+	for _, coalition := range []coalitions.Coalition{coalitions.Red, coalitions.Blue} {
+		response, err := c.coalitionClient.GetBullseye(ctx, &grpccoalition.GetBullseyeRequest{
+			Coalition: coalitionToGRPC(coalition),
+		})
+		if err != nil {
+			log.Warn().Err(err).Stringer("coalition", coalition).Msg("error getting bullseye from DCS-gRPC")
+			continue
+		}
+		position := response.GetPosition()
+		if position == nil {
+			continue
+		}
+		c.lock.Lock()
+		c.bullseyes[coalition] = orb.Point{position.GetLon(), position.GetLat()}
+		c.lock.Unlock()
+	}
+}
+
+// Stream implements [Client.Stream].
+func (c *GRPCClient) Stream(ctx context.Context, wg *sync.WaitGroup, starts chan<- sim.Started, updates chan<- sim.Updated, fades chan<- sim.Faded, _ chan<- sim.Destroyed, _ chan<- sim.Launched) {
+	ticker := time.NewTicker(c.updateInterval)
+	defer ticker.Stop()
+	wg.Go(func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case start := <-c.starts:
+				starts <- start
+			}
+		}
+	})
+
+	wg.Go(func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case fade := <-c.fades:
+				fades <- fade
+			}
+		}
+	})
+
+	wg.Go(func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				for _, update := range c.collectUpdates() {
+					updates <- update
+				}
+			}
+		}
+	})
+
+	<-ctx.Done()
+}
+
+func (c *GRPCClient) collectUpdates() []sim.Updated {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	result := make([]sim.Updated, 0, len(c.units))
+	for id, u := range c.units {
+		position := u.GetPosition()
+		if position == nil {
+			log.Error().Uint64("id", id).Msg("unit missing position")
+			continue
+		}
+		frame := trackfiles.Frame{
+			Time:     c.missionTime,
+			Point:    orb.Point{position.GetLon(), position.GetLat()},
+			Altitude: unit.Length(position.GetAlt()) * unit.Meter,
+		}
+		if velocity := u.GetVelocity(); velocity != nil {
+			frame.Heading = unit.Angle(velocity.GetHeading()) * unit.Degree
+			if vector := velocity.GetVelocity(); vector != nil {
+				// DCS-gRPC does not report airspeed. In the absence of wind data, the speed relative to the ground is
+				// the best available approximation. In DCS coordinates, the Y axis points up.
+				tas := unit.Speed(math.Sqrt(vector.GetX()*vector.GetX()+vector.GetY()*vector.GetY()+vector.GetZ()*vector.GetZ())) * unit.MetersPerSecond
+				verticalSpeed := unit.Speed(vector.GetY()) * unit.MetersPerSecond
+				frame.TAS = &tas
+				frame.VerticalSpeed = &verticalSpeed
+			}
+		} else {
+			frame.Heading = unit.Angle(u.GetOrientation().GetYaw()) * unit.Degree
+		}
+		if orientation := u.GetOrientation(); orientation != nil {
+			pitch := unit.Angle(orientation.GetPitch()) * unit.Degree
+			roll := unit.Angle(orientation.GetRoll()) * unit.Degree
+			frame.Pitch = &pitch
+			frame.Roll = &roll
+		}
+
+		name := u.GetPlayerName()
+		if name == "" {
+			name = u.GetName()
+		}
+		if name == "" {
+			name = fmt.Sprintf("Unit %d", id)
+		}
+
+		class, _ := grpcClass(u)
+		result = append(result, sim.Updated{
+			Labels: trackfiles.Labels{
+				ID:        id,
+				Name:      name,
+				Coalition: coalitionFromGRPC(u.GetCoalition()),
+				ACMIName:  u.GetType(),
+				Class:     class,
+				Group:     u.GetGroup().GetName(),
+			},
+			Frame: frame,
+		})
+	}
+	return result
+}
+
+// Bullseye implements [Client.Bullseye].
+func (c *GRPCClient) Bullseye(coalition coalitions.Coalition) (orb.Point, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	if bullseye, ok := c.bullseyes[coalition]; ok {
+		return bullseye, nil
+	}
+	return orb.Point{}, errors.New("bullseye not found")
+}
+
+// Time implements [Client.Time].
+func (c *GRPCClient) Time() time.Time {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.missionTime
+}
+
+func (c *GRPCClient) reset() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.startTime = time.Time{}
+	c.missionTime = time.Time{}
+	c.units = make(map[uint64]*common.Unit)
+	c.bullseyes = make(map[coalitions.Coalition]orb.Point)
+}
+
+func coalitionFromGRPC(coalition common.Coalition) coalitions.Coalition {
+	switch coalition {
+	case common.Coalition_COALITION_RED:
+		return coalitions.Red
+	case common.Coalition_COALITION_BLUE:
+		return coalitions.Blue
+	default:
+		return coalitions.Neutrals
+	}
+}
+
+func coalitionToGRPC(coalition coalitions.Coalition) common.Coalition {
+	switch coalition {
+	case coalitions.Red:
+		return common.Coalition_COALITION_RED
+	case coalitions.Blue:
+		return common.Coalition_COALITION_BLUE
+	default:
+		return common.Coalition_COALITION_NEUTRAL
+	}
+}
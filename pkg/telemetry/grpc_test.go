@@ -0,0 +1,161 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	grpccoalition "github.com/DCS-gRPC/go-bindings/dcs/v0/coalition"
+	"github.com/DCS-gRPC/go-bindings/dcs/v0/common"
+	"github.com/DCS-gRPC/go-bindings/dcs/v0/mission"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+type fakeUnitStream struct {
+	grpc.ClientStream
+	ctx       context.Context
+	responses chan *mission.StreamUnitsResponse
+}
+
+func (s *fakeUnitStream) Recv() (*mission.StreamUnitsResponse, error) {
+	select {
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	case response, ok := <-s.responses:
+		if !ok {
+			return nil, errors.New("stream closed")
+		}
+		return response, nil
+	}
+}
+
+type fakeMissionClient struct {
+	mission.MissionServiceClient
+	streams chan *fakeUnitStream
+}
+
+func (*fakeMissionClient) GetScenarioStartTime(context.Context, *mission.GetScenarioStartTimeRequest, ...grpc.CallOption) (*mission.GetScenarioStartTimeResponse, error) {
+	return &mission.GetScenarioStartTimeResponse{Datetime: "2024-06-01T12:00:00Z"}, nil
+}
+
+func (c *fakeMissionClient) StreamUnits(ctx context.Context, _ *mission.StreamUnitsRequest, _ ...grpc.CallOption) (mission.MissionService_StreamUnitsClient, error) {
+	stream := &fakeUnitStream{ctx: ctx, responses: make(chan *mission.StreamUnitsResponse)}
+	c.streams <- stream
+	return stream, nil
+}
+
+type fakeCoalitionClient struct {
+	grpccoalition.CoalitionServiceClient
+}
+
+func (*fakeCoalitionClient) GetBullseye(_ context.Context, request *grpccoalition.GetBullseyeRequest, _ ...grpc.CallOption) (*grpccoalition.GetBullseyeResponse, error) {
+	if request.GetCoalition() == common.Coalition_COALITION_BLUE {
+		return &grpccoalition.GetBullseyeResponse{Position: &common.Position{Lat: 40, Lon: 30}}, nil
+	}
+	return &grpccoalition.GetBullseyeResponse{Position: &common.Position{Lat: 42, Lon: 33}}, nil
+}
+
+func newTestUnit(id uint32, category common.GroupCategory) *mission.StreamUnitsResponse {
+	playerName := "Mobius 1"
+	return &mission.StreamUnitsResponse{
+		Time: 10,
+		Update: &mission.StreamUnitsResponse_Unit{
+			Unit: &common.Unit{
+				Id:         id,
+				Name:       "Aerial-1-1",
+				Coalition:  common.Coalition_COALITION_BLUE,
+				Type:       "F-15C",
+				Position:   &common.Position{Lat: 40.5, Lon: 30.5, Alt: 6096},
+				Velocity:   &common.Velocity{Heading: 90, Speed: 200},
+				PlayerName: &playerName,
+				Group:      &common.Group{Category: category},
+			},
+		},
+	}
+}
+
+func TestGRPCClient(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(t.Context())
+	missionClient := &fakeMissionClient{streams: make(chan *fakeUnitStream)}
+	client := NewGRPCClient(missionClient, &fakeCoalitionClient{}, 10*time.Millisecond)
+	client.retryInterval = 10 * time.Millisecond
+
+	starts := make(chan sim.Started)
+	updates := make(chan sim.Updated)
+	fades := make(chan sim.Faded)
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		_ = client.Run(ctx)
+	})
+	wg.Go(func() {
+		client.Stream(ctx, &wg, starts, updates, fades, make(chan sim.Destroyed), make(chan sim.Launched))
+	})
+	t.Cleanup(func() {
+		cancel()
+		wg.Wait()
+	})
+
+	stream := <-missionClient.streams
+	<-starts
+	bullseye, err := client.Bullseye(coalitions.Blue)
+	require.NoError(t, err)
+	assert.InDelta(t, 30, bullseye.Lon(), 0.001)
+	assert.InDelta(t, 40, bullseye.Lat(), 0.001)
+
+	// Trains are ignored.
+	stream.responses <- newTestUnit(2, common.GroupCategory_GROUP_CATEGORY_TRAIN)
+	stream.responses <- newTestUnit(1, common.GroupCategory_GROUP_CATEGORY_AIRPLANE)
+	update := <-updates
+	assert.Equal(t, uint64(1), update.Labels.ID)
+	assert.Equal(t, "Mobius 1", update.Labels.Name)
+	assert.Equal(t, "F-15C", update.Labels.ACMIName)
+	assert.Equal(t, trackfiles.Aircraft, update.Labels.Class)
+	assert.Equal(t, coalitions.Coalition(coalitions.Blue), update.Labels.Coalition)
+	assert.InDelta(t, 30.5, update.Frame.Point.Lon(), 0.001)
+	assert.InDelta(t, 40.5, update.Frame.Point.Lat(), 0.001)
+	assert.InDelta(t, 20000, update.Frame.Altitude.Feet(), 1)
+	assert.InDelta(t, 90, update.Frame.Heading.Degrees(), 0.001)
+	assert.Equal(t, time.Date(2024, 6, 1, 12, 0, 10, 0, time.UTC), update.Frame.Time)
+	assert.Equal(t, update.Frame.Time, client.Time())
+
+	stream.responses <- &mission.StreamUnitsResponse{
+		Time:   20,
+		Update: &mission.StreamUnitsResponse_Gone{Gone: &mission.StreamUnitsResponse_UnitGone{Id: 1}},
+	}
+	fade := <-fades
+	assert.Equal(t, uint64(1), fade.ID)
+
+	// Ships are tracked as surface contacts.
+	stream.responses <- newTestUnit(3, common.GroupCategory_GROUP_CATEGORY_SHIP)
+	for update := range updates {
+		if update.Labels.ID == 3 {
+			assert.Equal(t, trackfiles.Watercraft, update.Labels.Class)
+			break
+		}
+	}
+
+	// When the stream ends, the client reconnects and starts a new mission.
+	close(stream.responses)
+	<-missionClient.streams
+	<-starts
+}
+
+func TestParseScenarioTime(t *testing.T) {
+	t.Parallel()
+	expected := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	for _, datetime := range []string{"2024-06-01T12:00:00Z", "2024-06-01T12:00:00"} {
+		actual, err := parseScenarioTime(datetime)
+		require.NoError(t, err)
+		assert.True(t, expected.Equal(actual), datetime)
+	}
+	_, err := parseScenarioTime("yesterday")
+	require.Error(t, err)
+}
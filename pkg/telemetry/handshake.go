@@ -156,27 +156,53 @@ func DecodeClientHandshake(packet string) (*ClientHandshake, error) {
 	if !strings.HasPrefix(lines[0], LowLevelProtocol+".") {
 		return nil, errors.New("unexpected low level protocol version")
 	}
-	handshake.LowLevelProtocolVersion = strings.Split(lines[0], ".")[1]
+	handshake.LowLevelProtocolVersion = strings.TrimPrefix(lines[0], LowLevelProtocol+".")
 	if !strings.HasPrefix(lines[1], HighLevelProtocol+".") {
 		return nil, errors.New("unexpected high level protocol version")
 	}
-	handshake.HighLevelProtocolVersion = strings.Split(lines[1], ".")[1]
+	handshake.HighLevelProtocolVersion = strings.TrimPrefix(lines[1], HighLevelProtocol+".")
 
-	if !strings.HasPrefix(lines[2], "Client ") {
+	// The hostname may or may not be prefixed with "Client ", depending on the client.
+	handshake.Hostname = strings.TrimPrefix(lines[2], "Client ")
+	if handshake.Hostname == "" {
 		return nil, errors.New("unexpected client hostname")
 	}
-	handshake.Hostname = strings.Split(lines[2], " ")[1]
 
 	hash, _, ok := strings.Cut(lines[3], string(rune(0)))
 	if !ok {
 		return nil, errors.New("unable to decode password hash")
 	}
-	if len(hash) == 8 {
+	if _, err := strconv.ParseUint(hash, 16, 64); err != nil {
+		return nil, fmt.Errorf("unable to decode password hash: %w", err)
+	}
+	// Hashes are encoded without leading zeros, so a short hash may have been computed using either algorithm.
+	switch {
+	case len(hash) > 16:
+		return nil, errors.New("unexpected password hash length")
+	case len(hash) > 8:
+		handshake.hashCRC64WE = &hash
+	case len(hash) == 8:
 		handshake.hashCRC32ISOHDLC = &hash
-	} else if len(hash) == 16 {
+	default:
 		handshake.hashCRC64WE = &hash
-	} else {
-		return nil, errors.New("unexpected password hash length")
+		handshake.hashCRC32ISOHDLC = &hash
 	}
 	return handshake, nil
 }
+
+// Verify checks if the handshake's password hash matches the given password using any of the given algorithms.
+func (h *ClientHandshake) Verify(password string, algorithms ...HashAlgorithm) bool {
+	for _, algorithm := range algorithms {
+		switch algorithm {
+		case CRC64WE:
+			if h.hashCRC64WE != nil && *h.hashCRC64WE == hashPassword(password, CRC64WE) {
+				return true
+			}
+		case CRC32ISOHDLC:
+			if h.hashCRC32ISOHDLC != nil && *h.hashCRC32ISOHDLC == hashPassword(password, CRC32ISOHDLC) {
+				return true
+			}
+		}
+	}
+	return false
+}
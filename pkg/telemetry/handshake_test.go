@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestPasswordHash(t *testing.T) {
@@ -42,3 +43,29 @@ func TestPasswordHash(t *testing.T) {
 		})
 	}
 }
+
+func TestClientHandshakeRoundTrip(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		password  string
+		algorithm HashAlgorithm
+	}{
+		{password: "", algorithm: CRC64WE},
+		{password: "", algorithm: CRC32ISOHDLC},
+		{password: "local", algorithm: CRC64WE},
+		{password: "local", algorithm: CRC32ISOHDLC},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.password+"/"+tc.algorithm.String(), func(t *testing.T) {
+			t.Parallel()
+			packet := NewClientHandshake("skyeye", tc.password).Encode(tc.algorithm)
+			decoded, err := DecodeClientHandshake(packet)
+			require.NoError(t, err)
+			assert.Equal(t, LowLevelProtocolVersion, decoded.LowLevelProtocolVersion)
+			assert.Equal(t, HighLevelProtocolVersion, decoded.HighLevelProtocolVersion)
+			assert.Equal(t, "skyeye", decoded.Hostname)
+			assert.True(t, decoded.Verify(tc.password, tc.algorithm))
+			assert.False(t, decoded.Verify("remote", CRC64WE, CRC32ISOHDLC))
+		})
+	}
+}
@@ -15,7 +15,7 @@ type Client interface {
 	// Run reads telemetry data.
 	Run(context.Context) error
 	// Stream publishes telemetry data to the given channels.
-	Stream(context.Context, *sync.WaitGroup, chan<- sim.Started, chan<- sim.Updated, chan<- sim.Faded)
+	Stream(context.Context, *sync.WaitGroup, chan<- sim.Started, chan<- sim.Updated, chan<- sim.Faded, chan<- sim.Destroyed, chan<- sim.Launched)
 	// Bullseye returns the position of the given coalition's bullseye.
 	Bullseye(coalitions.Coalition) (orb.Point, error)
 	// Time returns the current mission time.
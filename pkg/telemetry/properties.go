@@ -1,10 +1,26 @@
 package telemetry
 
 import (
+	"strconv"
+
+	"github.com/dharmab/goacmi/v2/objects"
 	acmi "github.com/dharmab/goacmi/v2/properties/coalitions"
 	skyeye "github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/martinlindhe/unit"
+)
+
+// Optional ACMI flight data properties. Speeds are in meters per second.
+// Reference: https://www.tacview.net/documentation/acmi/en/ (Object Properties section)
+const (
+	iasProperty           = "IAS"
+	tasProperty           = "TAS"
+	machProperty          = "Mach"
+	verticalSpeedProperty = "VerticalSpeed"
 )
 
+// groupProperty is the optional ACMI property containing the name of the object's mission group.
+const groupProperty = "Group"
+
 func propertyToCoalition(v string) skyeye.Coalition {
 	switch v {
 	case string(acmi.Allies):
@@ -15,3 +31,26 @@ func propertyToCoalition(v string) skyeye.Coalition {
 		return skyeye.Neutrals
 	}
 }
+
+// getNumber returns the value of a numeric property, or nil if the property is missing or malformed.
+func getNumber(object *objects.Object, name string) *float64 {
+	property, ok := object.GetProperty(name)
+	if !ok {
+		return nil
+	}
+	value, err := strconv.ParseFloat(property, 64)
+	if err != nil {
+		return nil
+	}
+	return &value
+}
+
+// getSpeed returns the value of a speed property, or nil if the property is missing or malformed.
+func getSpeed(object *objects.Object, name string) *unit.Speed {
+	value := getNumber(object, name)
+	if value == nil {
+		return nil
+	}
+	speed := unit.Speed(*value) * unit.MetersPerSecond
+	return &speed
+}
@@ -0,0 +1,154 @@
+package telemetry
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// recordingHandshakeFile is the name of the file within a recording archive which contains the host handshake.
+const recordingHandshakeFile = "handshake.txt"
+
+// maxRecordingSuffix is the number of suffixed names tried when a recording file with the same name already exists.
+const maxRecordingSuffix = 100
+
+// recording writes a copy of a real-time telemetry stream to a compressed ACMI file. The archive contains the host
+// handshake and the ACMI data exactly as it was received, and can be replayed using NewFileClient.
+//
+// The file is created when the first data is written, so that a connection which is rejected during the handshake
+// does not leave an empty recording behind.
+type recording struct {
+	directory string
+	handshake HostHandshake
+	// path is the path of the recording file. It is empty until the file is created.
+	path    string
+	file    *os.File
+	archive *zip.Writer
+	acmi    io.Writer
+	// closed is true once the recording has been finalized or has failed. Writes to a closed recording are discarded.
+	closed bool
+	// lock protects path, file, archive, acmi, and closed.
+	lock sync.Mutex
+}
+
+// newRecording returns a recording which will be written to the given directory, named after the time the first
+// data is written.
+func newRecording(directory string, handshake HostHandshake) *recording {
+	return &recording{
+		directory: directory,
+		handshake: handshake,
+	}
+}
+
+// open creates the recording file and writes the handshake to it. The caller must hold the lock.
+func (r *recording) open() error {
+	if err := os.MkdirAll(r.directory, 0o755); err != nil {
+		return fmt.Errorf("failed to create recording directory: %w", err)
+	}
+	name, file, err := createRecordingFile(r.directory, "SkyEye-"+time.Now().UTC().Format("20060102-150405"))
+	if err != nil {
+		return err
+	}
+
+	archive := zip.NewWriter(file)
+	handshakeWriter, err := archive.Create(recordingHandshakeFile)
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to add handshake to recording: %w", err)
+	}
+	if _, err := io.WriteString(handshakeWriter, strings.TrimRight(r.handshake.Encode(), "\x00")); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write handshake to recording: %w", err)
+	}
+	acmi, err := archive.Create(name + ".txt.acmi")
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to add ACMI data to recording: %w", err)
+	}
+
+	r.path = file.Name()
+	r.file = file
+	r.archive = archive
+	r.acmi = acmi
+	log.Info().Str("path", r.path).Msg("recording telemetry")
+	return nil
+}
+
+// createRecordingFile creates a new recording file in the given directory. If a file with the given name already
+// exists, a numeric suffix is appended rather than overwriting the existing recording. The returned name excludes
+// the directory and extension.
+func createRecordingFile(directory, name string) (string, *os.File, error) {
+	candidate := name
+	for i := 1; i <= maxRecordingSuffix; i++ {
+		path := filepath.Join(directory, candidate+".zip.acmi")
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err == nil {
+			return candidate, file, nil
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return "", nil, fmt.Errorf("failed to create recording file: %w", err)
+		}
+		candidate = fmt.Sprintf("%s-%d", name, i)
+	}
+	return "", nil, fmt.Errorf("failed to create recording file: too many recordings named %s", name)
+}
+
+// Write implements io.Writer. Write never returns an error, so that a failed recording does not interrupt the
+// telemetry stream. If writing fails, the error is logged and the rest of the stream is not recorded.
+func (r *recording) Write(p []byte) (int, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.closed || len(p) == 0 {
+		return len(p), nil
+	}
+	if r.file == nil {
+		if err := r.open(); err != nil {
+			log.Error().Err(err).Msg("failed to start telemetry recording, continuing without recording")
+			r.closed = true
+			return len(p), nil
+		}
+	}
+	if _, err := r.acmi.Write(p); err != nil {
+		log.Error().Err(err).Str("path", r.path).Msg("error writing telemetry recording, stopping recording")
+		r.finalize()
+	}
+	return len(p), nil
+}
+
+// Close finalizes the recording. If no data was written, no file is created.
+func (r *recording) Close() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.closed {
+		return nil
+	}
+	if r.file == nil {
+		r.closed = true
+		return nil
+	}
+	log.Info().Str("path", r.path).Msg("finalizing telemetry recording")
+	return r.finalize()
+}
+
+// finalize closes the archive and file. The caller must hold the lock.
+func (r *recording) finalize() error {
+	r.closed = true
+	archiveErr := r.archive.Close()
+	fileErr := r.file.Close()
+	if archiveErr != nil {
+		return fmt.Errorf("failed to close recording archive: %w", archiveErr)
+	}
+	if fileErr != nil {
+		return fmt.Errorf("failed to close recording file: %w", fileErr)
+	}
+	return nil
+}
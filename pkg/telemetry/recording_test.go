@@ -0,0 +1,85 @@
+package telemetry
+
+import (
+	"archive/zip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecording(t *testing.T) {
+	t.Parallel()
+	directory := filepath.Join(t.TempDir(), "recordings")
+	handshake := HostHandshake{
+		LowLevelProtocolVersion:  LowLevelProtocolVersion,
+		HighLevelProtocolVersion: HighLevelProtocolVersion,
+		Hostname:                 "dcs.example.com",
+	}
+	rec := newRecording(directory, handshake)
+
+	data := "FileType=text/acmi/tacview\nFileVersion=2.2\n0,ReferenceTime=2024-06-01T12:00:00Z\n#0.5\n"
+	n, err := io.WriteString(rec, data)
+	require.NoError(t, err)
+	assert.Equal(t, len(data), n)
+	require.NoError(t, rec.Close())
+
+	// Writes after the recording is finalized are discarded.
+	_, err = io.WriteString(rec, "#1.0\n")
+	require.NoError(t, err)
+	require.NoError(t, rec.Close())
+
+	// The recording can be read back as an ACMI file.
+	acmi, err := openFile(rec.path)
+	require.NoError(t, err)
+	defer acmi.Close()
+	recorded, err := io.ReadAll(acmi)
+	require.NoError(t, err)
+	assert.Equal(t, data, string(recorded))
+
+	archive, err := zip.OpenReader(rec.path)
+	require.NoError(t, err)
+	defer archive.Close()
+	f, err := archive.Open(recordingHandshakeFile)
+	require.NoError(t, err)
+	defer f.Close()
+	packet, err := io.ReadAll(f)
+	require.NoError(t, err)
+	decoded, err := DecodeHostHandshake(string(packet))
+	require.NoError(t, err)
+	assert.Equal(t, handshake, decoded)
+}
+
+func TestRecordingWithoutData(t *testing.T) {
+	t.Parallel()
+	directory := filepath.Join(t.TempDir(), "recordings")
+	rec := newRecording(directory, HostHandshake{})
+	require.NoError(t, rec.Close())
+
+	// A recording which never received data does not leave a file behind.
+	assert.NoDirExists(t, directory)
+	_, err := io.WriteString(rec, "#1.0\n")
+	require.NoError(t, err)
+	assert.NoDirExists(t, directory)
+}
+
+func TestCreateRecordingFile(t *testing.T) {
+	t.Parallel()
+	directory := t.TempDir()
+	existing := filepath.Join(directory, "SkyEye-20240601-120000.zip.acmi")
+	require.NoError(t, os.WriteFile(existing, []byte("existing"), 0o644))
+
+	name, file, err := createRecordingFile(directory, "SkyEye-20240601-120000")
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	assert.Equal(t, "SkyEye-20240601-120000-1", name)
+	assert.Equal(t, filepath.Join(directory, "SkyEye-20240601-120000-1.zip.acmi"), file.Name())
+
+	// The existing recording is not overwritten.
+	contents, err := os.ReadFile(existing)
+	require.NoError(t, err)
+	assert.Equal(t, "existing", string(contents))
+}
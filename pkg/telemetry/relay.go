@@ -0,0 +1,434 @@
+package telemetry
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dharmab/goacmi/v2/objects"
+	"github.com/dharmab/goacmi/v2/parsing"
+	"github.com/dharmab/goacmi/v2/properties"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// relayBufferSize is the number of lines which may be queued for a downstream client. A client which falls further
+	// behind than this is disconnected, so that a slow client cannot stall the relay.
+	relayBufferSize = 4096
+	// relayReadTimeout is the maximum time to wait for a line from the upstream telemetry service before reconnecting.
+	relayReadTimeout = 10 * time.Minute
+	// relayHandshakeTimeout is the maximum time to wait for a downstream client's handshake.
+	relayHandshakeTimeout = 10 * time.Second
+)
+
+// Relay reads real-time telemetry from a single upstream telemetry service and serves it to any number of downstream
+// clients over the same protocol. Clients which connect mid-mission first receive a snapshot of the current state of
+// every object, followed by the live stream.
+type Relay struct {
+	client   *RealTimeClient
+	listener net.Listener
+	// hostname is sent in the host handshake to downstream clients.
+	hostname string
+	// passwords contains the passwords which downstream clients may provide. Each downstream client may be given its
+	// own password.
+	passwords []string
+
+	// state is the current state of the upstream telemetry stream.
+	state *relayState
+	// downstreams contains the downstream clients which are receiving the stream.
+	downstreams map[*downstream]struct{}
+	// lock protects state and downstreams.
+	lock sync.Mutex
+}
+
+// downstream is a client of the relay.
+type downstream struct {
+	hostname string
+	// lines is the queue of lines to send to the client. It is closed when the client is dropped.
+	lines chan string
+}
+
+// NewRelay creates a new relay which reads from the telemetry service at upstreamAddress and listens for downstream
+// clients at listenAddress. Downstream clients must provide one of the given passwords. If no passwords are given,
+// downstream clients must provide an empty password.
+func NewRelay(
+	upstreamAddress,
+	upstreamPassword string,
+	connectionTimeout time.Duration,
+	listenAddress,
+	hostname string,
+	passwords []string,
+) (*Relay, error) {
+	listener, err := net.Listen("tcp", listenAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %v: %w", listenAddress, err)
+	}
+	if len(passwords) == 0 {
+		passwords = []string{""}
+	}
+	return &Relay{
+		// The relay reads the stream itself, so the client's update interval is unused.
+		client:      NewRealTimeClient(upstreamAddress, hostname, upstreamPassword, connectionTimeout, 0, ""),
+		listener:    listener,
+		hostname:    hostname,
+		passwords:   passwords,
+		state:       newRelayState(),
+		downstreams: make(map[*downstream]struct{}),
+	}, nil
+}
+
+// Address returns the address the relay is listening on, including port.
+func (r *Relay) Address() string {
+	return r.listener.Addr().String()
+}
+
+// Run relays telemetry data until the context is canceled, automatically reconnecting to the upstream telemetry
+// service if the connection is lost. All downstream clients are disconnected whenever the upstream connection is
+// re-established, so that they reset their state along with the relay.
+func (r *Relay) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	wg.Go(func() {
+		r.serve(ctx, &wg)
+	})
+	stop := context.AfterFunc(ctx, func() {
+		_ = r.listener.Close()
+		r.reset()
+	})
+	defer stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+			nextAttempt := time.Now().Add(r.client.retryInterval)
+			if err := r.read(ctx); err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				log.Error().Err(err).Msg("error reading upstream telemetry, retrying")
+				select {
+				case <-ctx.Done():
+				case <-time.After(time.Until(nextAttempt)):
+				}
+			}
+		}
+	}
+}
+
+// read relays lines from a single connection to the upstream telemetry service.
+func (r *Relay) read(ctx context.Context) error {
+	connection, err := r.client.connect()
+	if err != nil {
+		return fmt.Errorf("error connecting to telemetry service: %w", err)
+	}
+	defer connection.Close()
+	stop := context.AfterFunc(ctx, func() {
+		_ = connection.Close()
+	})
+	defer stop()
+
+	reader := bufio.NewReader(connection)
+	if _, err := r.client.handshake(reader, connection); err != nil {
+		return fmt.Errorf("error during client handhake: %w", err)
+	}
+	log.Info().Msg("connected to upstream telemetry service, resetting relay")
+	r.reset()
+
+	for {
+		if err := connection.SetReadDeadline(time.Now().Add(relayReadTimeout)); err != nil {
+			return fmt.Errorf("error setting read deadline: %w", err)
+		}
+		line, err := readACMILine(reader)
+		if err != nil {
+			return fmt.Errorf("error reading line: %w", err)
+		}
+		if err := r.broadcast(line); err != nil {
+			return fmt.Errorf("error relaying ACMI stream: %w", err)
+		}
+	}
+}
+
+// broadcast applies the line to the relay state and queues it for every downstream client.
+func (r *Relay) broadcast(line string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if err := r.state.apply(line); err != nil {
+		return err
+	}
+	if !strings.HasSuffix(line, "\n") {
+		line += "\n"
+	}
+	for d := range r.downstreams {
+		select {
+		case d.lines <- line:
+		default:
+			log.Warn().Str("hostname", d.hostname).Msg("downstream client fell behind, disconnecting")
+			r.drop(d)
+		}
+	}
+	return nil
+}
+
+// reset disconnects all downstream clients and clears the relay state.
+func (r *Relay) reset() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for d := range r.downstreams {
+		r.drop(d)
+	}
+	r.state = newRelayState()
+}
+
+// subscribe registers a new downstream client. The client's queue begins with a snapshot of the current state.
+func (r *Relay) subscribe(hostname string) *downstream {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	snapshot := r.state.snapshot()
+	d := &downstream{
+		hostname: hostname,
+		lines:    make(chan string, len(snapshot)+relayBufferSize),
+	}
+	for _, line := range snapshot {
+		d.lines <- line
+	}
+	r.downstreams[d] = struct{}{}
+	return d
+}
+
+// unsubscribe removes a downstream client, if it has not already been removed.
+func (r *Relay) unsubscribe(d *downstream) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.drop(d)
+}
+
+// drop removes a downstream client and closes its queue. The caller must hold the lock.
+func (r *Relay) drop(d *downstream) {
+	if _, ok := r.downstreams[d]; !ok {
+		return
+	}
+	delete(r.downstreams, d)
+	close(d.lines)
+}
+
+func (r *Relay) serve(ctx context.Context, wg *sync.WaitGroup) {
+	log.Info().Str("address", r.Address()).Msg("listening for downstream telemetry clients")
+	for {
+		conn, err := r.listener.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				log.Error().Err(err).Msg("error accepting downstream connection")
+			}
+			return
+		}
+		wg.Go(func() {
+			defer conn.Close()
+			stop := context.AfterFunc(ctx, func() {
+				_ = conn.Close()
+			})
+			defer stop()
+			if err := r.handle(conn); err != nil {
+				log.Debug().Err(err).Str("address", conn.RemoteAddr().String()).Msg("downstream connection ended")
+			}
+		})
+	}
+}
+
+// handle performs the handshake with a downstream client and then sends it the stream until either side disconnects.
+func (r *Relay) handle(conn net.Conn) error {
+	reader := bufio.NewReader(conn)
+	clientHandshake, err := r.handshake(reader, conn)
+	if err != nil {
+		return err
+	}
+	logger := log.With().Str("hostname", clientHandshake.Hostname).Str("address", conn.RemoteAddr().String()).Logger()
+	if !r.verify(clientHandshake) {
+		logger.Warn().Msg("downstream client provided incorrect password")
+		return errors.New("client provided incorrect password")
+	}
+	logger.Info().Msg("downstream client connected")
+	defer logger.Info().Msg("downstream client disconnected")
+
+	d := r.subscribe(clientHandshake.Hostname)
+	defer r.unsubscribe(d)
+	// Clients do not send anything after the handshake, so a completed read means the client disconnected.
+	go func() {
+		_, _ = io.Copy(io.Discard, reader)
+		r.unsubscribe(d)
+	}()
+	for line := range d.lines {
+		if _, err := io.WriteString(conn, line); err != nil {
+			return fmt.Errorf("error sending data: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *Relay) handshake(reader *bufio.Reader, conn net.Conn) (*ClientHandshake, error) {
+	hostHandshake := HostHandshake{
+		LowLevelProtocolVersion:  LowLevelProtocolVersion,
+		HighLevelProtocolVersion: HighLevelProtocolVersion,
+		Hostname:                 r.hostname,
+	}
+	if _, err := io.WriteString(conn, hostHandshake.Encode()); err != nil {
+		return nil, fmt.Errorf("error sending host handshake: %w", err)
+	}
+
+	if err := conn.SetReadDeadline(time.Now().Add(relayHandshakeTimeout)); err != nil {
+		return nil, fmt.Errorf("error setting read deadline: %w", err)
+	}
+	packet, err := reader.ReadString('\x00')
+	if err != nil {
+		return nil, fmt.Errorf("error reading client handshake: %w", err)
+	}
+	if err := conn.SetReadDeadline(time.Time{}); err != nil {
+		return nil, fmt.Errorf("error clearing read deadline: %w", err)
+	}
+
+	clientHandshake, err := DecodeClientHandshake(packet)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding client handshake: %w", err)
+	}
+	return clientHandshake, nil
+}
+
+func (r *Relay) verify(handshake *ClientHandshake) bool {
+	for _, password := range r.passwords {
+		if handshake.Verify(password, CRC64WE, CRC32ISOHDLC) {
+			return true
+		}
+	}
+	return false
+}
+
+// relayState is the accumulated state of a telemetry stream, from which a snapshot can be sent to clients which join
+// mid-stream.
+type relayState struct {
+	// header contains the file header lines.
+	header []string
+	// global contains the properties of the global object.
+	global map[string]string
+	// timeFrame is the most recent time frame line.
+	timeFrame string
+	// objects maps object IDs to their properties.
+	objects map[uint64]map[string]string
+}
+
+func newRelayState() *relayState {
+	return &relayState{
+		global:  make(map[string]string),
+		objects: make(map[uint64]map[string]string),
+	}
+}
+
+// apply updates the state with the given line.
+func (s *relayState) apply(line string) error {
+	line = strings.TrimSpace(line)
+	switch {
+	case line == "", strings.HasPrefix(line, "//"):
+		return nil
+	case strings.HasPrefix(line, properties.FileType+"="), strings.HasPrefix(line, properties.FileVersion+"="):
+		s.header = append(s.header, line)
+		return nil
+	case strings.HasPrefix(line, "#"):
+		s.timeFrame = line
+		return nil
+	}
+
+	update, err := parsing.ParseObjectUpdate(line)
+	if err != nil {
+		return fmt.Errorf("error parsing object update: %w", err)
+	}
+	if update.IsRemoval {
+		delete(s.objects, update.ID)
+		return nil
+	}
+	if update.ID == objects.GlobalObjectID {
+		for key, value := range update.Properties {
+			// Events are only relevant at the moment they occur.
+			if key != eventProperty {
+				s.global[key] = value
+			}
+		}
+		return nil
+	}
+	object, ok := s.objects[update.ID]
+	if !ok {
+		object = make(map[string]string)
+		s.objects[update.ID] = object
+	}
+	for key, value := range update.Properties {
+		if key == transformProperty {
+			value = mergeTransform(object[key], value)
+		}
+		object[key] = value
+	}
+	return nil
+}
+
+// snapshot returns lines which recreate the current state.
+func (s *relayState) snapshot() []string {
+	lines := make([]string, 0, len(s.header)+len(s.objects)+2)
+	for _, line := range s.header {
+		lines = append(lines, line+"\n")
+	}
+	if len(s.global) > 0 {
+		lines = append(lines, encodeObject(objects.GlobalObjectID, s.global))
+	}
+	if s.timeFrame != "" {
+		lines = append(lines, s.timeFrame+"\n")
+	}
+	for _, id := range slices.Sorted(maps.Keys(s.objects)) {
+		lines = append(lines, encodeObject(id, s.objects[id]))
+	}
+	return lines
+}
+
+// transformProperty is the name of the object property which contains the object's position and orientation.
+const transformProperty = "T"
+
+// mergeTransform applies a transform update to a previous transform. Empty components in the update are unchanged
+// since the previous transform.
+func mergeTransform(previous, update string) string {
+	previousComponents := strings.Split(previous, "|")
+	updateComponents := strings.Split(update, "|")
+	if len(previousComponents) != len(updateComponents) {
+		return update
+	}
+	for i, component := range updateComponents {
+		if component != "" {
+			previousComponents[i] = component
+		}
+	}
+	return strings.Join(previousComponents, "|")
+}
+
+// encodeObject encodes an object update line containing all the given properties.
+func encodeObject(id uint64, props map[string]string) string {
+	var sb strings.Builder
+	sb.WriteString(strconv.FormatUint(id, 16))
+	keys := slices.Sorted(maps.Keys(props))
+	// The transform is conventionally the first property.
+	if i := slices.Index(keys, transformProperty); i > 0 {
+		keys = slices.Insert(slices.Delete(keys, i, i+1), 0, transformProperty)
+	}
+	for _, key := range keys {
+		sb.WriteString(",")
+		sb.WriteString(key)
+		sb.WriteString("=")
+		sb.WriteString(strings.ReplaceAll(props[key], ",", "\\,"))
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
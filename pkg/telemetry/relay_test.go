@@ -0,0 +1,87 @@
+package telemetry_test
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/telemetry"
+	"github.com/dharmab/skyeye/pkg/telemetry/telemetrytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runRelay runs a relay against the given server until the test ends.
+func runRelay(t *testing.T, server *telemetrytest.Server, passwords ...string) *telemetry.Relay {
+	t.Helper()
+	relay, err := telemetry.NewRelay(server.Address(), "upstream", time.Second, "127.0.0.1:0", "relay", passwords)
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(t.Context())
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		assert.NoError(t, relay.Run(ctx))
+	})
+	t.Cleanup(func() {
+		cancel()
+		wg.Wait()
+		require.NoError(t, server.Close())
+	})
+	return relay
+}
+
+func TestRelay(t *testing.T) {
+	t.Parallel()
+	lines := append(slices.Clone(testLines), "1,T=0.5||")
+	server, err := telemetrytest.NewServer(telemetrytest.WithPassword("upstream"), telemetrytest.WithLines(lines...))
+	require.NoError(t, err)
+	relay := runRelay(t, server, "blue", "red")
+
+	expected := time.Date(2024, 6, 1, 12, 0, 10, 0, time.UTC)
+	first := runClientAt(t, relay.Address(), "blue")
+	expectStart(t, first)
+	require.Eventually(t, func() bool { return first.client.Time().Equal(expected) }, 5*time.Second, 10*time.Millisecond)
+
+	// A client which joins after the data was sent receives a snapshot of the current state.
+	second := runClientAt(t, relay.Address(), "red")
+	expectStart(t, second)
+	require.Eventually(t, func() bool { return second.client.Time().Equal(expected) }, 5*time.Second, 10*time.Millisecond)
+
+	for _, c := range []*testClient{first, second} {
+		require.EventuallyWithT(t, func(t *assert.CollectT) {
+			bullseye, err := c.client.Bullseye(coalitions.Blue)
+			require.NoError(t, err)
+			assert.InDelta(t, 30.5, bullseye.Lon(), 0.001)
+			assert.InDelta(t, 40, bullseye.Lat(), 0.001)
+		}, 5*time.Second, 10*time.Millisecond)
+	}
+	assert.Equal(t, []string{"relay"}, server.Accepted())
+}
+
+func TestRelayWrongPassword(t *testing.T) {
+	t.Parallel()
+	server, err := telemetrytest.NewServer(telemetrytest.WithPassword("upstream"), telemetrytest.WithLines(testLines...))
+	require.NoError(t, err)
+	relay := runRelay(t, server, "blue")
+
+	conn, err := net.Dial("tcp", relay.Address())
+	require.NoError(t, err)
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	_, err = reader.ReadString('\x00')
+	require.NoError(t, err)
+	handshake := telemetry.NewClientHandshake("intruder", "red")
+	_, err = io.WriteString(conn, handshake.Encode(telemetry.CRC64WE))
+	require.NoError(t, err)
+
+	// The relay disconnects the client without sending any data.
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Empty(t, data)
+}
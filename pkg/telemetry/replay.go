@@ -0,0 +1,186 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dharmab/goacmi/v2/parsing"
+	"github.com/rs/zerolog/log"
+)
+
+// ReplayOption configures the replay of an ACMI file.
+type ReplayOption func(*replayer)
+
+// WithReplayStart skips ahead to the given offset from the first time frame in the file. Data before the offset is
+// read as fast as possible, so that the radar picture is complete when the replay reaches the offset.
+func WithReplayStart(offset time.Duration) ReplayOption {
+	return func(r *replayer) {
+		r.start = offset
+	}
+}
+
+// WithReplayEnd pauses the replay at the given offset from the first time frame in the file, freezing the radar
+// picture at that moment. The replay may be resumed past the end.
+func WithReplayEnd(offset time.Duration) ReplayOption {
+	return func(r *replayer) {
+		r.end = offset
+	}
+}
+
+// WithReplayTimeScale sets the replay speed. 1 replays in real time, 10 replays ten times faster than real time, and
+// 0 replays as fast as possible.
+func WithReplayTimeScale(scale float64) ReplayOption {
+	return func(r *replayer) {
+		r.timeScale = scale
+	}
+}
+
+// replayer paces the time frames in an ACMI file.
+type replayer struct {
+	// start is the offset from the first time frame at which pacing begins.
+	start time.Duration
+	// end is the offset from the first time frame at which the replay pauses. Zero means the replay runs until the end
+	// of the file.
+	end time.Duration
+	// endReached is true once the replay has paused at the end offset.
+	endReached bool
+	// timeScale is the replay speed relative to real time. Zero or less means as fast as possible.
+	timeScale float64
+	// paused is true while the replay is paused.
+	paused bool
+
+	// firstFrame is the time frame offset of the first time frame in the file.
+	firstFrame time.Duration
+	// firstFrameSet is true once the first time frame has been read.
+	firstFrameSet bool
+	// anchorFrame and anchorTime relate a time frame to the wall clock time at which it was read. Later time frames
+	// are paced relative to the anchor. The anchor is reset whenever the replay speed or pause state changes.
+	anchorFrame time.Duration
+	anchorTime  time.Time
+	anchored    bool
+	// changed is closed and replaced whenever the replay speed or pause state changes, waking any pending wait.
+	changed chan struct{}
+	// lock protects all fields.
+	lock sync.Mutex
+}
+
+func newReplayer(opts ...ReplayOption) *replayer {
+	r := &replayer{changed: make(chan struct{})}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// pause pauses the replay at the next time frame.
+func (r *replayer) pause() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.paused = true
+	r.notify()
+}
+
+// resume resumes a paused replay.
+func (r *replayer) resume() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.paused = false
+	r.notify()
+}
+
+// isPaused returns true if the replay is paused.
+func (r *replayer) isPaused() bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.paused
+}
+
+// setTimeScale changes the replay speed.
+func (r *replayer) setTimeScale(scale float64) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.timeScale = scale
+	r.notify()
+}
+
+// notify resets the pacing anchor and wakes any pending wait. The caller must hold the lock.
+func (r *replayer) notify() {
+	r.anchored = false
+	close(r.changed)
+	r.changed = make(chan struct{})
+}
+
+// pace blocks until the given line is due to be read. Lines other than time frames are never delayed.
+func (r *replayer) pace(ctx context.Context, line string) error {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "#") {
+		return nil
+	}
+	frame, err := parsing.ParseTimeFrame(line)
+	if err != nil {
+		return fmt.Errorf("error parsing time frame: %w", err)
+	}
+	return r.wait(ctx, frame)
+}
+
+func (r *replayer) wait(ctx context.Context, frame time.Duration) error {
+	for {
+		r.lock.Lock()
+		if !r.firstFrameSet {
+			r.firstFrame = frame
+			r.firstFrameSet = true
+		}
+		elapsed := frame - r.firstFrame
+		if r.end > 0 && elapsed > r.end && !r.endReached {
+			log.Info().Stringer("offset", elapsed).Msg("reached end of replay, pausing")
+			r.endReached = true
+			r.paused = true
+			r.notify()
+		}
+		if elapsed < r.start {
+			r.lock.Unlock()
+			return nil
+		}
+		changed := r.changed
+		if r.paused {
+			r.lock.Unlock()
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-changed:
+				continue
+			}
+		}
+		if r.timeScale <= 0 {
+			r.lock.Unlock()
+			return nil
+		}
+		if !r.anchored {
+			r.anchorFrame = frame
+			r.anchorTime = time.Now()
+			r.anchored = true
+			r.lock.Unlock()
+			return nil
+		}
+		due := r.anchorTime.Add(time.Duration(float64(frame-r.anchorFrame) / r.timeScale))
+		r.lock.Unlock()
+
+		delay := time.Until(due)
+		if delay <= 0 {
+			return nil
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-changed:
+			timer.Stop()
+		case <-timer.C:
+			return nil
+		}
+	}
+}
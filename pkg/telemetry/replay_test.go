@@ -0,0 +1,114 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayerAsFastAsPossible(t *testing.T) {
+	t.Parallel()
+	r := newReplayer()
+	start := time.Now()
+	for _, line := range []string{"#0", "0,ReferenceTime=2024-06-01T12:00:00Z", "#3600", "#7200"} {
+		require.NoError(t, r.pace(t.Context(), line))
+	}
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestReplayerTimeScale(t *testing.T) {
+	t.Parallel()
+	r := newReplayer(WithReplayTimeScale(10))
+	require.NoError(t, r.pace(t.Context(), "#100"))
+	start := time.Now()
+	require.NoError(t, r.pace(t.Context(), "#101"))
+	assert.InDelta(t, 100*time.Millisecond, time.Since(start), float64(50*time.Millisecond))
+}
+
+func TestReplayerStartAndEnd(t *testing.T) {
+	t.Parallel()
+	r := newReplayer(WithReplayStart(time.Hour), WithReplayEnd(2*time.Hour), WithReplayTimeScale(1))
+	start := time.Now()
+	// Frames before the start are not paced.
+	require.NoError(t, r.pace(t.Context(), "#50"))
+	require.NoError(t, r.pace(t.Context(), "#1850"))
+	require.NoError(t, r.pace(t.Context(), "#3650"))
+	assert.Less(t, time.Since(start), time.Second)
+
+	// The replay pauses at the end.
+	done := make(chan error)
+	go func() {
+		done <- r.pace(t.Context(), "#7251")
+	}()
+	assert.Eventually(t, r.isPaused, time.Second, 5*time.Millisecond)
+	r.resume()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("resumed replay did not continue")
+	}
+}
+
+func TestReplayerPause(t *testing.T) {
+	t.Parallel()
+	r := newReplayer()
+	r.pause()
+	assert.True(t, r.isPaused())
+
+	done := make(chan error)
+	go func() {
+		done <- r.pace(t.Context(), "#1")
+	}()
+	select {
+	case <-done:
+		t.Fatal("paused replay did not block")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	r.resume()
+	assert.False(t, r.isPaused())
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("resumed replay did not continue")
+	}
+}
+
+func TestReplayerCanceled(t *testing.T) {
+	t.Parallel()
+	r := newReplayer()
+	r.pause()
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	require.ErrorIs(t, r.pace(ctx, "#1"), context.Canceled)
+}
+
+func TestReplayerSetTimeScale(t *testing.T) {
+	t.Parallel()
+	r := newReplayer(WithReplayTimeScale(1))
+	require.NoError(t, r.pace(t.Context(), "#100"))
+
+	done := make(chan error)
+	go func() {
+		done <- r.pace(t.Context(), "#3700")
+	}()
+	select {
+	case <-done:
+		t.Fatal("real time replay did not wait")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Speeding up the replay wakes the pending wait.
+	r.setTimeScale(0)
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("replay did not speed up")
+	}
+}
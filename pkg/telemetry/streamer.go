@@ -12,6 +12,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/dharmab/collections/sets"
 	"github.com/dharmab/goacmi/v2/objects"
 	"github.com/dharmab/goacmi/v2/parsing"
 	"github.com/dharmab/goacmi/v2/properties"
@@ -24,13 +25,17 @@ import (
 )
 
 type streamingClient struct {
-	starts chan sim.Started
-	fades  chan sim.Faded
+	starts   chan sim.Started
+	fades    chan sim.Faded
+	destroys chan sim.Destroyed
+	launches chan sim.Launched
 
 	// updateInterval is how often to send updates to the channels passed to Stream().
 	updateInterval time.Duration
 	// lastUpdateTime is the time that the last update was read.
 	lastUpdateTime time.Time
+	// replay paces the data when replaying a file. It is nil for real-time telemetry.
+	replay *replayer
 
 	// referenceTime is the reference point provided in the ACMI data.
 	referenceTime time.Time
@@ -42,12 +47,17 @@ type streamingClient struct {
 	referencePoint orb.Point
 	// cursorTime is the current frame time, computed by adding the current time frame to the reference time.
 	cursorTime time.Time
+	// timeFrames is the number of time frames received since the stream started. The objects in the first time frame
+	// are the initial state of the stream, such as the state sent by a server to a client which connects mid-mission.
+	timeFrames int
 
 	// state maps object IDs to statuses.
 	state map[uint64]*objects.Object
 	// bullseyesIdx maps coalitions to bullseye object IDs.
 	bullseyesIdx map[coalitions.Coalition]uint64
-	// lock protects state and bullseyesIdx.
+	// launchedIdx contains the IDs of missiles whose launch has been published.
+	launchedIdx sets.Set[uint64]
+	// lock protects timeFrames, state, bullseyesIdx, and launchedIdx.
 	lock sync.RWMutex
 }
 
@@ -57,13 +67,15 @@ func newStreamingClient(
 	c := &streamingClient{
 		starts:         make(chan sim.Started),
 		fades:          make(chan sim.Faded),
+		destroys:       make(chan sim.Destroyed),
+		launches:       make(chan sim.Launched),
 		updateInterval: updateInterval,
 	}
 	c.reset()
 	return c
 }
 
-func (c *streamingClient) Stream(ctx context.Context, wg *sync.WaitGroup, starts chan<- sim.Started, updates chan<- sim.Updated, fades chan<- sim.Faded) {
+func (c *streamingClient) Stream(ctx context.Context, wg *sync.WaitGroup, starts chan<- sim.Started, updates chan<- sim.Updated, fades chan<- sim.Faded, destroys chan<- sim.Destroyed, launches chan<- sim.Launched) {
 	ticker := time.NewTicker(c.updateInterval)
 	defer ticker.Stop()
 	wg.Go(func() {
@@ -88,6 +100,28 @@ func (c *streamingClient) Stream(ctx context.Context, wg *sync.WaitGroup, starts
 		}
 	})
 
+	wg.Go(func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case destroy := <-c.destroys:
+				destroys <- destroy
+			}
+		}
+	})
+
+	wg.Go(func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case launch := <-c.launches:
+				launches <- launch
+			}
+		}
+	})
+
 	wg.Go(func() {
 		for {
 			select {
@@ -136,13 +170,14 @@ func (c *streamingClient) collectUpdates() []sim.Updated {
 	result := make([]sim.Updated, 0, len(c.state))
 	for _, object := range c.state {
 		logger := log.With().Uint64("id", object.ID).Logger()
-		// Only collect updates for aircraft.
+		// Only collect updates for aircraft and sea and ground objects.
 		taglist, err := object.GetTypes()
 		if err != nil {
 			logger.Error().Err(err).Msg("error getting object types")
 			continue
 		}
-		if !isAircraft(taglist) {
+		class, ok := objectClass(taglist)
+		if !ok {
 			continue
 		}
 		logger = logger.With().Strs("tags", taglist).Logger()
@@ -154,7 +189,7 @@ func (c *streamingClient) collectUpdates() []sim.Updated {
 		}
 		logger = logger.With().Str("callsign", name).Logger()
 
-		coordinates, err := object.GetCoordinates(c.referencePoint.Lon(), c.referencePoint.Lat())
+		frame, err := c.objectFrame(object)
 		if err != nil {
 			logger.Error().Err(err).Msg("error getting object coordinates")
 			continue
@@ -173,23 +208,7 @@ func (c *streamingClient) collectUpdates() []sim.Updated {
 			continue
 		}
 		coalition := propertyToCoalition(acmiCoalition)
-
-		frame := trackfiles.Frame{
-			Time: c.cursorTime,
-			Point: orb.Point{
-				*coordinates.Longitude,
-				*coordinates.Latitude,
-			},
-		}
-		if coordinates.Altitude != nil {
-			frame.Altitude = *coordinates.Altitude
-		}
-		if coordinates.Heading != nil {
-			frame.Heading = *coordinates.Heading
-		}
-		if agl, err := object.GetLength(properties.AGL); err == nil {
-			frame.AGL = &agl
-		}
+		group, _ := object.GetProperty(groupProperty)
 
 		result = append(result, sim.Updated{
 			Labels: trackfiles.Labels{
@@ -197,6 +216,8 @@ func (c *streamingClient) collectUpdates() []sim.Updated {
 				Name:      callsign,
 				Coalition: coalition,
 				ACMIName:  name,
+				Class:     class,
+				Group:     group,
 			},
 			Frame: frame,
 		})
@@ -204,11 +225,46 @@ func (c *streamingClient) collectUpdates() []sim.Updated {
 	return result
 }
 
+// objectFrame returns the object's current position data. The caller must hold the lock.
+func (c *streamingClient) objectFrame(object *objects.Object) (trackfiles.Frame, error) {
+	coordinates, err := object.GetCoordinates(c.referencePoint.Lon(), c.referencePoint.Lat())
+	if err != nil {
+		return trackfiles.Frame{}, err
+	}
+	frame := trackfiles.Frame{
+		Time: c.cursorTime,
+		Point: orb.Point{
+			*coordinates.Longitude,
+			*coordinates.Latitude,
+		},
+	}
+	if coordinates.Altitude != nil {
+		frame.Altitude = *coordinates.Altitude
+	}
+	if coordinates.Heading != nil {
+		frame.Heading = *coordinates.Heading
+	}
+	frame.Pitch = coordinates.Pitch
+	frame.Roll = coordinates.Roll
+	if agl, err := object.GetLength(properties.AGL); err == nil {
+		frame.AGL = &agl
+	}
+	frame.IAS = getSpeed(object, iasProperty)
+	frame.TAS = getSpeed(object, tasProperty)
+	frame.Mach = getNumber(object, machProperty)
+	frame.VerticalSpeed = getSpeed(object, verticalSpeedProperty)
+	return frame, nil
+}
+
 func (c *streamingClient) handleLines(ctx context.Context, reader *bufio.Reader) error {
 	log.Info().Msg("resetting ACMI client state")
 	c.reset()
 	log.Info().Msg("sending mission start message")
-	c.starts <- sim.Started{}
+	select {
+	case c.starts <- sim.Started{}:
+	case <-ctx.Done():
+		return nil
+	}
 
 	// result holds a line read from the ACMI stream or an error from the reader goroutine.
 	type result struct {
@@ -256,6 +312,16 @@ func (c *streamingClient) handleLines(ctx context.Context, reader *bufio.Reader)
 				}
 				return fmt.Errorf("error reading line: %w", r.err)
 			}
+			if c.replay != nil {
+				if err := c.replay.pace(ctx, r.line); err != nil {
+					if errors.Is(err, context.Canceled) {
+						return nil
+					}
+					return err
+				}
+				// A paused or slowed replay is not a loss of telemetry.
+				c.lastUpdateTime = time.Now()
+			}
 			if err := c.handleLine(r.line); err != nil {
 				return fmt.Errorf("error reading ACMI stream: %w", err)
 			}
@@ -317,6 +383,9 @@ func (c *streamingClient) handleLine(line string) error {
 		if err := c.updateGlobalObject(update); err != nil {
 			return fmt.Errorf("error updating global object: %w", err)
 		}
+		if event, ok := update.Properties[eventProperty]; ok {
+			c.handleEvent(event)
+		}
 	} else {
 		if err := c.updateObject(update); err != nil {
 			return fmt.Errorf("error updating object: %w", err)
@@ -341,6 +410,7 @@ func (c *streamingClient) handleTimeFrame(line string) error {
 		return errors.New("time frame received before reference time")
 	}
 	c.cursorTime = c.referenceTime.Add(offset)
+	c.timeFrames++
 	return nil
 }
 
@@ -388,6 +458,10 @@ func (c *streamingClient) updateObject(update *objects.Update) error {
 	}
 	if fade != nil {
 		c.fades <- *fade
+		return nil
+	}
+	if launch := c.collectLaunch(update.ID); launch != nil {
+		c.launches <- *launch
 	}
 	return nil
 }
@@ -441,6 +515,7 @@ func (c *streamingClient) applyObjectUpdate(update *objects.Update) (*sim.Faded,
 
 	if update.IsRemoval {
 		delete(c.state, object.ID)
+		sets.Remove(c.launchedIdx, object.ID)
 		if isRelevantObject(taglist) {
 			logger.Info().Msg("recording object removal")
 		}
@@ -458,8 +533,10 @@ func (c *streamingClient) reset() {
 	c.referenceTimeSet = false
 	c.referencePoint = orb.Point{}
 	c.cursorTime = time.Time{}
+	c.timeFrames = 0
 	c.state = map[uint64]*objects.Object{}
 	c.bullseyesIdx = map[coalitions.Coalition]uint64{}
+	c.launchedIdx = sets.New[uint64]()
 }
 
 func isAircraft(taglist []string) bool {
@@ -471,5 +548,5 @@ func isBullseye(taglist []string) bool {
 }
 
 func isRelevantObject(taglist []string) bool {
-	return isAircraft(taglist) || isBullseye(taglist)
+	return isAircraft(taglist) || isSurface(taglist) || isBullseye(taglist) || isMissile(taglist)
 }
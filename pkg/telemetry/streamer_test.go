@@ -2,11 +2,14 @@ package telemetry
 
 import (
 	"bufio"
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/dharmab/goacmi/v2/parsing"
+	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -85,3 +88,233 @@ func TestHandleLineAcceptsContinuationRecordBeforeNextObject(t *testing.T) {
 
 	assert.Equal(t, time.Date(1989, 9, 13, 10, 8, 31, 0, time.UTC), client.Time())
 }
+
+func TestParseEvent(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		value     string
+		eventType string
+		ids       []uint64
+	}{
+		{value: "Destroyed|11c05|", eventType: "Destroyed", ids: []uint64{0x11c05}},
+		{value: "Destroyed|102", eventType: "Destroyed", ids: []uint64{0x102}},
+		{value: "TakenOff|a01|b02|Airbase", eventType: "TakenOff", ids: []uint64{0xa01, 0xb02}},
+		{value: "Destroyed|a01|b02", eventType: "Destroyed", ids: []uint64{0xa01, 0xb02}},
+		{value: "Destroyed|a01|b02|", eventType: "Destroyed", ids: []uint64{0xa01, 0xb02}},
+		{value: "Message||Hello", eventType: "Message", ids: []uint64{}},
+	}
+	for _, test := range testCases {
+		t.Run(test.value, func(t *testing.T) {
+			t.Parallel()
+			eventType, ids, err := parseEvent(test.value)
+			require.NoError(t, err)
+			assert.Equal(t, test.eventType, eventType)
+			assert.Equal(t, test.ids, ids)
+		})
+	}
+
+	_, _, err := parseEvent("")
+	require.Error(t, err)
+	_, _, err = parseEvent("Destroyed|xyz|")
+	require.Error(t, err)
+}
+
+func TestCollectUpdatesIncludesFlightData(t *testing.T) {
+	t.Parallel()
+
+	client := newStreamingClient(time.Second)
+	for _, line := range []string{
+		"FileType=text/acmi/tacview",
+		"FileVersion=2.2",
+		"0,ReferenceTime=2024-06-01T12:00:00Z",
+		"0,ReferenceLongitude=30",
+		"0,ReferenceLatitude=40",
+		"#10",
+		"102,T=0.1|0.2|6000|-30|5|270|||,Type=Air+FixedWing,Name=F-16C_50,Pilot=Viper 1,Coalition=Enemies,Group=Viper,IAS=180,TAS=250,Mach=0.79,VerticalSpeed=-10",
+		"103,T=0.3|0.2|6000,Type=Air+FixedWing,Name=Su-27,Pilot=Bandit 1,Coalition=Allies",
+	} {
+		require.NoError(t, client.handleLine(line))
+	}
+
+	updates := client.collectUpdates()
+	require.Len(t, updates, 2)
+	for _, update := range updates {
+		frame := update.Frame
+		switch update.Labels.ID {
+		case 0x102:
+			assert.Equal(t, "Viper", update.Labels.Group)
+			require.NotNil(t, frame.IAS)
+			assert.InDelta(t, 180, frame.IAS.MetersPerSecond(), 0.01)
+			require.NotNil(t, frame.TAS)
+			assert.InDelta(t, 250, frame.TAS.MetersPerSecond(), 0.01)
+			require.NotNil(t, frame.Mach)
+			assert.InDelta(t, 0.79, *frame.Mach, 0.001)
+			require.NotNil(t, frame.VerticalSpeed)
+			assert.InDelta(t, -10, frame.VerticalSpeed.MetersPerSecond(), 0.01)
+			require.NotNil(t, frame.Roll)
+			assert.InDelta(t, -30, frame.Roll.Degrees(), 0.01)
+			require.NotNil(t, frame.Pitch)
+			assert.InDelta(t, 5, frame.Pitch.Degrees(), 0.01)
+		case 0x103:
+			assert.Empty(t, update.Labels.Group)
+			assert.Nil(t, frame.IAS)
+			assert.Nil(t, frame.TAS)
+			assert.Nil(t, frame.Mach)
+			assert.Nil(t, frame.VerticalSpeed)
+			assert.Nil(t, frame.Pitch)
+			assert.Nil(t, frame.Roll)
+		default:
+			t.Fatalf("unexpected update for object %d", update.Labels.ID)
+		}
+	}
+}
+
+func TestCollectUpdatesIncludesSurfaceObjects(t *testing.T) {
+	t.Parallel()
+
+	client := newStreamingClient(time.Second)
+	for _, line := range []string{
+		"FileType=text/acmi/tacview",
+		"FileVersion=2.2",
+		"0,ReferenceTime=2024-06-01T12:00:00Z",
+		"0,ReferenceLongitude=30",
+		"0,ReferenceLatitude=40",
+		"#10",
+		"201,T=0.1|0.2|0,Type=Sea+Watercraft,Name=CVN_71,Coalition=Enemies",
+		"202,T=0.3|0.2|100,Type=Ground+AntiAircraft,Name=SA-11 Buk LN 9A310M1,Coalition=Allies",
+		"203,T=0.3|0.3|100,Type=Ground+Heavy+Armor+Vehicle+Tank,Name=T-72B,Coalition=Allies",
+		"204,T=0.3|0.4|100,Type=Ground+Static+Building,Name=Hangar,Coalition=Allies",
+	} {
+		require.NoError(t, client.handleLine(line))
+	}
+
+	classes := make(map[uint64]trackfiles.Class)
+	for _, update := range client.collectUpdates() {
+		classes[update.Labels.ID] = update.Labels.Class
+	}
+	assert.Equal(t, map[uint64]trackfiles.Class{
+		0x201: trackfiles.Watercraft,
+		0x202: trackfiles.AirDefense,
+		0x203: trackfiles.GroundVehicle,
+	}, classes)
+}
+
+func TestParseObjectID(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		value string
+		id    uint64
+		ok    bool
+	}{
+		{value: "102", id: 0x102, ok: true},
+		{value: "11c05", id: 0x11c05, ok: true},
+		{value: "A01", id: 0xa01, ok: true},
+		{value: "", ok: false},
+		{value: "xyz", ok: false},
+		{value: "-1", ok: false},
+	}
+	for _, test := range testCases {
+		t.Run(test.value, func(t *testing.T) {
+			t.Parallel()
+			id, err := parseObjectID(test.value)
+			if !test.ok {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.id, id)
+		})
+	}
+}
+
+func TestCollectLaunch(t *testing.T) {
+	t.Parallel()
+	header := []string{
+		"FileType=text/acmi/tacview",
+		"FileVersion=2.2",
+		"0,ReferenceTime=2024-06-01T12:00:00Z",
+		"0,ReferenceLongitude=30",
+		"0,ReferenceLatitude=40",
+		"#0",
+		"102,T=0.1|0.2|6000,Type=Air+FixedWing,Name=Su-27,Pilot=Bandit 1,Coalition=Allies",
+		"103,T=0.3|0.2|6000,Type=Air+FixedWing,Name=F-16C_50,Pilot=Viper 1,Coalition=Enemies",
+	}
+	testCases := []struct {
+		name     string
+		lines    []string
+		launched bool
+		launcher uint64
+		target   uint64
+	}{
+		{
+			name:     "parent and locked target",
+			lines:    []string{"#1", "201,T=0.1|0.2|6000,Type=Weapon+Missile,Name=R_77,Coalition=Allies,Parent=102,LockedTarget=103"},
+			launched: true,
+			launcher: 0x102,
+			target:   0x103,
+		},
+		{
+			name:     "launcher's locked target",
+			lines:    []string{"102,LockedTarget=103", "#1", "201,T=0.1|0.2|6000,Type=Weapon+Missile,Name=R_77,Coalition=Allies,Parent=102"},
+			launched: true,
+			launcher: 0x102,
+			target:   0x103,
+		},
+		{
+			name:     "no parent or target",
+			lines:    []string{"#1", "201,T=0.1|0.2|6000,Type=Weapon+Missile,Name=R_77,Coalition=Allies"},
+			launched: true,
+		},
+		{
+			name:     "malformed parent",
+			lines:    []string{"#1", "201,T=0.1|0.2|6000,Type=Weapon+Missile,Name=R_77,Coalition=Allies,Parent=xyz"},
+			launched: true,
+		},
+		{
+			name:  "not a missile",
+			lines: []string{"#1", "201,T=0.1|0.2|6000,Type=Weapon+Bomb,Name=FAB_500,Coalition=Allies,Parent=102"},
+		},
+		{
+			name:  "missing coalition",
+			lines: []string{"#1", "201,T=0.1|0.2|6000,Type=Weapon+Missile,Name=R_77,Parent=102"},
+		},
+		{
+			name:  "in flight in the initial state",
+			lines: []string{"201,T=0.1|0.2|6000,Type=Weapon+Missile,Name=R_77,Coalition=Allies,Parent=102", "#1", "201,T=0.2|0.2|6000"},
+		},
+		{
+			name: "reported once",
+			lines: []string{
+				"#1",
+				"201,T=0.1|0.2|6000,Type=Weapon+Missile,Name=R_77,Coalition=Allies,Parent=102",
+				"#2",
+				"201,T=0.2|0.2|6000",
+			},
+			launched: true,
+			launcher: 0x102,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			client := newStreamingClient(time.Second)
+			client.launches = make(chan sim.Launched, 8)
+			for _, line := range append(slices.Clone(header), test.lines...) {
+				require.NoError(t, client.handleLine(line))
+			}
+			close(client.launches)
+			launches := make([]sim.Launched, 0)
+			for launch := range client.launches {
+				launches = append(launches, launch)
+			}
+			if !test.launched {
+				assert.Empty(t, launches)
+				return
+			}
+			require.Len(t, launches, 1)
+			assert.Equal(t, uint64(0x201), launches[0].Labels.ID)
+			assert.Equal(t, test.launcher, launches[0].LauncherID)
+			assert.Equal(t, test.target, launches[0].TargetID)
+		})
+	}
+}
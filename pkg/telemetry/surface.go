@@ -0,0 +1,43 @@
+package telemetry
+
+import (
+	"slices"
+
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+)
+
+// Sea and ground object tags.
+// Reference: https://www.tacview.net/documentation/acmi/en/ (Object Types section)
+const (
+	watercraftTag   = "Watercraft"
+	antiAircraftTag = "AntiAircraft"
+	vehicleTag      = "Vehicle"
+)
+
+func isSurface(taglist []string) bool {
+	_, ok := surfaceClass(taglist)
+	return ok
+}
+
+// surfaceClass returns the trackfile class of a sea or ground object, or false if the object is not a tracked sea or
+// ground object. Buildings and other static objects are not tracked.
+func surfaceClass(taglist []string) (trackfiles.Class, bool) {
+	switch {
+	case slices.Contains(taglist, watercraftTag):
+		return trackfiles.Watercraft, true
+	case slices.Contains(taglist, antiAircraftTag):
+		return trackfiles.AirDefense, true
+	case slices.Contains(taglist, vehicleTag):
+		return trackfiles.GroundVehicle, true
+	default:
+		return trackfiles.Aircraft, false
+	}
+}
+
+// objectClass returns the trackfile class of the object, or false if the object is not tracked.
+func objectClass(taglist []string) (trackfiles.Class, bool) {
+	if isAircraft(taglist) {
+		return trackfiles.Aircraft, true
+	}
+	return surfaceClass(taglist)
+}
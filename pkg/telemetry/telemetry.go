@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"time"
 
@@ -24,25 +25,34 @@ type RealTimeClient struct {
 	password string
 	// connectionTimeout is the maximum time to wait for a connection to be established.
 	connectionTimeout time.Duration
-	hashAlgorithm     HashAlgorithm
+	// retryInterval is the minimum time between connection attempts.
+	retryInterval time.Duration
+	hashAlgorithm HashAlgorithm
+	// recordingDirectory is the directory in which to record the telemetry stream. If empty, the stream is not
+	// recorded.
+	recordingDirectory string
 }
 
 // NewRealTimeClient creates a new telemetry client for reading real-time
-// telemetry data.
+// telemetry data. If recordingDirectory is not empty, each connection to the
+// telemetry service is recorded to a new ACMI file in that directory.
 func NewRealTimeClient(
 	address,
 	clientHostname,
 	password string,
 	connectionTimeout time.Duration,
 	updateInterval time.Duration,
+	recordingDirectory string,
 ) *RealTimeClient {
 	return &RealTimeClient{
-		streamingClient:   *newStreamingClient(updateInterval),
-		address:           address,
-		hostname:          clientHostname,
-		password:          password,
-		connectionTimeout: connectionTimeout,
-		hashAlgorithm:     CRC64WE,
+		streamingClient:    *newStreamingClient(updateInterval),
+		address:            address,
+		hostname:           clientHostname,
+		password:           password,
+		connectionTimeout:  connectionTimeout,
+		retryInterval:      10 * time.Second,
+		hashAlgorithm:      CRC64WE,
+		recordingDirectory: recordingDirectory,
 	}
 }
 
@@ -55,7 +65,7 @@ func (c *RealTimeClient) Run(ctx context.Context) error {
 		case <-ctx.Done():
 			return nil
 		default:
-			nextAttempt := time.Now().Add(10 * time.Second)
+			nextAttempt := time.Now().Add(c.retryInterval)
 			if err := c.read(ctx); err != nil {
 				if errors.Is(err, context.Canceled) {
 					return nil
@@ -78,10 +88,23 @@ func (c *RealTimeClient) read(ctx context.Context) error {
 
 	reader := bufio.NewReader(connection)
 
-	if err := c.handshake(reader, connection); err != nil {
+	hostHandshake, err := c.handshake(reader, connection)
+	if err != nil {
 		return fmt.Errorf("error during client handhake: %w", err)
 	}
 
+	if c.recordingDirectory != "" {
+		rec := newRecording(c.recordingDirectory, hostHandshake)
+		defer func() {
+			if err := rec.Close(); err != nil {
+				log.Error().Err(err).Msg("error finalizing telemetry recording")
+			}
+		}()
+		// Everything read after the handshake is copied to the recording. The recording file is created when the
+		// first data arrives, so a rejected handshake does not leave an empty recording.
+		reader = bufio.NewReader(io.TeeReader(reader, rec))
+	}
+
 	if err := c.handleLines(ctx, reader); err != nil {
 		return fmt.Errorf("error reading updates: %w", err)
 	}
@@ -97,16 +120,16 @@ func (c *RealTimeClient) connect() (net.Conn, error) {
 	return connection, nil
 }
 
-func (c *RealTimeClient) handshake(reader *bufio.Reader, connection net.Conn) error {
+func (c *RealTimeClient) handshake(reader *bufio.Reader, connection net.Conn) (HostHandshake, error) {
 	hostHandshakePacket, err := reader.ReadString('\x00')
 	if err != nil {
-		return fmt.Errorf("error reading host handshake: %w", err)
+		return HostHandshake{}, fmt.Errorf("error reading host handshake: %w", err)
 	}
 
 	hostHandshake, err := DecodeHostHandshake(hostHandshakePacket)
 	if err != nil {
 		log.Debug().Str("packet", hostHandshakePacket).Msg("error decoding host handshake")
-		return fmt.Errorf("error decoding host handshake: %w", err)
+		return HostHandshake{}, fmt.Errorf("error decoding host handshake: %w", err)
 	}
 	log.Info().
 		Str("hostname", hostHandshake.Hostname).
@@ -129,8 +152,8 @@ func (c *RealTimeClient) handshake(reader *bufio.Reader, connection net.Conn) er
 		Msg("sending client handshake")
 	_, err = connection.Write([]byte(clientHandshake.Encode(c.hashAlgorithm)))
 	if err != nil {
-		return fmt.Errorf("error sending client handshake: %w", err)
+		return HostHandshake{}, fmt.Errorf("error sending client handshake: %w", err)
 	}
 
-	return nil
+	return hostHandshake, nil
 }
@@ -0,0 +1,161 @@
+package telemetry_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/dharmab/skyeye/pkg/telemetry"
+	"github.com/dharmab/skyeye/pkg/telemetry/telemetrytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testLines = []string{
+	"FileType=text/acmi/tacview",
+	"FileVersion=2.2",
+	"0,ReferenceTime=2024-06-01T12:00:00Z",
+	"0,ReferenceLongitude=30",
+	"0,ReferenceLatitude=40",
+	"#0",
+	"1,T=0|0|0,Type=Navaid+Static+Bullseye,Coalition=Enemies,Color=Blue",
+	"#10",
+}
+
+type testClient struct {
+	client *telemetry.RealTimeClient
+	starts chan sim.Started
+}
+
+// runClient runs a real-time client against the given server until the test ends.
+func runClient(t *testing.T, server *telemetrytest.Server, password string) *testClient {
+	t.Helper()
+	c := runClientAt(t, server.Address(), password)
+	t.Cleanup(func() {
+		require.NoError(t, server.Close())
+	})
+	return c
+}
+
+// runClientAt runs a real-time client against the telemetry service at the given address until the test ends.
+func runClientAt(t *testing.T, address string, password string) *testClient {
+	t.Helper()
+	ctx, cancel := context.WithCancel(t.Context())
+	client := telemetry.NewRealTimeClient(address, "skyeye", password, time.Second, 50*time.Millisecond, "")
+	telemetry.SetRetryInterval(client, 10*time.Millisecond)
+	starts := make(chan sim.Started, 10)
+	updates := make(chan sim.Updated, 10)
+
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		_ = client.Run(ctx)
+	})
+	wg.Go(func() {
+		client.Stream(ctx, &wg, starts, updates, make(chan sim.Faded), make(chan sim.Destroyed), make(chan sim.Launched))
+	})
+	wg.Go(func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-updates:
+			}
+		}
+	})
+	t.Cleanup(func() {
+		cancel()
+		wg.Wait()
+	})
+	return &testClient{client: client, starts: starts}
+}
+
+func expectStart(t *testing.T, c *testClient) {
+	t.Helper()
+	select {
+	case <-c.starts:
+	case <-time.After(5 * time.Second):
+		t.Fatal("client did not start a mission")
+	}
+}
+
+func TestRealTimeClient(t *testing.T) {
+	t.Parallel()
+	server, err := telemetrytest.NewServer(telemetrytest.WithPassword("local"), telemetrytest.WithLines(testLines...))
+	require.NoError(t, err)
+	c := runClient(t, server, "local")
+
+	expectStart(t, c)
+	expected := time.Date(2024, 6, 1, 12, 0, 10, 0, time.UTC)
+	require.Eventually(t, func() bool { return c.client.Time().Equal(expected) }, 5*time.Second, 10*time.Millisecond)
+	bullseye, err := c.client.Bullseye(coalitions.Blue)
+	require.NoError(t, err)
+	assert.InDelta(t, 30, bullseye.Lon(), 0.001)
+	assert.InDelta(t, 40, bullseye.Lat(), 0.001)
+	assert.Equal(t, []string{"skyeye"}, server.Accepted())
+	assert.Zero(t, server.Rejected())
+}
+
+func TestRealTimeClientPasswordNegotiation(t *testing.T) {
+	t.Parallel()
+	// The client alternates hash algorithms between attempts, starting with CRC32ISOHDLC.
+	testCases := []struct {
+		algorithm  telemetry.HashAlgorithm
+		rejections int
+	}{
+		{algorithm: telemetry.CRC32ISOHDLC, rejections: 0},
+		{algorithm: telemetry.CRC64WE, rejections: 1},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.algorithm.String(), func(t *testing.T) {
+			t.Parallel()
+			server, err := telemetrytest.NewServer(
+				telemetrytest.WithPassword("local"),
+				telemetrytest.WithHashAlgorithms(tc.algorithm),
+				telemetrytest.WithLines(testLines...),
+			)
+			require.NoError(t, err)
+			_ = runClient(t, server, "local")
+
+			require.Eventually(t, func() bool { return len(server.Accepted()) == 1 }, 5*time.Second, 10*time.Millisecond)
+			assert.Equal(t, tc.rejections, server.Rejected())
+		})
+	}
+}
+
+func TestRealTimeClientWrongPassword(t *testing.T) {
+	t.Parallel()
+	server, err := telemetrytest.NewServer(telemetrytest.WithPassword("local"), telemetrytest.WithLines(testLines...))
+	require.NoError(t, err)
+	_ = runClient(t, server, "remote")
+
+	assert.Eventually(t, func() bool { return server.Rejected() >= 2 }, 5*time.Second, 10*time.Millisecond)
+	assert.Empty(t, server.Accepted())
+}
+
+func TestRealTimeClientReconnects(t *testing.T) {
+	t.Parallel()
+	server, err := telemetrytest.NewServer(telemetrytest.WithLines(testLines...))
+	require.NoError(t, err)
+	c := runClient(t, server, "")
+
+	expectStart(t, c)
+	require.Eventually(t, func() bool { return len(server.Accepted()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	server.Disconnect()
+	// The client reconnects and starts a new mission.
+	expectStart(t, c)
+	assert.Eventually(t, func() bool { return len(server.Accepted()) == 2 }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestRealTimeClientMidStreamDisconnect(t *testing.T) {
+	t.Parallel()
+	server, err := telemetrytest.NewServer(telemetrytest.WithLines(testLines...), telemetrytest.WithDisconnectAfter(4))
+	require.NoError(t, err)
+	c := runClient(t, server, "")
+
+	expectStart(t, c)
+	expectStart(t, c)
+	assert.Eventually(t, func() bool { return len(server.Accepted()) >= 2 }, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,268 @@
+// Package telemetrytest provides a stand-in for a real-time telemetry server, for use in tests.
+package telemetrytest
+
+import (
+	"archive/zip"
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/dharmab/skyeye/pkg/telemetry"
+	"github.com/rs/zerolog/log"
+)
+
+// Server serves ACMI data over TCP using the real-time telemetry protocol. Each client which completes the handshake
+// receives the same data from the beginning. After the data is sent, the connection is held open until the client
+// disconnects, the server disconnects it, or the server is closed.
+type Server struct {
+	listener net.Listener
+	// hostname is sent in the host handshake.
+	hostname string
+	// password which clients must provide.
+	password string
+	// algorithms contains the password hash algorithms which the server accepts.
+	algorithms []telemetry.HashAlgorithm
+	// filePath is the path to an ACMI file to serve.
+	filePath string
+	// data is the ACMI data to serve.
+	data string
+	// disconnectAfter is the number of lines after which each client is disconnected. Zero means clients are never
+	// disconnected by the server.
+	disconnectAfter int
+
+	// connections contains the open client connections.
+	connections map[net.Conn]struct{}
+	// accepted is the hostnames of clients which completed the handshake, in order.
+	accepted []string
+	// rejected is the number of clients which failed the handshake.
+	rejected int
+	// lock protects connections, accepted and rejected.
+	lock sync.Mutex
+	wg   sync.WaitGroup
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithHostname sets the hostname sent in the host handshake.
+func WithHostname(hostname string) Option {
+	return func(s *Server) {
+		s.hostname = hostname
+	}
+}
+
+// WithPassword sets the password which clients must provide.
+func WithPassword(password string) Option {
+	return func(s *Server) {
+		s.password = password
+	}
+}
+
+// WithHashAlgorithms restricts the password hash algorithms which the server accepts. By default, both CRC64WE and
+// CRC32ISOHDLC are accepted.
+func WithHashAlgorithms(algorithms ...telemetry.HashAlgorithm) Option {
+	return func(s *Server) {
+		s.algorithms = algorithms
+	}
+}
+
+// WithFile serves the contents of the given ACMI file. Both text and ZIP compressed files are supported.
+func WithFile(path string) Option {
+	return func(s *Server) {
+		s.filePath = path
+	}
+}
+
+// WithLines serves the given lines of ACMI data.
+func WithLines(lines ...string) Option {
+	return func(s *Server) {
+		s.data = strings.Join(lines, "\n") + "\n"
+	}
+}
+
+// WithDisconnectAfter disconnects each client after sending the given number of lines, simulating a connection lost
+// mid-stream.
+func WithDisconnectAfter(lines int) Option {
+	return func(s *Server) {
+		s.disconnectAfter = lines
+	}
+}
+
+// NewServer starts a server listening on a random local port.
+func NewServer(opts ...Option) (*Server, error) {
+	s := &Server{
+		hostname:    "telemetrytest",
+		algorithms:  []telemetry.HashAlgorithm{telemetry.CRC64WE, telemetry.CRC32ISOHDLC},
+		connections: make(map[net.Conn]struct{}),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.filePath != "" {
+		data, err := readFile(s.filePath)
+		if err != nil {
+			return nil, err
+		}
+		s.data = data
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen: %w", err)
+	}
+	s.listener = listener
+	s.wg.Go(s.serve)
+	return s, nil
+}
+
+// readFile reads the ACMI data from the given text or ZIP compressed file.
+func readFile(path string) (string, error) {
+	archive, err := zip.OpenReader(path)
+	if errors.Is(err, zip.ErrFormat) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read ACMI file: %w", err)
+		}
+		return string(data), nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to open ACMI file: %w", err)
+	}
+	defer archive.Close()
+	for _, f := range archive.File {
+		if !strings.HasSuffix(f.Name, ".txt.acmi") {
+			continue
+		}
+		reader, err := f.Open()
+		if err != nil {
+			return "", fmt.Errorf("failed to open ACMI file in archive: %w", err)
+		}
+		defer reader.Close()
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return "", fmt.Errorf("failed to read ACMI file in archive: %w", err)
+		}
+		return string(data), nil
+	}
+	return "", errors.New("no ACMI file found in archive")
+}
+
+// Address returns the address the server is listening on, including port.
+func (s *Server) Address() string {
+	return s.listener.Addr().String()
+}
+
+// Accepted returns the hostnames of the clients which completed the handshake, in the order they connected.
+func (s *Server) Accepted() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return slices.Clone(s.accepted)
+}
+
+// Rejected returns the number of clients which failed the handshake.
+func (s *Server) Rejected() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.rejected
+}
+
+// Disconnect closes all open client connections. Clients may reconnect afterward.
+func (s *Server) Disconnect() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for conn := range s.connections {
+		_ = conn.Close()
+	}
+}
+
+// Close stops the server and closes all open client connections.
+func (s *Server) Close() error {
+	err := s.listener.Close()
+	s.Disconnect()
+	s.wg.Wait()
+	if err != nil {
+		return fmt.Errorf("failed to close listener: %w", err)
+	}
+	return nil
+}
+
+func (s *Server) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				log.Error().Err(err).Msg("error accepting connection")
+			}
+			return
+		}
+		s.lock.Lock()
+		s.connections[conn] = struct{}{}
+		s.lock.Unlock()
+		s.wg.Go(func() {
+			defer func() {
+				s.lock.Lock()
+				delete(s.connections, conn)
+				s.lock.Unlock()
+				_ = conn.Close()
+			}()
+			if err := s.handle(conn); err != nil {
+				log.Debug().Err(err).Msg("telemetry test server connection ended")
+			}
+		})
+	}
+}
+
+func (s *Server) handle(conn net.Conn) error {
+	hostHandshake := telemetry.HostHandshake{
+		LowLevelProtocolVersion:  telemetry.LowLevelProtocolVersion,
+		HighLevelProtocolVersion: telemetry.HighLevelProtocolVersion,
+		Hostname:                 s.hostname,
+	}
+	if _, err := io.WriteString(conn, hostHandshake.Encode()); err != nil {
+		return fmt.Errorf("error sending host handshake: %w", err)
+	}
+
+	reader := bufio.NewReader(conn)
+	packet, err := reader.ReadString('\x00')
+	if err != nil {
+		return fmt.Errorf("error reading client handshake: %w", err)
+	}
+	clientHandshake, err := telemetry.DecodeClientHandshake(packet)
+	if err != nil {
+		s.reject()
+		return fmt.Errorf("error decoding client handshake: %w", err)
+	}
+	if !clientHandshake.Verify(s.password, s.algorithms...) {
+		s.reject()
+		return errors.New("client provided incorrect password")
+	}
+	s.lock.Lock()
+	s.accepted = append(s.accepted, clientHandshake.Hostname)
+	s.lock.Unlock()
+
+	lines := strings.SplitAfter(s.data, "\n")
+	for i, line := range lines {
+		if s.disconnectAfter > 0 && i >= s.disconnectAfter {
+			return errors.New("disconnecting client mid-stream")
+		}
+		if _, err := io.WriteString(conn, line); err != nil {
+			return fmt.Errorf("error sending data: %w", err)
+		}
+	}
+
+	// Hold the connection open until it is closed by either side.
+	_, err = io.Copy(io.Discard, reader)
+	return err
+}
+
+func (s *Server) reject() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.rejected++
+}
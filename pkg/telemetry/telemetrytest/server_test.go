@@ -0,0 +1,41 @@
+package telemetrytest
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testData = "FileType=text/acmi/tacview\nFileVersion=2.2\n#0\n"
+
+func TestReadFile(t *testing.T) {
+	t.Parallel()
+	directory := t.TempDir()
+
+	textPath := filepath.Join(directory, "test.txt.acmi")
+	require.NoError(t, os.WriteFile(textPath, []byte(testData), 0o600))
+	data, err := readFile(textPath)
+	require.NoError(t, err)
+	assert.Equal(t, testData, data)
+
+	zipPath := filepath.Join(directory, "test.zip.acmi")
+	f, err := os.Create(zipPath)
+	require.NoError(t, err)
+	archive := zip.NewWriter(f)
+	w, err := archive.Create("test.txt.acmi")
+	require.NoError(t, err)
+	_, err = w.Write([]byte(testData))
+	require.NoError(t, err)
+	require.NoError(t, archive.Close())
+	require.NoError(t, f.Close())
+	data, err = readFile(zipPath)
+	require.NoError(t, err)
+	assert.Equal(t, testData, data)
+
+	_, err = readFile(filepath.Join(directory, "missing.acmi"))
+	require.Error(t, err)
+}
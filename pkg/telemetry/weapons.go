@@ -0,0 +1,109 @@
+package telemetry
+
+import (
+	"slices"
+	"strconv"
+
+	"github.com/dharmab/collections/sets"
+	"github.com/dharmab/goacmi/v2/properties"
+	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/rs/zerolog/log"
+)
+
+// Weapon tags and properties.
+// Reference: https://www.tacview.net/documentation/acmi/en/ (Object Types and Properties sections)
+const (
+	missileTag           = "Missile"
+	parentProperty       = "Parent"
+	lockedTargetProperty = "LockedTarget"
+)
+
+func isMissile(taglist []string) bool {
+	return slices.Contains(taglist, missileTag)
+}
+
+// parseObjectID parses a hexadecimal ACMI object ID.
+func parseObjectID(s string) (uint64, error) {
+	return strconv.ParseUint(s, 16, 64)
+}
+
+// objectIDProperty returns the object ID stored in the given property of the given object, or 0 if the property is
+// missing or malformed.
+func (c *streamingClient) objectIDProperty(id uint64, property string) uint64 {
+	object, ok := c.state[id]
+	if !ok {
+		return 0
+	}
+	value, ok := object.GetProperty(property)
+	if !ok {
+		return 0
+	}
+	referencedID, err := parseObjectID(value)
+	if err != nil {
+		log.Warn().Err(err).Uint64("id", id).Str("property", property).Msg("ignoring malformed object ID property")
+		return 0
+	}
+	return referencedID
+}
+
+// collectLaunch returns a launch message the first time the object with the given ID is observed as a missile with a
+// known position. Otherwise, it returns nil. Missiles in the initial state of the stream were launched before the
+// stream started, so they are not reported.
+func (c *streamingClient) collectLaunch(id uint64) *sim.Launched {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if sets.Contains(c.launchedIdx, id) {
+		return nil
+	}
+	object, ok := c.state[id]
+	if !ok {
+		return nil
+	}
+	taglist, err := object.GetTypes()
+	if err != nil || !isMissile(taglist) {
+		return nil
+	}
+	logger := log.With().Uint64("id", id).Logger()
+
+	if c.timeFrames < 2 {
+		logger.Debug().Msg("ignoring missile which was already in flight when the stream started")
+		sets.Add(c.launchedIdx, id)
+		return nil
+	}
+
+	frame, err := c.objectFrame(object)
+	if err != nil {
+		return nil
+	}
+	name, _ := object.GetProperty(properties.Name)
+	acmiCoalition, ok := object.GetProperty(properties.Coalition)
+	if !ok {
+		logger.Debug().Msg("missile missing coalition property")
+		return nil
+	}
+	sets.Add(c.launchedIdx, id)
+
+	launch := &sim.Launched{
+		Labels: trackfiles.Labels{
+			ID:        id,
+			Name:      name,
+			Coalition: propertyToCoalition(acmiCoalition),
+			ACMIName:  name,
+		},
+		Frame:      frame,
+		LauncherID: c.objectIDProperty(id, parentProperty),
+		TargetID:   c.objectIDProperty(id, lockedTargetProperty),
+	}
+	// If the missile does not report its target, fall back to the launching platform's locked target.
+	if launch.TargetID == 0 && launch.LauncherID != 0 {
+		launch.TargetID = c.objectIDProperty(launch.LauncherID, lockedTargetProperty)
+	}
+	logger.Info().
+		Str("missile", name).
+		Uint64("launcherID", launch.LauncherID).
+		Uint64("targetID", launch.TargetID).
+		Msg("recording missile launch")
+	return launch
+}
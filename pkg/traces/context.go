@@ -2,6 +2,7 @@ package traces
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/lithammer/shortuuid/v3"
@@ -19,9 +20,11 @@ const (
 	errorKey
 	radioFrequencyKey
 	clientNameKey
+	steppedOnKey
 	playerNameKey
 	requestKey
 	requestTextKey
+	callKey
 	callTextKey
 	receivedAtKey
 	recognizedAtKey
@@ -61,6 +64,22 @@ func GetRequestError(ctx context.Context) error {
 	return getValue[error](ctx, errorKey)
 }
 
+// WithRadioFrequency returns a new context with the radio frequency the request was received on.
+func WithRadioFrequency(ctx context.Context, frequency fmt.Stringer) context.Context {
+	return context.WithValue(ctx, radioFrequencyKey, frequency)
+}
+
+// GetRadioFrequency returns the radio frequency the request was received on, or nil if no radio frequency is set.
+func GetRadioFrequency(ctx context.Context) fmt.Stringer {
+	return getValue[fmt.Stringer](ctx, radioFrequencyKey)
+}
+
+// WithoutRadioFrequency returns a new context without any radio frequency. This is useful for broadcasting a response
+// on every frequency instead of only the frequency the request was received on.
+func WithoutRadioFrequency(ctx context.Context) context.Context {
+	return context.WithValue(ctx, radioFrequencyKey, nil)
+}
+
 // WithClientName returns a new context with the given client name.
 func WithClientName(ctx context.Context, name string) context.Context {
 	return context.WithValue(ctx, clientNameKey, name)
@@ -71,6 +90,18 @@ func GetClientName(ctx context.Context) string {
 	return getValue[string](ctx, clientNameKey)
 }
 
+// WithSteppedOn returns a new context which records whether the request was transmitted at the same time as another
+// transmission on the same frequency.
+func WithSteppedOn(ctx context.Context, steppedOn bool) context.Context {
+	return context.WithValue(ctx, steppedOnKey, steppedOn)
+}
+
+// IsSteppedOn returns true if the context records that the request was transmitted at the same time as another
+// transmission on the same frequency.
+func IsSteppedOn(ctx context.Context) bool {
+	return getValue[bool](ctx, steppedOnKey)
+}
+
 // WithPlayerName returns a new context with the given player name.
 func WithPlayerName(ctx context.Context, name string) context.Context {
 	return context.WithValue(ctx, playerNameKey, name)
@@ -106,6 +137,16 @@ func WithoutRequestText(ctx context.Context) context.Context {
 	return context.WithValue(ctx, requestTextKey, nil)
 }
 
+// WithCall returns a new context with the given call.
+func WithCall(ctx context.Context, call any) context.Context {
+	return context.WithValue(ctx, callKey, call)
+}
+
+// GetCall returns the call from the context, or nil if no call is set.
+func GetCall(ctx context.Context) any {
+	return ctx.Value(callKey)
+}
+
 // WithCallText returns a new context with the given call text.
 func WithCallText(ctx context.Context, text string) context.Context {
 	return context.WithValue(ctx, callTextKey, text)
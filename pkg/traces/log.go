@@ -21,12 +21,21 @@ func (*LogTracer) Trace(ctx context.Context) {
 	if clientName := GetClientName(ctx); clientName != "" {
 		loggerCtx = loggerCtx.Str("clientName", clientName)
 	}
+	if frequency := GetRadioFrequency(ctx); frequency != nil {
+		loggerCtx = loggerCtx.Stringer("frequency", frequency)
+	}
+	if IsSteppedOn(ctx) {
+		loggerCtx = loggerCtx.Bool("steppedOn", true)
+	}
 	if text := GetRequestText(ctx); text != "" {
 		loggerCtx = loggerCtx.Str("requestText", text)
 	}
 	if request := GetRequest(ctx); request != nil {
 		loggerCtx = loggerCtx.Type("requestType", request).Any("request", request)
 	}
+	if call := GetCall(ctx); call != nil {
+		loggerCtx = loggerCtx.Type("callType", call)
+	}
 	if text := GetCallText(ctx); text != "" {
 		loggerCtx = loggerCtx.Str("callText", text)
 	}
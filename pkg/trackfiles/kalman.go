@@ -0,0 +1,228 @@
+package trackfiles
+
+import (
+	"math"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+)
+
+const (
+	// positionNoise is the standard deviation of position error in telemetry, in meters.
+	positionNoise = 15.0
+	// horizontalManeuverNoise is the standard deviation of unmodeled horizontal acceleration, in meters per second
+	// squared. This controls how quickly the filter follows a turning contact.
+	horizontalManeuverNoise = 3.0
+	// verticalManeuverNoise is the standard deviation of unmodeled vertical acceleration, in meters per second squared.
+	verticalManeuverNoise = 5.0
+	// initialVelocityNoise is the standard deviation of the velocity of a contact seen only once, in meters per
+	// second.
+	initialVelocityNoise = 300.0
+)
+
+// State is a filtered estimate of a contact's position and velocity.
+type State struct {
+	// Time of the estimate.
+	Time time.Time
+	// Point is the contact's estimated 2D position.
+	Point orb.Point
+	// Altitude is the contact's estimated altitude above sea level.
+	Altitude unit.Length
+	// EastVelocity is the eastward component of the contact's estimated ground velocity.
+	EastVelocity unit.Speed
+	// NorthVelocity is the northward component of the contact's estimated ground velocity.
+	NorthVelocity unit.Speed
+	// VerticalVelocity is the contact's estimated rate of climb. Negative values indicate a descent.
+	VerticalVelocity unit.Speed
+	// PositionError is the standard deviation of the error in the estimated 2D position.
+	PositionError unit.Length
+	// VelocityError is the standard deviation of the error in the estimated ground velocity.
+	VelocityError unit.Speed
+}
+
+// GroundSpeed returns the magnitude of the estimated ground velocity.
+func (s State) GroundSpeed() unit.Speed {
+	return unit.Speed(math.Hypot(s.EastVelocity.MetersPerSecond(), s.NorthVelocity.MetersPerSecond())) * unit.MetersPerSecond
+}
+
+// axis is a constant velocity Kalman filter along one axis. The position is tracked as an offset from the filter's
+// reference point, which is moved to the estimated position after every step, so the position state is always zero
+// between steps.
+type axis struct {
+	// velocity in meters per second.
+	velocity float64
+	// covariance of the position and velocity errors, in square meters and square meters per second.
+	covariance [2][2]float64
+	// maneuverNoise is the standard deviation of unmodeled acceleration, in meters per second squared.
+	maneuverNoise float64
+}
+
+// newAxis creates a filter axis with the given initial velocity.
+func newAxis(velocity, velocityNoise, maneuverNoise float64) axis {
+	return axis{
+		velocity: velocity,
+		covariance: [2][2]float64{
+			{positionNoise * positionNoise, 0},
+			{0, velocityNoise * velocityNoise},
+		},
+		maneuverNoise: maneuverNoise,
+	}
+}
+
+// predict advances the filter by the given number of seconds and returns the position offset.
+func (a *axis) predict(dt float64) float64 {
+	p := a.covariance
+	q := a.maneuverNoise * a.maneuverNoise
+	a.covariance = [2][2]float64{
+		{
+			p[0][0] + dt*(p[0][1]+p[1][0]) + dt*dt*p[1][1] + q*math.Pow(dt, 4)/4,
+			p[0][1] + dt*p[1][1] + q*math.Pow(dt, 3)/2,
+		},
+		{
+			p[1][0] + dt*p[1][1] + q*math.Pow(dt, 3)/2,
+			p[1][1] + q*dt*dt,
+		},
+	}
+	return a.velocity * dt
+}
+
+// correct applies a position measurement at the given offset from the predicted position, and returns the correction
+// to the position.
+func (a *axis) correct(offset float64) float64 {
+	p := a.covariance
+	innovationVariance := p[0][0] + positionNoise*positionNoise
+	positionGain := p[0][0] / innovationVariance
+	velocityGain := p[1][0] / innovationVariance
+	a.velocity += velocityGain * offset
+	a.covariance = [2][2]float64{
+		{(1 - positionGain) * p[0][0], (1 - positionGain) * p[0][1]},
+		{p[1][0] - velocityGain*p[0][0], p[1][1] - velocityGain*p[0][1]},
+	}
+	return positionGain * offset
+}
+
+// initialize sets the filter to the velocity between two measurements the given number of seconds apart.
+func (a *axis) initialize(offset, dt float64) {
+	r := positionNoise * positionNoise
+	a.velocity = offset / dt
+	a.covariance = [2][2]float64{
+		{r, r / dt},
+		{r / dt, 2 * r / (dt * dt)},
+	}
+}
+
+// kalmanFilter estimates a contact's position and velocity from noisy position measurements, using a constant
+// velocity model with independent east, north and vertical axes.
+type kalmanFilter struct {
+	// samples is the number of measurements applied to the filter.
+	samples int
+	// time of the current estimate.
+	time time.Time
+	// point is the estimated 2D position.
+	point orb.Point
+	// altitude is the estimated altitude in meters.
+	altitude float64
+	// east, north and vertical are the filter axes.
+	east, north, vertical axis
+}
+
+// update applies the given frame to the filter.
+func (k *kalmanFilter) update(f Frame) {
+	if k.samples == 0 {
+		k.reset(f)
+		return
+	}
+	dt := f.Time.Sub(k.time).Seconds()
+	if dt < 0 {
+		return
+	}
+	if k.samples == 1 && dt > 0 {
+		// Initialize the velocity from the difference between the first two measurements.
+		east, north := offset(k.point, f.Point)
+		k.east.initialize(east, dt)
+		k.north.initialize(north, dt)
+		k.vertical.initialize(f.Altitude.Meters()-k.altitude, dt)
+		k.point = f.Point
+		k.altitude = f.Altitude.Meters()
+		k.time = f.Time
+		k.samples++
+		return
+	}
+	k.step(dt)
+	east, north := offset(k.point, f.Point)
+	k.point = move(k.point, k.east.correct(east), k.north.correct(north))
+	k.altitude += k.vertical.correct(f.Altitude.Meters() - k.altitude)
+	k.time = f.Time
+	if dt > 0 {
+		k.samples++
+	}
+}
+
+// reset initializes the filter from a single frame. If the frame contains a reported speed, the initial velocity
+// follows the reported heading.
+func (k *kalmanFilter) reset(f Frame) {
+	k.samples = 1
+	k.time = f.Time
+	k.point = f.Point
+	k.altitude = f.Altitude.Meters()
+	var east, north, vertical float64
+	if speed, ok := f.reportedGroundSpeed(); ok {
+		east = speed.MetersPerSecond() * math.Sin(f.Heading.Radians())
+		north = speed.MetersPerSecond() * math.Cos(f.Heading.Radians())
+	}
+	if f.VerticalSpeed != nil {
+		vertical = f.VerticalSpeed.MetersPerSecond()
+	}
+	k.east = newAxis(east, initialVelocityNoise, horizontalManeuverNoise)
+	k.north = newAxis(north, initialVelocityNoise, horizontalManeuverNoise)
+	k.vertical = newAxis(vertical, initialVelocityNoise, verticalManeuverNoise)
+}
+
+// step advances the filter by the given number of seconds without a measurement.
+func (k *kalmanFilter) step(dt float64) {
+	k.point = move(k.point, k.east.predict(dt), k.north.predict(dt))
+	k.altitude += k.vertical.predict(dt)
+	k.time = k.time.Add(time.Duration(dt * float64(time.Second)))
+}
+
+// hasVelocity returns true if the filter has a velocity estimate from at least two measurements.
+func (k *kalmanFilter) hasVelocity() bool {
+	return k.samples > 1
+}
+
+// state returns the filter's current estimate.
+func (k *kalmanFilter) state() State {
+	return State{
+		Time:             k.time,
+		Point:            k.point,
+		Altitude:         unit.Length(k.altitude) * unit.Meter,
+		EastVelocity:     unit.Speed(k.east.velocity) * unit.MetersPerSecond,
+		NorthVelocity:    unit.Speed(k.north.velocity) * unit.MetersPerSecond,
+		VerticalVelocity: unit.Speed(k.vertical.velocity) * unit.MetersPerSecond,
+		PositionError:    unit.Length(math.Sqrt(k.east.covariance[0][0]+k.north.covariance[0][0])) * unit.Meter,
+		VelocityError:    unit.Speed(math.Sqrt(k.east.covariance[1][1]+k.north.covariance[1][1])) * unit.MetersPerSecond,
+	}
+}
+
+// offset returns the east and north distances in meters from a to b.
+func offset(a, b orb.Point) (float64, float64) {
+	distance := spatial.Distance(a, b).Meters()
+	if distance == 0 {
+		return 0, 0
+	}
+	bearing := spatial.TrueBearing(a, b).Value().Radians()
+	return distance * math.Sin(bearing), distance * math.Cos(bearing)
+}
+
+// move returns the point at the given east and north distances in meters from p.
+func move(p orb.Point, east, north float64) orb.Point {
+	distance := math.Hypot(east, north)
+	if distance == 0 {
+		return p
+	}
+	bearing := bearings.NewTrueBearing(unit.Angle(math.Atan2(east, north)) * unit.Radian)
+	return spatial.PointAtBearingAndDistance(p, bearing, unit.Length(distance)*unit.Meter)
+}
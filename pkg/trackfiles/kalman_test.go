@@ -0,0 +1,128 @@
+package trackfiles
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterSmoothsNoisyTrack(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewPCG(1, 2))
+	tf := New(testLabels)
+	start := time.Now()
+	course := bearings.NewTrueBearing(60 * unit.Degree)
+	speed := 200 * unit.MetersPerSecond
+	interval := 2 * time.Second
+
+	var rawError, filteredError float64
+	var previous Frame
+	for i := range 60 {
+		elapsed := time.Duration(i) * interval
+		truth := spatial.PointAtBearingAndDistance(testOrigin, course, unit.Length(speed.MetersPerSecond()*elapsed.Seconds())*unit.Meter)
+		frame := Frame{
+			Time:     start.Add(elapsed),
+			Point:    move(truth, rng.NormFloat64()*positionNoise, rng.NormFloat64()*positionNoise),
+			Altitude: 20000 * unit.Foot,
+		}
+		tf.Update(frame)
+		if i >= 10 {
+			raw := spatial.TrueBearing(previous.Point, frame.Point)
+			rawError += math.Pow(bearings.AngularDistance(raw, course).Degrees(), 2)
+			filtered, _ := tf.Velocity()
+			filteredError += math.Pow(bearings.AngularDistance(filtered, course).Degrees(), 2)
+		}
+		previous = frame
+	}
+
+	// The filtered course jitters much less than the course between consecutive frames.
+	assert.Less(t, filteredError, rawError/4)
+	filtered, filteredSpeed := tf.Velocity()
+	assert.InDelta(t, course.Degrees(), filtered.Degrees(), 2)
+	assert.InDelta(t, speed.MetersPerSecond(), filteredSpeed.MetersPerSecond(), 5)
+
+	state, ok := tf.State()
+	require.True(t, ok)
+	assert.Less(t, state.PositionError.Meters(), positionNoise*math.Sqrt2)
+	assert.Less(t, state.VelocityError.MetersPerSecond(), 10.0)
+}
+
+func TestFilterFollowsTurn(t *testing.T) {
+	t.Parallel()
+	tf := New(testLabels)
+	start := time.Now()
+	point := testOrigin
+	interval := 2 * time.Second
+	for i := range 20 {
+		heading := 0 * unit.Degree
+		if i >= 10 {
+			heading = 90 * unit.Degree
+		}
+		point = spatial.PointAtBearingAndDistance(point, bearings.NewTrueBearing(heading), 400*unit.Meter)
+		tf.Update(Frame{Time: start.Add(time.Duration(i) * interval), Point: point})
+	}
+	course, _ := tf.Velocity()
+	assert.InDelta(t, 90, course.Degrees(), 2)
+}
+
+func TestPredict(t *testing.T) {
+	t.Parallel()
+	t.Run("empty trackfile", func(t *testing.T) {
+		t.Parallel()
+		tf := New(testLabels)
+		_, ok := tf.Predict(time.Now())
+		assert.False(t, ok)
+	})
+	t.Run("single frame with reported speed", func(t *testing.T) {
+		t.Parallel()
+		tf := New(testLabels)
+		now := time.Now()
+		tas := 100 * unit.MetersPerSecond
+		tf.Update(Frame{Time: now, Point: testOrigin, Heading: 90 * unit.Degree, TAS: &tas})
+		state, ok := tf.Predict(now.Add(10 * time.Second))
+		require.True(t, ok)
+		assert.InDelta(t, 1000, spatial.Distance(testOrigin, state.Point).Meters(), 1)
+		assert.InDelta(t, 90, spatial.TrueBearing(testOrigin, state.Point).Degrees(), 0.5)
+	})
+	t.Run("constant velocity", func(t *testing.T) {
+		t.Parallel()
+		tf := New(testLabels)
+		now := time.Now()
+		course := bearings.NewTrueBearing(180 * unit.Degree)
+		for i := range 5 {
+			tf.Update(Frame{
+				Time:     now.Add(time.Duration(i) * time.Second),
+				Point:    spatial.PointAtBearingAndDistance(testOrigin, course, unit.Length(i*150)*unit.Meter),
+				Altitude: unit.Length(1000+i*10) * unit.Meter,
+			})
+		}
+		current, ok := tf.State()
+		require.True(t, ok)
+		predicted, ok := tf.Predict(now.Add(24 * time.Second))
+		require.True(t, ok)
+		expected := spatial.PointAtBearingAndDistance(testOrigin, course, 24*150*unit.Meter)
+		assert.InDelta(t, 0, spatial.Distance(expected, predicted.Point).Meters(), 1)
+		assert.InDelta(t, 1240, predicted.Altitude.Meters(), 1)
+		assert.InDelta(t, 10, predicted.VerticalVelocity.MetersPerSecond(), 0.1)
+		assert.Greater(t, predicted.PositionError, current.PositionError)
+	})
+	t.Run("time before most recent frame", func(t *testing.T) {
+		t.Parallel()
+		tf := New(testLabels)
+		now := time.Now()
+		tf.Update(Frame{Time: now.Add(-time.Second), Point: testOrigin})
+		dest := spatial.PointAtBearingAndDistance(testOrigin, bearings.NewTrueBearing(0), 100*unit.Meter)
+		tf.Update(Frame{Time: now, Point: dest})
+		state, ok := tf.Predict(now.Add(-time.Minute))
+		require.True(t, ok)
+		assert.Equal(t, now, state.Time)
+		assert.InDelta(t, 0, spatial.Distance(dest, state.Point).Meters(), 0.01)
+	})
+}
@@ -29,6 +29,44 @@ type Labels struct {
 	// The name of the aircraft type in the ACMI file.
 	// See https://www.tacview.net/documentation/database/en/
 	ACMIName string
+	// Class is the kind of object. The zero value is Aircraft.
+	Class Class
+	// Group is the name of the mission group the object belongs to, if known.
+	Group string
+}
+
+// Class is the kind of object tracked by a trackfile.
+type Class int
+
+const (
+	// Aircraft are fixed-wing and rotary-wing aircraft.
+	Aircraft Class = iota
+	// Watercraft are ships and boats.
+	Watercraft
+	// AirDefense are surface-to-air missile systems and anti-aircraft artillery.
+	AirDefense
+	// GroundVehicle are ground vehicles other than air defenses, such as armor.
+	GroundVehicle
+)
+
+// IsSurface returns true if the class is a sea or ground object.
+func (c Class) IsSurface() bool {
+	return c != Aircraft
+}
+
+func (c Class) String() string {
+	switch c {
+	case Aircraft:
+		return "aircraft"
+	case Watercraft:
+		return "watercraft"
+	case AirDefense:
+		return "air defense"
+	case GroundVehicle:
+		return "ground vehicle"
+	default:
+		return "unknown"
+	}
 }
 
 // Trackfile tracks a contact's movement over time.
@@ -37,11 +75,17 @@ type Trackfile struct {
 	Contact Labels
 	// track is a collection of frames, ordered from most recent to least recent.
 	track *deques.Counting[Frame]
-	lock  sync.RWMutex
+	// filter smooths the frames into an estimate of the contact's position and velocity.
+	filter kalmanFilter
+	lock   sync.RWMutex
 }
 
 const maxLength = 4
 
+// minCourseSpeed is the slowest filtered ground speed from which a course is computed. The filtered velocity of a
+// slower contact, such as a hovering helicopter, is mostly noise, so the reported heading is used instead.
+const minCourseSpeed = 1 * unit.MetersPerSecond
+
 // Frame describes a contact's position and velocity at a point in time.
 type Frame struct {
 	// Time within the simulation when the event occurred.
@@ -55,6 +99,84 @@ type Frame struct {
 	AGL *unit.Length
 	// Heading is the direction the contact is moving. This is not necessarily the direction the nose is poining.
 	Heading unit.Angle
+	// IAS is the indicated airspeed, if available.
+	IAS *unit.Speed
+	// TAS is the true airspeed, if available.
+	TAS *unit.Speed
+	// Mach is the airspeed as a fraction of the speed of sound, if available.
+	Mach *float64
+	// VerticalSpeed is the rate of climb, if available. Negative values indicate a descent.
+	VerticalSpeed *unit.Speed
+	// Pitch is the angle of the nose above the horizon, if available. Negative values indicate nose low.
+	Pitch *unit.Angle
+	// Roll is the bank angle, if available. Positive values indicate a bank to the right.
+	Roll *unit.Angle
+}
+
+// reportedSpeed returns the true airspeed reported in the frame, or false if the frame has no speed data. If only the
+// indicated airspeed or Mach number is available, the true airspeed is estimated using the International Standard
+// Atmosphere.
+func (f Frame) reportedSpeed() (unit.Speed, bool) {
+	if f.TAS != nil {
+		return *f.TAS, true
+	}
+	if f.IAS != nil {
+		return unit.Speed(f.IAS.MetersPerSecond()/math.Sqrt(airDensityRatio(f.Altitude))) * unit.MetersPerSecond, true
+	}
+	if f.Mach != nil {
+		return unit.Speed(*f.Mach) * speedOfSound(f.Altitude), true
+	}
+	return 0, false
+}
+
+// reportedGroundSpeed returns the horizontal component of the speed reported in the frame, or false if the frame has
+// no speed data. Wind is not accounted for.
+func (f Frame) reportedGroundSpeed() (unit.Speed, bool) {
+	speed, ok := f.reportedSpeed()
+	if !ok {
+		return 0, false
+	}
+	if f.VerticalSpeed != nil {
+		horizontal := math.Sqrt(math.Max(0, math.Pow(speed.MetersPerSecond(), 2)-math.Pow(f.VerticalSpeed.MetersPerSecond(), 2)))
+		return unit.Speed(horizontal) * unit.MetersPerSecond, true
+	}
+	if f.Pitch != nil {
+		return unit.Speed(speed.MetersPerSecond()*math.Abs(math.Cos(f.Pitch.Radians()))) * unit.MetersPerSecond, true
+	}
+	return speed, true
+}
+
+// International Standard Atmosphere constants.
+const (
+	seaLevelTemperature   = 288.15  // K
+	tropopauseTemperature = 216.65  // K
+	tropopauseAltitude    = 11000   // m
+	lapseRate             = 0.0065  // K/m
+	heatCapacityRatio     = 1.4     // dimensionless
+	gasConstant           = 287.053 // J/(kg·K)
+	gravity               = 9.80665 // m/s²
+)
+
+// temperature returns the air temperature at the given altitude in the International Standard Atmosphere.
+func temperature(altitude unit.Length) float64 {
+	return math.Max(seaLevelTemperature-lapseRate*altitude.Meters(), tropopauseTemperature)
+}
+
+// speedOfSound returns the speed of sound at the given altitude in the International Standard Atmosphere.
+func speedOfSound(altitude unit.Length) unit.Speed {
+	return unit.Speed(math.Sqrt(heatCapacityRatio*gasConstant*temperature(altitude))) * unit.MetersPerSecond
+}
+
+// airDensityRatio returns the ratio of the air density at the given altitude to the air density at sea level in the
+// International Standard Atmosphere. Above the tropopause the temperature is constant and density decays
+// exponentially.
+func airDensityRatio(altitude unit.Length) float64 {
+	const exponent = gravity/(lapseRate*gasConstant) - 1
+	if altitude.Meters() <= tropopauseAltitude {
+		return math.Pow(temperature(altitude)/seaLevelTemperature, exponent)
+	}
+	ratio := math.Pow(tropopauseTemperature/seaLevelTemperature, exponent)
+	return ratio * math.Exp(-gravity*(altitude.Meters()-tropopauseAltitude)/(gasConstant*tropopauseTemperature))
 }
 
 // New creates a new trackfile with the given labels.
@@ -88,6 +210,7 @@ func (t *Trackfile) Update(f Frame) {
 		return
 	}
 	t.track.Push(f)
+	t.filter.update(f)
 }
 
 // Bullseye returns the bearing and distance from the bullseye to the track's last known position.
@@ -139,32 +262,40 @@ func (t *Trackfile) Course(opts ...spatial.Option) bearings.Bearing {
 	return t.computeCourse(opts...)
 }
 
-// computeCourse returns the magnetic bearing between the two most recent frames, or the heading if only one frame exists. Caller must hold t.lock.
+// computeCourse returns the magnetic course of the filtered velocity, or the heading if the velocity is unknown or slow.
+// Caller must hold t.lock.
 func (t *Trackfile) computeCourse(opts ...spatial.Option) bearings.Bearing {
 	latest, ok := t.track.Newest()
 	if !ok {
 		return bearings.NewTrueBearing(0)
 	}
-	if t.track.Len() == 1 {
-		return bearings.NewTrueBearing(
-			latest.Heading,
-		).Magnetic(t.bestAvailableDeclination())
+	declination := t.bestAvailableDeclination()
+	if course, ok := t.filteredCourse(opts...); ok {
+		return course.Magnetic(declination)
 	}
+	return bearings.NewTrueBearing(latest.Heading).Magnetic(declination)
+}
 
-	previous, _ := t.track.At(1)
-
-	declination := t.bestAvailableDeclination()
-	course := spatial.TrueBearing(previous.Point, latest.Point, opts...).Magnetic(declination)
-	return course
+// filteredCourse returns the true course of the filtered velocity. The second return value is false if the velocity is
+// unknown or slower than minCourseSpeed. Caller must hold t.lock.
+func (t *Trackfile) filteredCourse(opts ...spatial.Option) (bearings.Bearing, bool) {
+	if !t.filter.hasVelocity() {
+		return nil, false
+	}
+	state := t.filter.state()
+	if state.GroundSpeed() < minCourseSpeed {
+		return nil, false
+	}
+	// Project the velocity one second ahead, so that the course is measured in the same coordinate system as other
+	// bearings computed with the same options.
+	ahead := move(state.Point, state.EastVelocity.MetersPerSecond(), state.NorthVelocity.MetersPerSecond())
+	return spatial.TrueBearing(state.Point, ahead, opts...), true
 }
 
 // Direction returns the cardinal direction that the track is moving in, or [brevity.UnknownDirection] if the track is not moving faster than 1 m/s.
 func (t *Trackfile) Direction() brevity.Track {
 	t.lock.RLock()
 	defer t.lock.RUnlock()
-	if t.track.Len() < 2 {
-		return brevity.UnknownDirection
-	}
 	if t.groundSpeed() < 1*unit.MetersPerSecond {
 		return brevity.UnknownDirection
 	}
@@ -172,32 +303,72 @@ func (t *Trackfile) Direction() brevity.Track {
 	return brevity.TrackFromBearing(t.computeCourse())
 }
 
-// groundSpeed returns the approximate ground speed of the track in two dimensions. Caller must hold t.lock.
-func (t *Trackfile) groundSpeed(opts ...spatial.Option) unit.Speed {
+// groundSpeed returns the approximate ground speed of the track in two dimensions. The speed reported in the most
+// recent frame is preferred, if available. Otherwise, the speed of the filtered velocity is used. Caller must hold
+// t.lock.
+func (t *Trackfile) groundSpeed() unit.Speed {
 	latest, ok := t.track.Newest()
 	if !ok {
 		return 0
 	}
-	previous, ok := t.track.At(1)
-	if !ok {
+	if speed, ok := latest.reportedGroundSpeed(); ok {
+		return speed
+	}
+	if !t.filter.hasVelocity() {
 		return 0
 	}
+	return t.filter.state().GroundSpeed()
+}
 
-	timeDelta := latest.Time.Sub(previous.Time)
-	if timeDelta == 0 {
-		return 0
+// Velocity returns the true course and ground speed of the track. If the track has not moved, the course is the
+// contact's heading and the speed is zero.
+func (t *Trackfile) Velocity(opts ...spatial.Option) (bearings.Bearing, unit.Speed) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	latest, ok := t.track.Newest()
+	if !ok {
+		return bearings.NewTrueBearing(0), 0
 	}
+	speed := t.groundSpeed()
+	if speed == 0 {
+		return bearings.NewTrueBearing(latest.Heading), 0
+	}
+	if course, ok := t.filteredCourse(opts...); ok {
+		return course, speed
+	}
+	return bearings.NewTrueBearing(latest.Heading), speed
+}
 
-	groundDistance := spatial.Distance(latest.Point, previous.Point, opts...)
-	groundSpeed := unit.Speed(
-		groundDistance.Meters()/
-			timeDelta.Seconds(),
-	) * unit.MetersPerSecond
+// State returns the filtered estimate of the track's position and velocity at the time of the most recent frame. The
+// second return value is false if the trackfile is empty.
+func (t *Trackfile) State() (State, bool) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	if t.filter.samples == 0 {
+		return State{}, false
+	}
+	return t.filter.state(), true
+}
 
-	return groundSpeed
+// Predict returns the filtered estimate of the track's position and velocity, extrapolated from the most recent frame
+// to the given time. The error of the estimate grows with the time since the most recent frame. If the given time is
+// before the most recent frame, the estimate at the most recent frame is returned. The second return value is false if
+// the trackfile is empty.
+func (t *Trackfile) Predict(at time.Time) (State, bool) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	if t.filter.samples == 0 {
+		return State{}, false
+	}
+	filter := t.filter
+	if dt := at.Sub(filter.time).Seconds(); dt > 0 {
+		filter.step(dt)
+	}
+	return filter.state(), true
 }
 
-// Speed returns either the ground speed or the true 3D speed of the track, whichever is greater.
+// Speed returns the true airspeed reported in the most recent frame, if available. Otherwise, it returns the true 3D
+// speed of the filtered velocity.
 func (t *Trackfile) Speed() unit.Speed {
 	t.lock.RLock()
 	defer t.lock.RUnlock()
@@ -205,37 +376,12 @@ func (t *Trackfile) Speed() unit.Speed {
 	if !ok {
 		return 0
 	}
-	previous, ok := t.track.At(1)
-	if !ok {
-		return 0
+	if speed, ok := latest.reportedSpeed(); ok {
+		return speed
 	}
-
-	timeDelta := latest.Time.Sub(previous.Time)
-	if timeDelta == 0 {
+	if !t.filter.hasVelocity() {
 		return 0
 	}
-
-	var verticalDistance unit.Length
-	if latest.Altitude > previous.Altitude {
-		verticalDistance = latest.Altitude - previous.Altitude
-	} else {
-		verticalDistance = previous.Altitude - latest.Altitude
-	}
-	verticalSpeed := unit.Speed(
-		verticalDistance.Meters()/
-			timeDelta.Seconds(),
-	) * unit.MetersPerSecond
-
-	groundSpeed := t.groundSpeed()
-
-	trueSpeed := unit.Speed(
-		math.Sqrt(
-			math.Pow(groundSpeed.MetersPerSecond(), 2)+math.Pow(verticalSpeed.MetersPerSecond(), 2),
-		),
-	) * unit.MetersPerSecond
-
-	if groundSpeed > trueSpeed {
-		return groundSpeed
-	}
-	return trueSpeed
+	state := t.filter.state()
+	return unit.Speed(math.Hypot(state.GroundSpeed().MetersPerSecond(), state.VerticalVelocity.MetersPerSecond())) * unit.MetersPerSecond
 }
@@ -1,6 +1,7 @@
 package trackfiles
 
 import (
+	"fmt"
 	"sync"
 	"testing"
 	"time"
@@ -171,6 +172,21 @@ func TestCourse(t *testing.T) {
 		expected := spatial.TrueBearing(testOrigin, dest).Magnetic(declination)
 		assert.InDelta(t, expected.Degrees(), tf.Course().Degrees(), 0.5)
 	})
+	t.Run("very slow movement uses heading field", func(t *testing.T) {
+		t.Parallel()
+		tf := New(testLabels)
+		now := time.Now()
+		heading := 135 * unit.Degree
+		tf.Update(Frame{Time: now.Add(-2 * time.Second), Point: testOrigin, Heading: heading})
+		// Drift less than 1 m/s in a different direction than the heading
+		dest := spatial.PointAtBearingAndDistance(testOrigin, bearings.NewTrueBearing(0), 1*unit.Meter)
+		tf.Update(Frame{Time: now, Point: dest, Heading: heading})
+
+		declination, err := bearings.Declination(dest, now)
+		require.NoError(t, err)
+		expected := bearings.NewTrueBearing(heading).Magnetic(declination)
+		assert.InDelta(t, expected.Degrees(), tf.Course().Degrees(), 0.5)
+	})
 }
 
 func TestDirection(t *testing.T) {
@@ -204,6 +220,13 @@ func TestDirection(t *testing.T) {
 		tf.Update(Frame{Time: now, Point: dest})
 		assert.Equal(t, brevity.UnknownDirection, tf.Direction())
 	})
+	t.Run("single frame with reported speed", func(t *testing.T) {
+		t.Parallel()
+		tf := New(testLabels)
+		tas := 200 * unit.MetersPerSecond
+		tf.Update(Frame{Time: time.Now(), Point: testOrigin, Heading: 90 * unit.Degree, TAS: &tas})
+		assert.Equal(t, brevity.East, tf.Direction())
+	})
 }
 
 func TestSpeed(t *testing.T) {
@@ -238,6 +261,110 @@ func TestSpeed(t *testing.T) {
 		tf.Update(Frame{Time: now, Point: dest, Altitude: alt})
 		assert.InDelta(t, 100.0, tf.Speed().MetersPerSecond(), 0.5)
 	})
+	t.Run("single frame with reported true airspeed", func(t *testing.T) {
+		t.Parallel()
+		tf := New(testLabels)
+		tas := 250 * unit.MetersPerSecond
+		tf.Update(Frame{Time: time.Now(), Point: testOrigin, Altitude: 20000 * unit.Foot, TAS: &tas})
+		assert.InDelta(t, 250.0, tf.Speed().MetersPerSecond(), 0.01)
+	})
+	t.Run("reported true airspeed preferred over movement", func(t *testing.T) {
+		t.Parallel()
+		tf := New(testLabels)
+		now := time.Now()
+		tf.Update(Frame{Time: now.Add(-2 * time.Second), Point: testOrigin})
+		dest := spatial.PointAtBearingAndDistance(testOrigin, bearings.NewTrueBearing(0), 200*unit.Meter)
+		tas := 150 * unit.MetersPerSecond
+		tf.Update(Frame{Time: now, Point: dest, TAS: &tas})
+		assert.InDelta(t, 150.0, tf.Speed().MetersPerSecond(), 0.01)
+	})
+	t.Run("single frame with reported indicated airspeed", func(t *testing.T) {
+		t.Parallel()
+		tf := New(testLabels)
+		ias := 300 * unit.Knot
+		tf.Update(Frame{Time: time.Now(), Point: testOrigin, Altitude: 20000 * unit.Foot, IAS: &ias})
+		// Air at 20,000 feet is about half as dense as at sea level, so the true airspeed is much higher.
+		assert.InDelta(t, 411, tf.Speed().Knots(), 1)
+	})
+	t.Run("indicated airspeed equals true airspeed at sea level", func(t *testing.T) {
+		t.Parallel()
+		tf := New(testLabels)
+		ias := 300 * unit.Knot
+		tf.Update(Frame{Time: time.Now(), Point: testOrigin, IAS: &ias})
+		assert.InDelta(t, 300, tf.Speed().Knots(), 0.01)
+	})
+	t.Run("reported true airspeed preferred over indicated airspeed and mach", func(t *testing.T) {
+		t.Parallel()
+		tf := New(testLabels)
+		tas := 250 * unit.MetersPerSecond
+		ias := 150 * unit.MetersPerSecond
+		mach := 1.0
+		tf.Update(Frame{Time: time.Now(), Point: testOrigin, Altitude: 20000 * unit.Foot, TAS: &tas, IAS: &ias, Mach: &mach})
+		assert.InDelta(t, 250.0, tf.Speed().MetersPerSecond(), 0.01)
+	})
+	t.Run("single frame with reported mach", func(t *testing.T) {
+		t.Parallel()
+		tf := New(testLabels)
+		mach := 1.0
+		tf.Update(Frame{Time: time.Now(), Point: testOrigin, Altitude: 40000 * unit.Foot, Mach: &mach})
+		// The speed of sound in the stratosphere is about 573 knots.
+		assert.InDelta(t, 573, tf.Speed().Knots(), 1)
+	})
+}
+
+func TestAirDensityRatio(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		altitude unit.Length
+		expected float64
+	}{
+		{altitude: 0, expected: 1},
+		{altitude: 10000 * unit.Foot, expected: 0.7385},
+		{altitude: 11000 * unit.Meter, expected: 0.2971},
+		{altitude: 40000 * unit.Foot, expected: 0.2462},
+	}
+	for _, test := range testCases {
+		t.Run(fmt.Sprintf("%.0f ft", test.altitude.Feet()), func(t *testing.T) {
+			t.Parallel()
+			assert.InDelta(t, test.expected, airDensityRatio(test.altitude), 0.001)
+		})
+	}
+}
+
+func TestVelocity(t *testing.T) {
+	t.Parallel()
+	t.Run("single frame", func(t *testing.T) {
+		t.Parallel()
+		tf := New(testLabels)
+		tf.Update(Frame{Time: time.Now(), Point: testOrigin, Heading: 45 * unit.Degree})
+		course, speed := tf.Velocity()
+		assert.InDelta(t, 45, course.Degrees(), 0.5)
+		assert.True(t, course.IsTrue())
+		assert.Zero(t, speed)
+	})
+	t.Run("horizontal movement", func(t *testing.T) {
+		t.Parallel()
+		tf := New(testLabels)
+		now := time.Now()
+		tf.Update(Frame{Time: now.Add(-2 * time.Second), Point: testOrigin})
+		dest := spatial.PointAtBearingAndDistance(testOrigin, bearings.NewTrueBearing(90*unit.Degree), 200*unit.Meter)
+		tf.Update(Frame{Time: now, Point: dest})
+		course, speed := tf.Velocity()
+		assert.InDelta(t, 90, course.Degrees(), 0.5)
+		assert.True(t, course.IsTrue())
+		assert.InDelta(t, 100.0, speed.MetersPerSecond(), 0.5)
+	})
+	t.Run("single frame with reported speed", func(t *testing.T) {
+		t.Parallel()
+		tf := New(testLabels)
+		tas := 200 * unit.MetersPerSecond
+		verticalSpeed := 120 * unit.MetersPerSecond
+		tf.Update(Frame{Time: time.Now(), Point: testOrigin, Heading: 45 * unit.Degree, TAS: &tas, VerticalSpeed: &verticalSpeed})
+		course, speed := tf.Velocity()
+		assert.InDelta(t, 45, course.Degrees(), 0.5)
+		assert.True(t, course.IsTrue())
+		assert.InDelta(t, 160.0, speed.MetersPerSecond(), 0.01)
+	})
 }
 
 func TestBullseye(t *testing.T) {